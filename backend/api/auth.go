@@ -0,0 +1,140 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+
+	"github.com/liferip/stock-analyzer/backend/api/problem"
+	"github.com/liferip/stock-analyzer/backend/internal/auth"
+	"github.com/liferip/stock-analyzer/backend/internal/ratelimit"
+)
+
+const (
+	// scopeRoutePrefix identifica las rutas que declaran el scope que requieren
+	scopeRoutePrefix = "scope:"
+	// queryTokenRoutePrefix identifica las rutas que aceptan la credencial en access_token
+	queryTokenRoutePrefix = "query-token:"
+)
+
+// RequireScope declara el scope que requiere una ruta, en lugar del que corresponde a su método
+func RequireScope(route *mux.Route, scope auth.Scope) *mux.Route {
+	template, _ := route.GetPathTemplate()
+	return route.Name(scopeRoutePrefix + string(scope) + " " + template)
+}
+
+// AllowQueryToken permite enviar la credencial de una ruta GET en el parámetro access_token,
+// para EventSource y WebSocket, que no permiten enviar headers desde el navegador. Las demás
+// rutas lo ignoran, para no exponer credenciales en URLs, historiales y logs de proxies.
+func AllowQueryToken(route *mux.Route) *mux.Route {
+	template, _ := route.GetPathTemplate()
+	return route.Name(queryTokenRoutePrefix + template)
+}
+
+// authMiddleware autentica cada solicitud con una API key o un token JWT y exige el scope
+// de la ruta: read:stocks para GET y HEAD y admin para los métodos de escritura, salvo que
+// la ruta declare otro con RequireScope. Las IPs que superan el límite de credenciales
+// inválidas reciben 429 sin volver a autenticar, para no consultar la base de datos.
+func authMiddleware(authenticator *auth.Authenticator, limiter *ratelimit.Limiter, trustForwarded bool, logger *zap.Logger) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Las solicitudes preflight de CORS no llevan credenciales
+			if r.Method == http.MethodOptions {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			principal := authenticator.Anonymous()
+			if credential := requestCredential(r); credential != "" {
+				client := "ip:" + clientIP(r, trustForwarded)
+				if result, ok := limiter.CheckFailures(client); ok && !result.Allowed {
+					logger.Warn("Too many authentication failures",
+						zap.String("client", client),
+						zap.String("path", r.URL.Path))
+					respondRateLimited(w, r, result)
+					return
+				}
+
+				var err error
+				principal, err = authenticator.Authenticate(r.Context(), credential)
+				if errors.Is(err, auth.ErrUnauthenticated) {
+					limiter.RecordFailure(client)
+					logger.Debug("Authentication failed",
+						zap.String("path", r.URL.Path),
+						zap.Error(err))
+					respondUnauthorized(w, r, "Invalid credentials")
+					return
+				}
+				if err != nil {
+					logger.Error("Error authenticating request", zap.Error(err))
+					respondWithError(w, r, http.StatusInternalServerError, problem.CodeInternal, "Error authenticating request")
+					return
+				}
+			}
+			if principal == nil {
+				respondUnauthorized(w, r, "Missing credentials")
+				return
+			}
+
+			if required := requiredScope(r); !principal.Allows(required) {
+				respondWithError(w, r, http.StatusForbidden, problem.CodeForbidden, "This endpoint requires the "+string(required)+" scope")
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(auth.WithPrincipal(r.Context(), principal)))
+		})
+	}
+}
+
+// requestCredential obtiene la credencial de los headers X-API-Key o Authorization: Bearer.
+// En las solicitudes GET a rutas declaradas con AllowQueryToken también se acepta el
+// parámetro access_token.
+func requestCredential(r *http.Request) string {
+	if key := r.Header.Get("X-API-Key"); key != "" {
+		return key
+	}
+
+	if header := r.Header.Get("Authorization"); header != "" {
+		scheme, credential, ok := strings.Cut(header, " ")
+		if ok && strings.EqualFold(scheme, "Bearer") {
+			return strings.TrimSpace(credential)
+		}
+		return ""
+	}
+
+	if r.Method == http.MethodGet && allowsQueryToken(r) {
+		return r.URL.Query().Get("access_token")
+	}
+	return ""
+}
+
+// allowsQueryToken indica si la ruta de la solicitud se declaró con AllowQueryToken
+func allowsQueryToken(r *http.Request) bool {
+	route := mux.CurrentRoute(r)
+	return route != nil && strings.HasPrefix(route.GetName(), queryTokenRoutePrefix)
+}
+
+// requiredScope obtiene el scope requerido por una solicitud según su ruta y su método
+func requiredScope(r *http.Request) auth.Scope {
+	if route := mux.CurrentRoute(r); route != nil {
+		if name, ok := strings.CutPrefix(route.GetName(), scopeRoutePrefix); ok {
+			scope, _, _ := strings.Cut(name, " ")
+			return auth.Scope(scope)
+		}
+	}
+
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		return auth.ScopeReadStocks
+	}
+	return auth.ScopeAdmin
+}
+
+// respondUnauthorized envía un 401 indicando los esquemas de autenticación aceptados
+func respondUnauthorized(w http.ResponseWriter, r *http.Request, message string) {
+	w.Header().Set("WWW-Authenticate", `Bearer realm="stock-analyzer"`)
+	respondWithError(w, r, http.StatusUnauthorized, problem.CodeUnauthenticated, message)
+}
@@ -0,0 +1,119 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+
+	"github.com/liferip/stock-analyzer/backend/api/problem"
+	"github.com/liferip/stock-analyzer/backend/internal/cors"
+)
+
+// corsMiddleware agrega los headers CORS de la política a las respuestas de los orígenes
+// aceptados. Las solicitudes preflight las responde preflightHandler.
+func corsMiddleware(policy *cors.Policy) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		if !policy.Enabled() {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !isPreflight(r) {
+				policy.SetHeaders(w.Header(), r.Header.Get("Origin"))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// preflightHandler responde las solicitudes OPTIONS. Una solicitud preflight solo se acepta
+// si el origen, el método y los headers cumplen la política y alguna ruta atiende el método
+// pedido; las rutas inexistentes responden 404 y los métodos que la ruta no atiende, 405.
+func preflightHandler(router *mux.Router, policy *cors.Policy, logger *zap.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		allowed := AllowedMethods(router, r)
+		if len(allowed) == 0 {
+			respondWithError(w, r, http.StatusNotFound, problem.CodeNotFound, "No route matches "+r.URL.Path)
+			return
+		}
+		w.Header().Set("Allow", strings.Join(append(allowed, http.MethodOptions), ", "))
+
+		// Una solicitud OPTIONS que no es preflight solo consulta los métodos de la ruta
+		if !isPreflight(r) {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		// La respuesta depende de estos headers, aunque se rechace
+		w.Header().Add("Vary", "Origin")
+		w.Header().Add("Vary", "Access-Control-Request-Method")
+		w.Header().Add("Vary", "Access-Control-Request-Headers")
+
+		origin := r.Header.Get("Origin")
+		method := r.Header.Get("Access-Control-Request-Method")
+		headers := r.Header.Get("Access-Control-Request-Headers")
+
+		if !containsMethod(allowed, method) {
+			respondWithError(w, r, http.StatusMethodNotAllowed, problem.CodeMethodNotAllowed,
+				method+" is not allowed on "+r.URL.Path)
+			return
+		}
+
+		if !policy.AllowOrigin(origin) || !policy.AllowMethod(method) || !policy.AllowHeaders(headers) {
+			logger.Debug("CORS preflight rejected",
+				zap.String("origin", origin),
+				zap.String("method", method),
+				zap.String("headers", headers),
+				zap.String("path", r.URL.Path))
+			respondWithError(w, r, http.StatusForbidden, problem.CodeForbidden,
+				"Cross-origin request from "+origin+" is not allowed by the CORS policy")
+			return
+		}
+
+		policy.SetPreflightHeaders(w.Header(), origin, headers)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// isPreflight indica si una solicitud es una preflight de CORS
+func isPreflight(r *http.Request) bool {
+	return r.Method == http.MethodOptions &&
+		r.Header.Get("Origin") != "" &&
+		r.Header.Get("Access-Control-Request-Method") != ""
+}
+
+// AllowedMethods obtiene los métodos de las rutas del router que coinciden con la ruta de la
+// solicitud, sin OPTIONS, que atiende preflightHandler
+func AllowedMethods(router *mux.Router, r *http.Request) []string {
+	var allowed []string
+	router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		methods, err := route.GetMethods()
+		if err != nil {
+			return nil
+		}
+		for _, method := range methods {
+			if method == http.MethodOptions || containsMethod(allowed, method) {
+				continue
+			}
+			candidate := r.Clone(r.Context())
+			candidate.Method = method
+			if route.Match(candidate, &mux.RouteMatch{}) {
+				allowed = append(allowed, method)
+			}
+		}
+		return nil
+	})
+	return allowed
+}
+
+// containsMethod indica si un método está en la lista
+func containsMethod(methods []string, method string) bool {
+	for _, m := range methods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
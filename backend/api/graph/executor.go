@@ -0,0 +1,106 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"github.com/liferip/stock-analyzer/backend/config"
+	"github.com/liferip/stock-analyzer/backend/internal/service"
+)
+
+// Module proporciona las dependencias de la API GraphQL
+var Module = fx.Provide(NewExecutor)
+
+// Request representa una consulta GraphQL recibida por HTTP
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Executor valida y ejecuta consultas GraphQL sobre el esquema de stocks,
+// rechazando las que superan los límites de profundidad o complejidad
+type Executor struct {
+	schema        graphql.Schema
+	maxDepth      int
+	maxComplexity int
+	logger        *zap.Logger
+}
+
+// NewExecutor crea una nueva instancia de Executor
+func NewExecutor(
+	cfg *config.Config,
+	stockService service.StockService,
+	logger *zap.Logger,
+) (*Executor, error) {
+	logger = logger.Named("graphql")
+
+	schema, err := newSchema(&resolver{
+		stockService: stockService,
+		logger:       logger,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("building GraphQL schema: %w", err)
+	}
+
+	return &Executor{
+		schema:        schema,
+		maxDepth:      cfg.GraphQLMaxDepth,
+		maxComplexity: cfg.GraphQLMaxComplexity,
+		logger:        logger,
+	}, nil
+}
+
+// Execute ejecuta una consulta. Devuelve false si la consulta se rechazó antes de ejecutarse
+// por errores de sintaxis, de validación o por superar los límites.
+func (e *Executor) Execute(ctx context.Context, req Request) (*graphql.Result, bool) {
+	document, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource(&source.Source{
+			Body: []byte(req.Query),
+			Name: "GraphQL request",
+		}),
+	})
+	if err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}, false
+	}
+
+	validation := graphql.ValidateDocument(&e.schema, document, nil)
+	if !validation.IsValid {
+		return &graphql.Result{Errors: validation.Errors}, false
+	}
+
+	cost, err := analyzeQuery(&e.schema, document, req.OperationName, req.Variables)
+	if err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}, false
+	}
+	if e.maxDepth > 0 && cost.Depth > e.maxDepth {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(
+			fmt.Errorf("query depth %d exceeds the maximum of %d", cost.Depth, e.maxDepth),
+		)}, false
+	}
+	if e.maxComplexity > 0 && cost.Complexity > e.maxComplexity {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(
+			fmt.Errorf("query complexity %d exceeds the maximum of %d", cost.Complexity, e.maxComplexity),
+		)}, false
+	}
+
+	e.logger.Debug("Executing GraphQL query",
+		zap.String("operation", req.OperationName),
+		zap.Int("depth", cost.Depth),
+		zap.Int("complexity", cost.Complexity))
+
+	return graphql.Execute(graphql.ExecuteParams{
+		Schema:        e.schema,
+		AST:           document,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       ctx,
+	}), true
+}
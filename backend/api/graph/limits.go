@@ -0,0 +1,191 @@
+package graph
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// queryCost contiene la profundidad y la complejidad calculadas de una operación
+type queryCost struct {
+	Depth      int
+	Complexity int
+}
+
+// costAnalyzer calcula la profundidad y la complejidad de una operación ya validada.
+// Cada campo cuesta 1 y el costo de sus hijos se multiplica por el argumento first
+// (o su valor por defecto), que es la cantidad máxima de elementos que puede devolver.
+type costAnalyzer struct {
+	schema    *graphql.Schema
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]interface{}
+}
+
+// analyzeQuery calcula el costo de la operación indicada del documento
+func analyzeQuery(
+	schema *graphql.Schema,
+	document *ast.Document,
+	operationName string,
+	variables map[string]interface{},
+) (queryCost, error) {
+	analyzer := &costAnalyzer{
+		schema:    schema,
+		fragments: make(map[string]*ast.FragmentDefinition),
+	}
+
+	var operation *ast.OperationDefinition
+	for _, definition := range document.Definitions {
+		switch definition := definition.(type) {
+		case *ast.FragmentDefinition:
+			analyzer.fragments[definition.Name.Value] = definition
+		case *ast.OperationDefinition:
+			name := ""
+			if definition.Name != nil {
+				name = definition.Name.Value
+			}
+			if operationName == "" || name == operationName {
+				if operation != nil && operationName == "" {
+					return queryCost{}, fmt.Errorf("must provide operation name if query contains multiple operations")
+				}
+				operation = definition
+			}
+		}
+	}
+	if operation == nil {
+		return queryCost{}, fmt.Errorf("unknown operation named %q", operationName)
+	}
+
+	// Usar los valores por defecto de las variables que no se enviaron
+	analyzer.variables = make(map[string]interface{}, len(variables))
+	for name, value := range variables {
+		analyzer.variables[name] = value
+	}
+	for _, definition := range operation.VariableDefinitions {
+		name := definition.Variable.Name.Value
+		if _, ok := analyzer.variables[name]; !ok && definition.DefaultValue != nil {
+			if n := analyzer.intValue(definition.DefaultValue, -1); n >= 0 {
+				analyzer.variables[name] = n
+			}
+		}
+	}
+
+	var root *graphql.Object
+	switch operation.Operation {
+	case ast.OperationTypeQuery:
+		root = schema.QueryType()
+	default:
+		return queryCost{}, fmt.Errorf("operation %q is not supported", operation.Operation)
+	}
+
+	depth, complexity := analyzer.selectionSet(operation.SelectionSet, root)
+	return queryCost{Depth: depth, Complexity: complexity}, nil
+}
+
+// selectionSet devuelve la profundidad y la complejidad de un conjunto de selecciones sobre un tipo
+func (a *costAnalyzer) selectionSet(set *ast.SelectionSet, parent graphql.Named) (int, int) {
+	if set == nil {
+		return 0, 0
+	}
+
+	maxDepth, total := 0, 0
+	for _, selection := range set.Selections {
+		var depth, complexity int
+
+		switch selection := selection.(type) {
+		case *ast.Field:
+			depth, complexity = a.field(selection, parent)
+		case *ast.InlineFragment:
+			typ := parent
+			if selection.TypeCondition != nil {
+				if named, ok := a.schema.Type(selection.TypeCondition.Name.Value).(graphql.Named); ok {
+					typ = named
+				}
+			}
+			depth, complexity = a.selectionSet(selection.SelectionSet, typ)
+		case *ast.FragmentSpread:
+			fragment, ok := a.fragments[selection.Name.Value]
+			if !ok {
+				continue
+			}
+			typ := parent
+			if named, ok := a.schema.Type(fragment.TypeCondition.Name.Value).(graphql.Named); ok {
+				typ = named
+			}
+			depth, complexity = a.selectionSet(fragment.SelectionSet, typ)
+		}
+
+		if depth > maxDepth {
+			maxDepth = depth
+		}
+		total += complexity
+	}
+
+	return maxDepth, total
+}
+
+// field devuelve la profundidad y la complejidad de un campo y sus hijos
+func (a *costAnalyzer) field(field *ast.Field, parent graphql.Named) (int, int) {
+	// Los campos de introspección no consultan la base de datos
+	if strings.HasPrefix(field.Name.Value, "__") {
+		return 0, 0
+	}
+
+	object, ok := parent.(*graphql.Object)
+	if !ok {
+		return 1, 1
+	}
+	definition, ok := object.Fields()[field.Name.Value]
+	if !ok {
+		return 1, 1
+	}
+
+	childDepth, childComplexity := a.selectionSet(field.SelectionSet, graphql.GetNamed(definition.Type))
+	return 1 + childDepth, 1 + a.multiplier(field, definition)*childComplexity
+}
+
+// multiplier obtiene la cantidad de elementos que puede devolver un campo paginado, 1 si no lo es
+func (a *costAnalyzer) multiplier(field *ast.Field, definition *graphql.FieldDefinition) int {
+	for _, arg := range definition.Args {
+		if arg.Name() != firstArg {
+			continue
+		}
+
+		first, _ := arg.DefaultValue.(int)
+		for _, value := range field.Arguments {
+			if value.Name.Value == firstArg {
+				first = a.intValue(value.Value, first)
+			}
+		}
+
+		if first < 0 {
+			return 0
+		}
+		if first > maxPageSize {
+			return maxPageSize
+		}
+		return first
+	}
+
+	return 1
+}
+
+// intValue obtiene el valor entero de un literal o variable, o el valor por defecto
+func (a *costAnalyzer) intValue(value ast.Value, defaultValue int) int {
+	switch value := value.(type) {
+	case *ast.IntValue:
+		if n, err := strconv.Atoi(value.Value); err == nil {
+			return n
+		}
+	case *ast.Variable:
+		switch n := a.variables[value.Name.Value].(type) {
+		case int:
+			return n
+		case float64:
+			return int(n)
+		}
+	}
+	return defaultValue
+}
@@ -0,0 +1,134 @@
+package graph
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+	"go.uber.org/zap"
+)
+
+func TestAnalyzeQuery(t *testing.T) {
+	schema, err := newSchema(&resolver{logger: zap.NewNop()})
+	if err != nil {
+		t.Fatalf("newSchema returned error: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		query         string
+		operationName string
+		variables     map[string]interface{}
+		want          queryCost
+		wantErr       string
+	}{
+		{
+			name:  "default page size",
+			query: `{ stocks { total } }`,
+			want:  queryCost{Depth: 2, Complexity: 21},
+		},
+		{
+			name:  "explicit first",
+			query: `{ stocks(first: 5) { items { ticker } } }`,
+			want:  queryCost{Depth: 3, Complexity: 11},
+		},
+		{
+			name:  "first above the maximum page size",
+			query: `{ stocks(first: 500) { total } }`,
+			want:  queryCost{Depth: 2, Complexity: 101},
+		},
+		{
+			name:  "nested pages multiply",
+			query: `{ brokerages(first: 2) { items { stocks(first: 3) { total } } } }`,
+			want:  queryCost{Depth: 4, Complexity: 11},
+		},
+		{
+			name:  "introspection fields are free",
+			query: `{ __typename stocks(first: 1) { total } }`,
+			want:  queryCost{Depth: 2, Complexity: 2},
+		},
+		{
+			name: "fragment spread",
+			query: `{ stocks(first: 5) { ...page } }
+				fragment page on StockPage { total items { ticker } }`,
+			want: queryCost{Depth: 3, Complexity: 16},
+		},
+		{
+			name: "nested fragment spreads",
+			query: `{ stocks(first: 5) { ...page } }
+				fragment page on StockPage { items { ...fields } }
+				fragment fields on Stock { ticker company }`,
+			want: queryCost{Depth: 3, Complexity: 16},
+		},
+		{
+			name:  "inline fragment",
+			query: `{ stocks(first: 5) { ... on StockPage { total items { ticker } } } }`,
+			want:  queryCost{Depth: 3, Complexity: 16},
+		},
+		{
+			name:  "variable default value",
+			query: `query Stocks($first: Int = 3) { stocks(first: $first) { total } }`,
+			want:  queryCost{Depth: 2, Complexity: 4},
+		},
+		{
+			name:      "variable overrides its default value",
+			query:     `query Stocks($first: Int = 3) { stocks(first: $first) { total } }`,
+			variables: map[string]interface{}{"first": 10},
+			want:      queryCost{Depth: 2, Complexity: 11},
+		},
+		{
+			name:      "variable decoded from JSON",
+			query:     `query Stocks($first: Int) { stocks(first: $first) { total } }`,
+			variables: map[string]interface{}{"first": float64(7)},
+			want:      queryCost{Depth: 2, Complexity: 8},
+		},
+		{
+			name:  "variable without value uses the argument default",
+			query: `query Stocks($first: Int) { stocks(first: $first) { total } }`,
+			want:  queryCost{Depth: 2, Complexity: 21},
+		},
+		{
+			name:          "selected operation",
+			query:         `query Small { stocks(first: 1) { total } } query Large { stocks(first: 50) { total } }`,
+			operationName: "Large",
+			want:          queryCost{Depth: 2, Complexity: 51},
+		},
+		{
+			name:    "multiple operations without a name",
+			query:   `query Small { stocks(first: 1) { total } } query Large { stocks(first: 50) { total } }`,
+			wantErr: "must provide operation name",
+		},
+		{
+			name:          "unknown operation",
+			query:         `query Small { stocks(first: 1) { total } }`,
+			operationName: "Large",
+			wantErr:       `unknown operation named "Large"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			document, err := parser.Parse(parser.ParseParams{
+				Source: source.NewSource(&source.Source{Body: []byte(tt.query)}),
+			})
+			if err != nil {
+				t.Fatalf("parsing query: %v", err)
+			}
+
+			got, err := analyzeQuery(&schema, document, tt.operationName, tt.variables)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("analyzeQuery() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("analyzeQuery() returned error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("analyzeQuery() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
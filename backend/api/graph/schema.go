@@ -0,0 +1,380 @@
+package graph
+
+import (
+	"context"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"go.uber.org/zap"
+
+	"github.com/liferip/stock-analyzer/backend/internal/models"
+	"github.com/liferip/stock-analyzer/backend/internal/service"
+)
+
+const (
+	// defaultPageSize es la cantidad de elementos devueltos cuando no se indica first
+	defaultPageSize = 20
+	// maxPageSize es la cantidad máxima de elementos que se pueden pedir con first
+	maxPageSize = 100
+	// firstArg es el argumento de paginación que multiplica la complejidad de los campos hijos
+	firstArg = "first"
+)
+
+// resolver contiene las dependencias usadas por los resolvers del esquema
+type resolver struct {
+	stockService service.StockService
+	logger       *zap.Logger
+}
+
+// page representa una página de resultados con el total sin paginar
+type page struct {
+	Total int64       `json:"total"`
+	Items interface{} `json:"items"`
+}
+
+// pageArgs devuelve los argumentos de paginación con sus valores por defecto
+func pageArgs(defaultFirst int) graphql.FieldConfigArgument {
+	return graphql.FieldConfigArgument{
+		firstArg: &graphql.ArgumentConfig{
+			Type:         graphql.Int,
+			DefaultValue: defaultFirst,
+			Description:  "Maximum number of items to return (at most 100)",
+		},
+		"offset": &graphql.ArgumentConfig{
+			Type:         graphql.Int,
+			DefaultValue: 0,
+			Description:  "Number of items to skip",
+		},
+	}
+}
+
+// pagination obtiene first y offset de los argumentos, limitando first al máximo permitido
+func pagination(args map[string]interface{}) (int, int) {
+	first, _ := args[firstArg].(int)
+	offset, _ := args["offset"].(int)
+
+	if first < 0 {
+		first = 0
+	}
+	if first > maxPageSize {
+		first = maxPageSize
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return first, offset
+}
+
+// pageType crea el tipo de una página de elementos del tipo indicado
+func pageType(name string, itemType graphql.Type) *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: name,
+		Fields: graphql.Fields{
+			"total": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Int),
+				Description: "Total number of items matching the filters",
+			},
+			"items": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(itemType))),
+			},
+		},
+	})
+}
+
+// newSchema construye el esquema GraphQL sobre el servicio de stocks
+func newSchema(r *resolver) (graphql.Schema, error) {
+	scoreFactorType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "ScoreFactor",
+		Description: "Contribution of one factor to a recommendation score",
+		Fields: graphql.Fields{
+			"factor":       &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"input":        &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"value":        &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+			"weight":       &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+			"contribution": &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+		},
+	})
+
+	ratingEventType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "RatingEvent",
+		Description: "A rating change recorded from the upstream API",
+		Fields: graphql.Fields{
+			"id":         &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"ticker":     &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"company":    &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"brokerage":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"action":     &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"ratingFrom": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"ratingTo":   &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"targetFrom": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"targetTo":   &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"time":       &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+		},
+	})
+	ratingEventPageType := pageType("RatingEventPage", ratingEventType)
+
+	// Stock y Recommendation se referencian entre sí, por eso los campos se agregan después
+	recommendationType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Recommendation",
+		Description: "Investment recommendation computed for a stock",
+		Fields: graphql.Fields{
+			"score":        &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+			"reasons":      &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String)))},
+			"breakdown":    &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(scoreFactorType)))},
+			"currentPrice": &graphql.Field{Type: graphql.Float},
+			"potentialUp":  &graphql.Field{Type: graphql.Float, Description: "Percent upside from the latest close to the target"},
+			"aboveTarget":  &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+			"targetChange": &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+		},
+	})
+
+	stockType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Stock",
+		Description: "Latest rating record of a ticker",
+		Fields: graphql.Fields{
+			"id":         &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"ticker":     &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"company":    &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"brokerage":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"action":     &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"ratingFrom": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"ratingTo":   &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"targetFrom": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"targetTo":   &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"time":       &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"history": &graphql.Field{
+				Type:        graphql.NewNonNull(ratingEventPageType),
+				Description: "Rating changes recorded for the ticker, newest first",
+				Args:        pageArgs(defaultPageSize),
+				Resolve:     r.stockHistory,
+			},
+			"recommendation": &graphql.Field{
+				Type:        graphql.NewNonNull(recommendationType),
+				Description: "Recommendation computed from this record and the latest close",
+				Resolve:     r.stockRecommendation,
+			},
+		},
+	})
+	recommendationType.AddFieldConfig("stock", &graphql.Field{Type: graphql.NewNonNull(stockType)})
+	stockPageType := pageType("StockPage", stockType)
+
+	brokerageType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Brokerage",
+		Description: "Brokerage with its activity in the rating history",
+		Fields: graphql.Fields{
+			"name":        &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"eventCount":  &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"tickerCount": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"lastEventAt": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"stocks": &graphql.Field{
+				Type:        graphql.NewNonNull(stockPageType),
+				Description: "Stocks whose latest record comes from this brokerage",
+				Args:        pageArgs(defaultPageSize),
+				Resolve:     r.brokerageStocks,
+			},
+		},
+	})
+	brokeragePageType := pageType("BrokeragePage", brokerageType)
+
+	sortType := graphql.NewEnum(graphql.EnumConfig{
+		Name: "RecommendationSort",
+		Values: graphql.EnumValueConfigMap{
+			"SCORE":  &graphql.EnumValueConfig{Value: string(service.SortByScore), Description: "Highest score first"},
+			"UPSIDE": &graphql.EnumValueConfig{Value: string(service.SortByUpside), Description: "Highest upside from the latest close first"},
+		},
+	})
+
+	stocksArgs := pageArgs(defaultPageSize)
+	stocksArgs["ticker"] = &graphql.ArgumentConfig{Type: graphql.String}
+	stocksArgs["brokerage"] = &graphql.ArgumentConfig{Type: graphql.String}
+
+	ratingEventsArgs := pageArgs(defaultPageSize)
+	ratingEventsArgs["ticker"] = &graphql.ArgumentConfig{Type: graphql.String}
+	ratingEventsArgs["brokerage"] = &graphql.ArgumentConfig{Type: graphql.String}
+	ratingEventsArgs["from"] = &graphql.ArgumentConfig{Type: graphql.DateTime, Description: "Only events at or after this time"}
+	ratingEventsArgs["to"] = &graphql.ArgumentConfig{Type: graphql.DateTime, Description: "Only events at or before this time"}
+
+	queryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"stocks": &graphql.Field{
+				Type:        graphql.NewNonNull(stockPageType),
+				Description: "Latest record of each ticker, newest first",
+				Args:        stocksArgs,
+				Resolve:     r.stocks,
+			},
+			"stock": &graphql.Field{
+				Type:        stockType,
+				Description: "Latest record of a ticker, or null if it is unknown",
+				Args: graphql.FieldConfigArgument{
+					"ticker": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: r.stock,
+			},
+			"ratingEvents": &graphql.Field{
+				Type:        graphql.NewNonNull(ratingEventPageType),
+				Description: "Rating change history, newest first",
+				Args:        ratingEventsArgs,
+				Resolve:     r.ratingEvents,
+			},
+			"brokerages": &graphql.Field{
+				Type:        graphql.NewNonNull(brokeragePageType),
+				Description: "Brokerages ordered by number of rating changes",
+				Args:        pageArgs(defaultPageSize),
+				Resolve:     r.brokerages,
+			},
+			"recommendations": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(recommendationType))),
+				Description: "Top recommendations",
+				Args: graphql.FieldConfigArgument{
+					"sort": &graphql.ArgumentConfig{Type: sortType, DefaultValue: string(service.SortByScore)},
+					firstArg: &graphql.ArgumentConfig{
+						Type:         graphql.Int,
+						DefaultValue: defaultPageSize,
+						Description:  "Maximum number of recommendations to return (at most 100)",
+					},
+				},
+				Resolve: r.recommendations,
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: queryType})
+}
+
+// stocks resuelve Query.stocks
+func (r *resolver) stocks(p graphql.ResolveParams) (interface{}, error) {
+	first, offset := pagination(p.Args)
+	ticker, _ := p.Args["ticker"].(string)
+	brokerage, _ := p.Args["brokerage"].(string)
+
+	return r.findStocks(p.Context, models.StockFilter{
+		Ticker:    ticker,
+		Brokerage: brokerage,
+		Limit:     first,
+		Offset:    offset,
+	})
+}
+
+// stock resuelve Query.stock
+func (r *resolver) stock(p graphql.ResolveParams) (interface{}, error) {
+	ticker, _ := p.Args["ticker"].(string)
+
+	stock, err := r.stockService.GetStockByTicker(p.Context, ticker)
+	if err != nil {
+		r.logger.Error("Error getting stock", zap.String("ticker", ticker), zap.Error(err))
+		return nil, err
+	}
+	if stock == nil {
+		return nil, nil
+	}
+	return *stock, nil
+}
+
+// ratingEvents resuelve Query.ratingEvents
+func (r *resolver) ratingEvents(p graphql.ResolveParams) (interface{}, error) {
+	first, offset := pagination(p.Args)
+	filter := models.RatingEventFilter{Limit: first, Offset: offset}
+	filter.Ticker, _ = p.Args["ticker"].(string)
+	filter.Brokerage, _ = p.Args["brokerage"].(string)
+	filter.From, _ = p.Args["from"].(time.Time)
+	filter.To, _ = p.Args["to"].(time.Time)
+
+	return r.findRatingEvents(p.Context, filter)
+}
+
+// brokerages resuelve Query.brokerages
+func (r *resolver) brokerages(p graphql.ResolveParams) (interface{}, error) {
+	first, offset := pagination(p.Args)
+
+	brokerages, err := r.stockService.GetBrokerages(p.Context)
+	if err != nil {
+		r.logger.Error("Error getting brokerages", zap.Error(err))
+		return nil, err
+	}
+
+	total := len(brokerages)
+	if offset > total {
+		offset = total
+	}
+	end := offset + first
+	if end > total {
+		end = total
+	}
+
+	return page{Total: int64(total), Items: brokerages[offset:end]}, nil
+}
+
+// recommendations resuelve Query.recommendations
+func (r *resolver) recommendations(p graphql.ResolveParams) (interface{}, error) {
+	first, _ := pagination(p.Args)
+	sort, _ := p.Args["sort"].(string)
+
+	sortBy, err := service.ParseRecommendationSort(sort)
+	if err != nil {
+		return nil, err
+	}
+
+	recommendations, err := r.stockService.GetTopRecommendations(p.Context, sortBy, first)
+	if err != nil {
+		r.logger.Error("Error getting recommendations", zap.Error(err))
+		return nil, err
+	}
+	return recommendations, nil
+}
+
+// stockHistory resuelve Stock.history
+func (r *resolver) stockHistory(p graphql.ResolveParams) (interface{}, error) {
+	stock := p.Source.(models.Stock)
+	first, offset := pagination(p.Args)
+
+	return r.findRatingEvents(p.Context, models.RatingEventFilter{
+		Ticker: stock.Ticker,
+		Limit:  first,
+		Offset: offset,
+	})
+}
+
+// stockRecommendation resuelve Stock.recommendation
+func (r *resolver) stockRecommendation(p graphql.ResolveParams) (interface{}, error) {
+	stock := p.Source.(models.Stock)
+
+	recommendation, err := r.stockService.ScoreStock(p.Context, &stock)
+	if err != nil {
+		r.logger.Error("Error scoring stock", zap.String("ticker", stock.Ticker), zap.Error(err))
+		return nil, err
+	}
+	return recommendation, nil
+}
+
+// brokerageStocks resuelve Brokerage.stocks
+func (r *resolver) brokerageStocks(p graphql.ResolveParams) (interface{}, error) {
+	brokerage := p.Source.(models.BrokerageSummary)
+	first, offset := pagination(p.Args)
+
+	return r.findStocks(p.Context, models.StockFilter{
+		Brokerage: brokerage.Name,
+		Limit:     first,
+		Offset:    offset,
+	})
+}
+
+// findStocks obtiene una página de stocks
+func (r *resolver) findStocks(ctx context.Context, filter models.StockFilter) (interface{}, error) {
+	stocks, total, err := r.stockService.FindStocks(ctx, filter)
+	if err != nil {
+		r.logger.Error("Error finding stocks", zap.Error(err))
+		return nil, err
+	}
+	return page{Total: total, Items: stocks}, nil
+}
+
+// findRatingEvents obtiene una página del historial de calificaciones
+func (r *resolver) findRatingEvents(ctx context.Context, filter models.RatingEventFilter) (interface{}, error) {
+	events, total, err := r.stockService.GetRatingHistory(ctx, filter)
+	if err != nil {
+		r.logger.Error("Error finding rating events", zap.Error(err))
+		return nil, err
+	}
+	return page{Total: total, Items: events}, nil
+}
@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+
+	"github.com/liferip/stock-analyzer/backend/internal/models"
+	"github.com/liferip/stock-analyzer/backend/internal/service"
+	"github.com/liferip/stock-analyzer/backend/pkg/logger"
+)
+
+// AlertHandler maneja las solicitudes de reglas y eventos de alerta
+type AlertHandler struct {
+	alertService service.AlertService
+	logger       *zap.Logger
+}
+
+// NewAlertHandler crea una nueva instancia de AlertHandler
+func NewAlertHandler(
+	alertService service.AlertService,
+	logger *zap.Logger,
+) *AlertHandler {
+	return &AlertHandler{
+		alertService: alertService,
+		logger:       logger.Named("alert_handler"),
+	}
+}
+
+// @Summary		List alert rules
+// @Description	Retrieves all alert rules, enabled or not
+// @Tags			alerts
+// @Accept			json
+// @Produce		json
+// @Success		200	{object}	map[string][]models.AlertRule
+// @Failure		500	{object}	map[string]string	"Error getting alert rules"
+// @Router			/alerts/rules [get]
+func (h *AlertHandler) ListRules(w http.ResponseWriter, r *http.Request) {
+	rules, err := h.alertService.ListRules(r.Context())
+	if err != nil {
+		logger.Ctx(r.Context(), h.logger).Error("Error getting alert rules", zap.Error(err))
+		respondWithError(w, http.StatusInternalServerError, "Error getting alert rules")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"items": rules,
+	})
+}
+
+// @Summary		Get alert rule
+// @Description	Retrieves an alert rule by its ID
+// @Tags			alerts
+// @Accept			json
+// @Produce		json
+// @Param			id	path		string	true	"Rule ID"
+// @Success		200	{object}	map[string]models.AlertRule
+// @Failure		404	{object}	map[string]string	"Alert rule not found"
+// @Failure		500	{object}	map[string]string	"Error getting alert rule"
+// @Router			/alerts/rules/{id} [get]
+func (h *AlertHandler) GetRule(w http.ResponseWriter, r *http.Request) {
+	rule, err := h.alertService.GetRule(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		if respondWithServiceError(w, err) {
+			return
+		}
+		logger.Ctx(r.Context(), h.logger).Error("Error getting alert rule", zap.Error(err))
+		respondWithError(w, http.StatusInternalServerError, "Error getting alert rule")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"item": rule,
+	})
+}
+
+// @Summary		Create alert rule
+// @Description	Creates an alert rule. All given conditions must match; empty lists match any value. Rules are enabled by default
+// @Tags			alerts
+// @Accept			json
+// @Produce		json
+// @Param			rule	body		models.AlertRuleInput	true	"Rule conditions"
+// @Success		201		{object}	map[string]models.AlertRule
+// @Failure		400		{object}	map[string]string	"Invalid alert rule"
+// @Failure		500		{object}	map[string]string	"Error creating alert rule"
+// @Router			/alerts/rules [post]
+func (h *AlertHandler) CreateRule(w http.ResponseWriter, r *http.Request) {
+	var input models.AlertRuleInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	rule, err := h.alertService.CreateRule(r.Context(), input)
+	if err != nil {
+		if respondWithServiceError(w, err) {
+			return
+		}
+		logger.Ctx(r.Context(), h.logger).Error("Error creating alert rule", zap.Error(err))
+		respondWithError(w, http.StatusInternalServerError, "Error creating alert rule")
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, map[string]interface{}{
+		"item": rule,
+	})
+}
+
+// @Summary		Update alert rule
+// @Description	Replaces the name and conditions of an alert rule; enabled is kept when omitted
+// @Tags			alerts
+// @Accept			json
+// @Produce		json
+// @Param			id		path		string					true	"Rule ID"
+// @Param			rule	body		models.AlertRuleInput	true	"Rule conditions"
+// @Success		200		{object}	map[string]models.AlertRule
+// @Failure		400		{object}	map[string]string	"Invalid alert rule"
+// @Failure		404		{object}	map[string]string	"Alert rule not found"
+// @Failure		500		{object}	map[string]string	"Error updating alert rule"
+// @Router			/alerts/rules/{id} [put]
+func (h *AlertHandler) UpdateRule(w http.ResponseWriter, r *http.Request) {
+	var input models.AlertRuleInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	rule, err := h.alertService.UpdateRule(r.Context(), mux.Vars(r)["id"], input)
+	if err != nil {
+		if respondWithServiceError(w, err) {
+			return
+		}
+		logger.Ctx(r.Context(), h.logger).Error("Error updating alert rule", zap.Error(err))
+		respondWithError(w, http.StatusInternalServerError, "Error updating alert rule")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"item": rule,
+	})
+}
+
+// @Summary		Delete alert rule
+// @Description	Deletes an alert rule; alerts it already triggered are kept
+// @Tags			alerts
+// @Accept			json
+// @Produce		json
+// @Param			id	path	string	true	"Rule ID"
+// @Success		204
+// @Failure		404	{object}	map[string]string	"Alert rule not found"
+// @Failure		500	{object}	map[string]string	"Error deleting alert rule"
+// @Router			/alerts/rules/{id} [delete]
+func (h *AlertHandler) DeleteRule(w http.ResponseWriter, r *http.Request) {
+	if err := h.alertService.DeleteRule(r.Context(), mux.Vars(r)["id"]); err != nil {
+		if respondWithServiceError(w, err) {
+			return
+		}
+		logger.Ctx(r.Context(), h.logger).Error("Error deleting alert rule", zap.Error(err))
+		respondWithError(w, http.StatusInternalServerError, "Error deleting alert rule")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary		List triggered alerts
+// @Description	Retrieves the alerts triggered during sync, newest first
+// @Tags			alerts
+// @Accept			json
+// @Produce		json
+// @Param			rule_id	query		string	false	"Filter by rule ID"
+// @Param			ticker	query		string	false	"Filter by ticker"
+// @Param			since	query		string	false	"Only alerts created at or after this RFC3339 time"
+// @Param			limit	query		int		false	"Maximum number of alerts (default 100, max 1000)"
+// @Success		200		{object}	map[string][]models.AlertEvent
+// @Failure		400		{object}	map[string]string	"Invalid query parameters"
+// @Failure		500		{object}	map[string]string	"Error getting alerts"
+// @Router			/alerts/events [get]
+func (h *AlertHandler) ListEvents(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := models.AlertEventFilter{
+		RuleID: query.Get("rule_id"),
+		Ticker: query.Get("ticker"),
+	}
+
+	if since := query.Get("since"); since != "" {
+		parsed, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid since, expected RFC3339 format")
+			return
+		}
+		filter.Since = parsed
+	}
+
+	if limit := query.Get("limit"); limit != "" {
+		parsed, err := strconv.Atoi(limit)
+		if err != nil || parsed <= 0 {
+			respondWithError(w, http.StatusBadRequest, "Invalid limit, expected a positive integer")
+			return
+		}
+		filter.Limit = parsed
+	}
+
+	alerts, err := h.alertService.ListEvents(r.Context(), filter)
+	if err != nil {
+		logger.Ctx(r.Context(), h.logger).Error("Error getting alerts", zap.Error(err))
+		respondWithError(w, http.StatusInternalServerError, "Error getting alerts")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"items": alerts,
+	})
+}
@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+
+	"github.com/liferip/stock-analyzer/backend/internal/models"
+	"github.com/liferip/stock-analyzer/backend/internal/service"
+	"github.com/liferip/stock-analyzer/backend/pkg/logger"
+)
+
+// APIKeyHandler maneja las solicitudes de administración de API keys
+type APIKeyHandler struct {
+	apiKeyService service.APIKeyService
+	logger        *zap.Logger
+}
+
+// NewAPIKeyHandler crea una nueva instancia de APIKeyHandler
+func NewAPIKeyHandler(
+	apiKeyService service.APIKeyService,
+	logger *zap.Logger,
+) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyService: apiKeyService,
+		logger:        logger.Named("api_key_handler"),
+	}
+}
+
+// @Summary		List API keys
+// @Description	Retrieves all issued API keys, including revoked and expired ones. Only the key prefix is returned. Requires the admin scope
+// @Tags			api-keys
+// @Accept			json
+// @Produce		json
+// @Success		200	{object}	map[string][]models.APIKey
+// @Failure		500	{object}	map[string]string	"Error getting API keys"
+// @Router			/api-keys [get]
+func (h *APIKeyHandler) ListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	keys, err := h.apiKeyService.ListAPIKeys(r.Context())
+	if err != nil {
+		logger.Ctx(r.Context(), h.logger).Error("Error getting API keys", zap.Error(err))
+		respondWithError(w, http.StatusInternalServerError, "Error getting API keys")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"items": keys,
+	})
+}
+
+// @Summary		Get API key
+// @Description	Retrieves an API key by its ID. Requires the admin scope
+// @Tags			api-keys
+// @Accept			json
+// @Produce		json
+// @Param			id	path		string	true	"API key ID"
+// @Success		200	{object}	map[string]models.APIKey
+// @Failure		404	{object}	map[string]string	"API key not found"
+// @Failure		500	{object}	map[string]string	"Error getting API key"
+// @Router			/api-keys/{id} [get]
+func (h *APIKeyHandler) GetAPIKey(w http.ResponseWriter, r *http.Request) {
+	key, err := h.apiKeyService.GetAPIKey(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		if respondWithServiceError(w, err) {
+			return
+		}
+		logger.Ctx(r.Context(), h.logger).Error("Error getting API key", zap.Error(err))
+		respondWithError(w, http.StatusInternalServerError, "Error getting API key")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"item": key,
+	})
+}
+
+// @Summary		Create API key
+// @Description	Issues an API key with a label, scopes (read:stocks, write:sync, read:metrics, admin) and an optional expiry. The key is only returned in this response; only its hash is stored. Requires the admin scope
+// @Tags			api-keys
+// @Accept			json
+// @Produce		json
+// @Param			key	body		models.APIKeyInput	true	"Label, scopes and expiry"
+// @Success		201	{object}	map[string]interface{}
+// @Failure		400	{object}	map[string]string	"Invalid API key"
+// @Failure		500	{object}	map[string]string	"Error creating API key"
+// @Router			/api-keys [post]
+func (h *APIKeyHandler) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	var input models.APIKeyInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	key, secret, err := h.apiKeyService.CreateAPIKey(r.Context(), input)
+	if err != nil {
+		if respondWithServiceError(w, err) {
+			return
+		}
+		logger.Ctx(r.Context(), h.logger).Error("Error creating API key", zap.Error(err))
+		respondWithError(w, http.StatusInternalServerError, "Error creating API key")
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, map[string]interface{}{
+		"item": key,
+		"key":  secret,
+	})
+}
+
+// @Summary		Rotate API key
+// @Description	Replaces the value of an API key, keeping its label, scopes and expiry. The previous value stops working immediately and the new one is only returned in this response. Requires the admin scope
+// @Tags			api-keys
+// @Accept			json
+// @Produce		json
+// @Param			id	path		string	true	"API key ID"
+// @Success		200	{object}	map[string]interface{}
+// @Failure		404	{object}	map[string]string	"API key not found"
+// @Failure		409	{object}	map[string]string	"API key is revoked"
+// @Failure		500	{object}	map[string]string	"Error rotating API key"
+// @Router			/api-keys/{id}/rotate [post]
+func (h *APIKeyHandler) RotateAPIKey(w http.ResponseWriter, r *http.Request) {
+	key, secret, err := h.apiKeyService.RotateAPIKey(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		if respondWithServiceError(w, err) {
+			return
+		}
+		logger.Ctx(r.Context(), h.logger).Error("Error rotating API key", zap.Error(err))
+		respondWithError(w, http.StatusInternalServerError, "Error rotating API key")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"item": key,
+		"key":  secret,
+	})
+}
+
+// @Summary		Revoke API key
+// @Description	Revokes an API key; it stays listed with its revocation time. Requires the admin scope
+// @Tags			api-keys
+// @Accept			json
+// @Produce		json
+// @Param			id	path	string	true	"API key ID"
+// @Success		204
+// @Failure		404	{object}	map[string]string	"API key not found"
+// @Failure		500	{object}	map[string]string	"Error revoking API key"
+// @Router			/api-keys/{id} [delete]
+func (h *APIKeyHandler) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	if err := h.apiKeyService.RevokeAPIKey(r.Context(), mux.Vars(r)["id"]); err != nil {
+		if respondWithServiceError(w, err) {
+			return
+		}
+		logger.Ctx(r.Context(), h.logger).Error("Error revoking API key", zap.Error(err))
+		respondWithError(w, http.StatusInternalServerError, "Error revoking API key")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/liferip/stock-analyzer/backend/internal/models"
+)
+
+// noLastModified omite Last-Modified en las respuestas cuya versión solo identifica el ETag
+var noLastModified time.Time
+
+// respondWithConditionalJSON envía payload como respondWithJSON, con un ETag fuerte calculado
+// sobre el cuerpo y Last-Modified, y responde 304 sin cuerpo si el cliente ya tiene esa versión.
+// Un lastModified cero, como noLastModified, omite Last-Modified.
+func respondWithConditionalJSON(w http.ResponseWriter, r *http.Request, payload interface{}, lastModified time.Time) {
+	response, err := json.Marshal(payload)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("Error processing response"))
+		return
+	}
+
+	sum := sha256.Sum256(response)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	lastModified = lastModified.UTC().Truncate(time.Second)
+
+	// Los clientes pueden guardar la respuesta, pero deben revalidarla en cada uso
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("ETag", etag)
+	if !lastModified.IsZero() {
+		w.Header().Set("Last-Modified", lastModified.Format(http.TimeFormat))
+	}
+
+	if notModified(r, etag, lastModified) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(response)
+}
+
+// notModified evalúa If-None-Match y, si no está presente, If-Modified-Since (RFC 9110, sección 13.2.2)
+func notModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+
+	if header := r.Header.Get("If-None-Match"); header != "" {
+		for _, candidate := range strings.Split(header, ",") {
+			candidate = strings.TrimSpace(candidate)
+			// If-None-Match usa la comparación débil, que ignora el prefijo W/
+			if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+				return true
+			}
+		}
+		return false
+	}
+
+	if header := r.Header.Get("If-Modified-Since"); header != "" && !lastModified.IsZero() {
+		since, err := http.ParseTime(header)
+		return err == nil && !lastModified.After(since)
+	}
+
+	return false
+}
+
+// latestStockUpdate obtiene la fecha de actualización más reciente de una lista de stocks
+func latestStockUpdate(stocks ...models.Stock) time.Time {
+	var latest time.Time
+	for i := range stocks {
+		if stocks[i].UpdatedAt.After(latest) {
+			latest = stocks[i].UpdatedAt
+		}
+	}
+	return latest
+}
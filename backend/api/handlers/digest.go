@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/liferip/stock-analyzer/backend/internal/service"
+	"github.com/liferip/stock-analyzer/backend/pkg/logger"
+)
+
+// DigestHandler maneja las solicitudes del resumen diario por correo
+type DigestHandler struct {
+	digestService service.DigestService
+	logger        *zap.Logger
+}
+
+// NewDigestHandler crea una nueva instancia de DigestHandler
+func NewDigestHandler(
+	digestService service.DigestService,
+	logger *zap.Logger,
+) *DigestHandler {
+	return &DigestHandler{
+		digestService: digestService,
+		logger:        logger.Named("digest_handler"),
+	}
+}
+
+// @Summary		Preview digest
+// @Description	Renders the digest that would be sent now: top recommendations, upgrades and downgrades recorded since the previous digest, and the biggest target changes
+// @Tags			digest
+// @Produce		json
+// @Produce		html
+// @Produce		plain
+// @Param			format	query		string	false	"Output format (default json)"	Enums(json, html, text)
+// @Success		200		{object}	models.Digest
+// @Failure		400		{object}	map[string]string	"Invalid format"
+// @Failure		500		{object}	map[string]string	"Error building digest"
+// @Router			/digest/preview [get]
+func (h *DigestHandler) PreviewDigest(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "json" && format != "html" && format != "text" {
+		respondWithError(w, http.StatusBadRequest, "Invalid format, expected 'json', 'html' or 'text'")
+		return
+	}
+
+	digest, err := h.digestService.BuildDigest(r.Context())
+	if err != nil {
+		logger.Ctx(r.Context(), h.logger).Error("Error building digest", zap.Error(err))
+		respondWithError(w, http.StatusInternalServerError, "Error building digest")
+		return
+	}
+
+	if format == "" || format == "json" {
+		respondWithJSON(w, http.StatusOK, digest)
+		return
+	}
+
+	html, text, err := h.digestService.Render(digest)
+	if err != nil {
+		logger.Ctx(r.Context(), h.logger).Error("Error rendering digest", zap.Error(err))
+		respondWithError(w, http.StatusInternalServerError, "Error building digest")
+		return
+	}
+
+	if format == "html" {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(html))
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(text))
+}
+
+// @Summary		Send digest
+// @Description	Sends the digest to the configured recipients now, even if one was already sent today
+// @Tags			digest
+// @Accept			json
+// @Produce		json
+// @Success		200	{object}	map[string]models.DigestRun
+// @Failure		400	{object}	map[string]string	"Digest is not configured"
+// @Failure		500	{object}	map[string]string	"Error sending digest"
+// @Router			/digest/send [post]
+func (h *DigestHandler) SendDigest(w http.ResponseWriter, r *http.Request) {
+	run, err := h.digestService.SendDigest(r.Context(), true)
+	if err != nil {
+		if respondWithServiceError(w, err) {
+			return
+		}
+		logger.Ctx(r.Context(), h.logger).Error("Error sending digest", zap.Error(err))
+		respondWithError(w, http.StatusInternalServerError, "Error sending digest")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"item": run,
+	})
+}
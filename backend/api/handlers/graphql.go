@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/liferip/stock-analyzer/backend/api/graph"
+)
+
+// maxGraphQLBodySize es el tamaño máximo del cuerpo de una consulta GraphQL
+const maxGraphQLBodySize = 1 << 20
+
+// GraphQLHandler maneja las consultas a la API GraphQL
+type GraphQLHandler struct {
+	executor *graph.Executor
+	logger   *zap.Logger
+}
+
+// NewGraphQLHandler crea una nueva instancia de GraphQLHandler
+func NewGraphQLHandler(
+	executor *graph.Executor,
+	logger *zap.Logger,
+) *GraphQLHandler {
+	return &GraphQLHandler{
+		executor: executor,
+		logger:   logger.Named("graphql_handler"),
+	}
+}
+
+// @Summary		GraphQL query
+// @Description	Runs a GraphQL query over stocks, rating history, brokerages and recommendations. POST a JSON body {"query","operationName","variables"}, or use GET with query, operationName and variables (JSON) query params. Queries deeper or more complex than the configured limits are rejected; the complexity counts each field once and multiplies the cost of paginated children by their "first" argument
+// @Tags			graphql
+// @Accept			json
+// @Produce		json
+// @Param			request			body		graph.Request	false	"GraphQL request (POST)"
+// @Param			query			query		string			false	"GraphQL query (GET)"
+// @Param			operationName	query		string			false	"Operation to run (GET)"
+// @Param			variables		query		string			false	"JSON-encoded variables (GET)"
+// @Success		200				{object}	map[string]interface{}	"GraphQL result with data and errors"
+// @Failure		400				{object}	map[string]interface{}	"Invalid request, query or limits exceeded"
+// @Router			/graphql [get]
+// @Router			/graphql [post]
+func (h *GraphQLHandler) Query(w http.ResponseWriter, r *http.Request) {
+	var req graph.Request
+
+	if r.Method == http.MethodGet {
+		query := r.URL.Query()
+		req.Query = query.Get("query")
+		req.OperationName = query.Get("operationName")
+		if variables := query.Get("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+				respondWithGraphQLError(w, "Invalid variables")
+				return
+			}
+		}
+	} else {
+		r.Body = http.MaxBytesReader(w, r.Body, maxGraphQLBodySize)
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			respondWithGraphQLError(w, "Invalid request payload")
+			return
+		}
+	}
+
+	if req.Query == "" {
+		respondWithGraphQLError(w, "Missing query")
+		return
+	}
+
+	result, executed := h.executor.Execute(r.Context(), req)
+	if !executed {
+		respondWithJSON(w, http.StatusBadRequest, result)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, result)
+}
+
+// respondWithGraphQLError envía un error de solicitud con el formato de errores de GraphQL
+func respondWithGraphQLError(w http.ResponseWriter, message string) {
+	respondWithJSON(w, http.StatusBadRequest, map[string]interface{}{
+		"errors": []map[string]string{{"message": message}},
+	})
+}
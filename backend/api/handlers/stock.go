@@ -2,33 +2,51 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
+	"time"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 
 	"github.com/gorilla/mux"
 	"github.com/liferip/stock-analyzer/backend/internal/models"
 	"github.com/liferip/stock-analyzer/backend/internal/service"
+	"github.com/liferip/stock-analyzer/backend/pkg/logger"
 )
 
 // Module proporciona las dependencias de los handlers
-var Module = fx.Provide(NewStockHandler)
+var Module = fx.Provide(NewStockHandler, NewTaxonomyHandler, NewAlertHandler, NewWebhookHandler, NewDigestHandler, NewStreamHandler, NewWebSocketHandler, NewGraphQLHandler, NewAPIKeyHandler, NewStockV2Handler)
+
+// tracer crea los spans de los handlers
+var tracer = otel.Tracer("github.com/liferip/stock-analyzer/backend/api/handlers")
+
+// startSpan inicia un span hijo del de la solicitud y devuelve la solicitud con su contexto
+func startSpan(r *http.Request, name string) (*http.Request, trace.Span) {
+	ctx, span := tracer.Start(r.Context(), name)
+	return r.WithContext(ctx), span
+}
 
 // StockHandler maneja las solicitudes relacionadas con stocks
 type StockHandler struct {
-	stockService service.StockService
-	logger       *zap.Logger
+	stockService    service.StockService
+	snapshotService service.SnapshotService
+	logger          *zap.Logger
 }
 
 // NewStockHandler crea una nueva instancia de StockHandler
 func NewStockHandler(
 	stockService service.StockService,
+	snapshotService service.SnapshotService,
 	logger *zap.Logger,
 ) *StockHandler {
 	return &StockHandler{
-		stockService: stockService,
-		logger:       logger.Named("stock_handler"),
+		stockService:    stockService,
+		snapshotService: snapshotService,
+		logger:          logger.Named("stock_handler"),
 	}
 }
 
@@ -37,16 +55,22 @@ func NewStockHandler(
 // @Tags			stock
 // @Accept			json
 // @Produce		json
-// @Success		200	{object}	map[string][]models.Stock
-// @Failure		404	{object}	map[string]string	"No stocks found"
-// @Failure		500	{object}	map[string]string	"Error getting stocks"
+// @Param			If-None-Match		header		string	false	"ETag of a previous response"
+// @Param			If-Modified-Since	header		string	false	"Last-Modified of a previous response"
+// @Success		200					{object}	map[string][]models.Stock
+// @Success		304					"Not modified"
+// @Failure		404					{object}	map[string]string	"No stocks found"
+// @Failure		500					{object}	map[string]string	"Error getting stocks"
 // @Router			/stock [get]
 func (h *StockHandler) GetStocks(w http.ResponseWriter, r *http.Request) {
+	r, span := startSpan(r, "StockHandler.GetStocks")
+	defer span.End()
+
 	ctx := r.Context()
 
 	stocks, err := h.stockService.GetAllStocks(ctx)
 	if err != nil {
-		h.logger.Error("Error getting stocks", zap.Error(err))
+		logger.Ctx(r.Context(), h.logger).Error("Error getting stocks", zap.Error(err))
 		respondWithError(w, http.StatusInternalServerError, "Error getting stocks")
 		return
 	}
@@ -57,9 +81,9 @@ func (h *StockHandler) GetStocks(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+	respondWithConditionalJSON(w, r, map[string]interface{}{
 		"items": stocks,
-	})
+	}, latestStockUpdate(stocks...))
 }
 
 // @Summary		Get stock by ticker
@@ -67,19 +91,25 @@ func (h *StockHandler) GetStocks(w http.ResponseWriter, r *http.Request) {
 // @Tags			stock
 // @Accept			json
 // @Produce		json
-// @Param			ticker	path		string	true	"Stock ticker symbol (e.g. AAPL)"
-// @Success		200		{object}	map[string]models.Stock
-// @Failure		404		{object}	map[string]string	"Stock not found"
-// @Failure		500		{object}	map[string]string	"Error getting stock by ticker"
+// @Param			ticker				path		string	true	"Stock ticker symbol (e.g. AAPL)"
+// @Param			If-None-Match		header		string	false	"ETag of a previous response"
+// @Param			If-Modified-Since	header		string	false	"Last-Modified of a previous response"
+// @Success		200					{object}	map[string]models.Stock
+// @Success		304					"Not modified"
+// @Failure		404					{object}	map[string]string	"Stock not found"
+// @Failure		500					{object}	map[string]string	"Error getting stock by ticker"
 // @Router			/stock/ticker/{ticker} [get]
 func (h *StockHandler) GetStockByTicker(w http.ResponseWriter, r *http.Request) {
+	r, span := startSpan(r, "StockHandler.GetStockByTicker")
+	defer span.End()
+
 	ctx := r.Context()
 
 	ticker := mux.Vars(r)["ticker"]
 
 	stock, err := h.stockService.GetStockByTicker(ctx, ticker)
 	if err != nil {
-		h.logger.Error("Error getting stock by ticker", zap.Error(err))
+		logger.Ctx(r.Context(), h.logger).Error("Error getting stock by ticker", zap.Error(err))
 		respondWithError(w, http.StatusInternalServerError, "Error getting stock by ticker")
 		return
 	}
@@ -90,43 +120,62 @@ func (h *StockHandler) GetStockByTicker(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+	respondWithConditionalJSON(w, r, map[string]interface{}{
 		"item": stock,
-	})
+	}, stock.UpdatedAt)
 }
 
 // @Summary		Get stock recommendations
-// @Description	Retrieves stock recommendations, optionally filtered by time
+// @Description	Retrieves stock recommendations, optionally filtered by time. Upside is measured from the latest stored close price to the target price. Responses carry an ETag but no Last-Modified: prices and the rating taxonomy change the result without changing any stock's updated_at, so revalidate with If-None-Match
 // @Tags			stock
 // @Accept			json
 // @Produce		json
-// @Param			time	query		string	false	"Time filter in YY/MM/DD format (e.g. '2025-03-31')"
-// @Success		200		{array}		models.StockRecommendation
-// @Failure		404		{object}	map[string]string	"No recommendations found"
-// @Failure		500		{object}	map[string]string	"Error getting recommendations"
+// @Param			time				query		string	false	"Date filter in YYYY-MM-DD format (e.g. '2025-03-31')"
+// @Param			sort				query		string	false	"Sort order"	Enums(score, upside)	default(score)
+// @Param			If-None-Match		header		string	false	"ETag of a previous response"
+// @Success		200					{array}		models.StockRecommendation
+// @Success		304					"Not modified"
+// @Failure		400					{object}	map[string]string	"Invalid sort or time"
+// @Failure		404					{object}	map[string]string	"No recommendations found"
+// @Failure		500					{object}	map[string]string	"Error getting recommendations"
 // @Router			/stock/recommendations [get]
 func (h *StockHandler) GetRecommendations(w http.ResponseWriter, r *http.Request) {
+	r, span := startSpan(r, "StockHandler.GetRecommendations")
+	defer span.End()
+
 	ctx := r.Context()
 
-	// Verificar si existe el parámetro de tiempo
-	time := r.URL.Query().Get("time")
+	// Verificar si existe el parámetro de tiempo, que es una fecha con el formato YYYY-MM-DD
+	date := r.URL.Query().Get("time")
+	if date != "" {
+		if _, err := time.Parse(time.DateOnly, date); err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid time, expected a date formatted as YYYY-MM-DD")
+			return
+		}
+	}
+
+	// Validar el criterio de ordenamiento
+	sortBy, err := service.ParseRecommendationSort(r.URL.Query().Get("sort"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid sort, expected 'score' or 'upside'")
+		return
+	}
 
 	var recommendations []models.StockRecommendation
-	var err error
 
-	if time == "" {
+	if date == "" {
 		// Sin parámetro de tiempo, obtener todas las recomendaciones
-		recommendations, err = h.stockService.GetRecommendations(ctx)
+		recommendations, err = h.stockService.GetRecommendations(ctx, sortBy)
 		if err != nil {
-			h.logger.Error("Error getting recommendations", zap.Error(err))
+			logger.Ctx(r.Context(), h.logger).Error("Error getting recommendations", zap.Error(err))
 			respondWithError(w, http.StatusInternalServerError, "Error getting recommendations")
 			return
 		}
 	} else {
 		// Con parámetro de tiempo, obtener recomendaciones filtradas
-		recommendations, err = h.stockService.GetRecommendationsByTime(ctx, time)
+		recommendations, err = h.stockService.GetRecommendationsByTime(ctx, date, sortBy)
 		if err != nil {
-			h.logger.Error("Error getting recommendations by time", zap.Error(err))
+			logger.Ctx(r.Context(), h.logger).Error("Error getting recommendations by time", zap.Error(err))
 			respondWithError(w, http.StatusInternalServerError, "Error getting recommendations by time")
 			return
 		}
@@ -138,7 +187,108 @@ func (h *StockHandler) GetRecommendations(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	respondWithJSON(w, http.StatusOK, recommendations)
+	// Sin Last-Modified: el precio actual y la taxonomía cambian la respuesta sin cambiar
+	// updated_at de los stocks, así que solo el ETag identifica la versión
+	respondWithConditionalJSON(w, r, recommendations, noLastModified)
+}
+
+// @Summary		List recommendation snapshots
+// @Description	Retrieves the dates that have a stored recommendation snapshot, newest first
+// @Tags			stock
+// @Accept			json
+// @Produce		json
+// @Success		200	{object}	map[string][]string
+// @Failure		500	{object}	map[string]string	"Error getting snapshot dates"
+// @Router			/stock/recommendations/snapshots [get]
+func (h *StockHandler) GetSnapshotDates(w http.ResponseWriter, r *http.Request) {
+	r, span := startSpan(r, "StockHandler.GetSnapshotDates")
+	defer span.End()
+
+	dates, err := h.snapshotService.GetSnapshotDates(r.Context())
+	if err != nil {
+		logger.Ctx(r.Context(), h.logger).Error("Error getting snapshot dates", zap.Error(err))
+		respondWithError(w, http.StatusInternalServerError, "Error getting snapshot dates")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"items": dates,
+	})
+}
+
+// @Summary		Get recommendation snapshot
+// @Description	Retrieves the top recommendations stored after the last sync of a given date, with scores, reasons and strategy version
+// @Tags			stock
+// @Accept			json
+// @Produce		json
+// @Param			date	path		string	true	"Snapshot date in YYYY-MM-DD format"
+// @Success		200		{object}	map[string][]models.RecommendationSnapshot
+// @Failure		404		{object}	map[string]string	"No snapshot found"
+// @Failure		500		{object}	map[string]string	"Error getting snapshot"
+// @Router			/stock/recommendations/snapshots/{date} [get]
+func (h *StockHandler) GetSnapshot(w http.ResponseWriter, r *http.Request) {
+	r, span := startSpan(r, "StockHandler.GetSnapshot")
+	defer span.End()
+
+	date, err := time.Parse(time.DateOnly, mux.Vars(r)["date"])
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid date, expected YYYY-MM-DD")
+		return
+	}
+
+	snapshots, err := h.snapshotService.GetSnapshot(r.Context(), date)
+	if err != nil {
+		logger.Ctx(r.Context(), h.logger).Error("Error getting snapshot", zap.Error(err))
+		respondWithError(w, http.StatusInternalServerError, "Error getting snapshot")
+		return
+	}
+
+	// Si no hay foto para la fecha, responder con un error
+	if len(snapshots) == 0 {
+		respondWithError(w, http.StatusNotFound, "No snapshot found")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"date":  date.Format(time.DateOnly),
+		"items": snapshots,
+	})
+}
+
+// @Summary		Diff recommendation snapshots
+// @Description	Compares the snapshots of two dates and lists the entries that entered or left the recommendation list
+// @Tags			stock
+// @Accept			json
+// @Produce		json
+// @Param			from	query		string	true	"Earlier snapshot date in YYYY-MM-DD format"
+// @Param			to		query		string	true	"Later snapshot date in YYYY-MM-DD format"
+// @Success		200		{object}	models.SnapshotDiff
+// @Failure		400		{object}	map[string]string	"Invalid dates"
+// @Failure		404		{object}	map[string]string	"No snapshot found"
+// @Failure		500		{object}	map[string]string	"Error comparing snapshots"
+// @Router			/stock/recommendations/snapshots/diff [get]
+func (h *StockHandler) DiffSnapshots(w http.ResponseWriter, r *http.Request) {
+	r, span := startSpan(r, "StockHandler.DiffSnapshots")
+	defer span.End()
+
+	from, fromErr := time.Parse(time.DateOnly, r.URL.Query().Get("from"))
+	to, toErr := time.Parse(time.DateOnly, r.URL.Query().Get("to"))
+	if fromErr != nil || toErr != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid 'from' or 'to', expected YYYY-MM-DD")
+		return
+	}
+
+	diff, err := h.snapshotService.DiffSnapshots(r.Context(), from, to)
+	if err != nil {
+		if respondWithServiceError(w, err) {
+			return
+		}
+		logger.Ctx(r.Context(), h.logger).Error("Error comparing snapshots", zap.Error(err))
+		respondWithError(w, http.StatusInternalServerError, "Error comparing snapshots")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, diff)
 }
 
 // @Summary		Synchronize stocks
@@ -150,11 +300,14 @@ func (h *StockHandler) GetRecommendations(w http.ResponseWriter, r *http.Request
 // @Failure		500	{object}	map[string]string		"Error synchronizing stocks"
 // @Router			/stock/sync [post]
 func (h *StockHandler) SyncStocks(w http.ResponseWriter, r *http.Request) {
+	r, span := startSpan(r, "StockHandler.SyncStocks")
+	defer span.End()
+
 	ctx := r.Context()
 
 	count, err := h.stockService.SyncStocksFromAPI(ctx)
 	if err != nil {
-		h.logger.Error("Error synchronizing stocks", zap.Error(err))
+		logger.Ctx(r.Context(), h.logger).Error("Error synchronizing stocks", zap.Error(err))
 		respondWithError(w, http.StatusInternalServerError, "Error synchronizing stocks")
 		return
 	}
@@ -165,6 +318,123 @@ func (h *StockHandler) SyncStocks(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// @Summary		Upsert closing prices
+// @Description	Stores daily closing prices per ticker, replacing any existing price for the same ticker and date
+// @Tags			stock
+// @Accept			json
+// @Produce		json
+// @Param			prices	body		map[string][]models.StockPriceItem	true	"Closing prices wrapped in 'items' (date in YYYY-MM-DD format)"
+// @Success		200		{object}	map[string]interface{}				"Success message and count"
+// @Failure		400		{object}	map[string]string					"Invalid prices"
+// @Failure		500		{object}	map[string]string					"Error saving prices"
+// @Router			/stock/prices [post]
+func (h *StockHandler) UpsertPrices(w http.ResponseWriter, r *http.Request) {
+	r, span := startSpan(r, "StockHandler.UpsertPrices")
+	defer span.End()
+
+	ctx := r.Context()
+
+	var payload struct {
+		Items []models.StockPriceItem `json:"items"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	count, err := h.stockService.UpsertPrices(ctx, payload.Items)
+	if err != nil {
+		if respondWithServiceError(w, err) {
+			return
+		}
+		logger.Ctx(r.Context(), h.logger).Error("Error saving prices", zap.Error(err))
+		respondWithError(w, http.StatusInternalServerError, "Error saving prices")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"message": "Prices saved correctly",
+		"count":   count,
+	})
+}
+
+// @Summary		Backtest recommendations
+// @Description	Replays stored rating events day by day, builds the top-N list with the selected strategy and measures forward returns from stored closing prices against a benchmark
+// @Tags			stock
+// @Accept			json
+// @Produce		json
+// @Param			from		query		string	false	"Start date in YYYY-MM-DD format (default: 90 days before 'to'). The period can span at most 3 years"
+// @Param			to			query		string	false	"End date in YYYY-MM-DD format (default: today)"
+// @Param			top			query		int		false	"Number of recommendations per day"	default(5)	minimum(1)
+// @Param			horizon		query		int		false	"Forward return horizon in days"		default(5)	minimum(1)	maximum(365)
+// @Param			strategy	query		string	false	"Ranking strategy"						Enums(score, upside)	default(score)
+// @Param			benchmark	query		string	false	"Benchmark ticker (default: BACKTEST_BENCHMARK)"
+// @Success		200			{object}	models.BacktestResult
+// @Failure		400			{object}	map[string]string	"Invalid backtest parameters"
+// @Failure		500			{object}	map[string]string	"Error running backtest"
+// @Router			/stock/backtest [get]
+func (h *StockHandler) RunBacktest(w http.ResponseWriter, r *http.Request) {
+	r, span := startSpan(r, "StockHandler.RunBacktest")
+	defer span.End()
+
+	ctx := r.Context()
+
+	params, err := parseBacktestParams(r)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.stockService.RunBacktest(ctx, params)
+	if err != nil {
+		if respondWithServiceError(w, err) {
+			return
+		}
+		logger.Ctx(r.Context(), h.logger).Error("Error running backtest", zap.Error(err))
+		respondWithError(w, http.StatusInternalServerError, "Error running backtest")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, result)
+}
+
+// parseBacktestParams lee los parámetros del backtest desde la query
+func parseBacktestParams(r *http.Request) (models.BacktestParams, error) {
+	query := r.URL.Query()
+	params := models.BacktestParams{
+		Strategy:  query.Get("strategy"),
+		Benchmark: query.Get("benchmark"),
+	}
+
+	var err error
+	if value := query.Get("from"); value != "" {
+		if params.From, err = time.Parse(time.DateOnly, value); err != nil {
+			return params, errors.New("Invalid 'from', expected YYYY-MM-DD")
+		}
+	}
+	if value := query.Get("to"); value != "" {
+		if params.To, err = time.Parse(time.DateOnly, value); err != nil {
+			return params, errors.New("Invalid 'to', expected YYYY-MM-DD")
+		}
+	}
+	if value := query.Get("top"); value != "" {
+		top, err := strconv.Atoi(value)
+		if err != nil {
+			return params, errors.New("Invalid 'top', expected an integer")
+		}
+		params.Top = &top
+	}
+	if value := query.Get("horizon"); value != "" {
+		horizon, err := strconv.Atoi(value)
+		if err != nil {
+			return params, errors.New("Invalid 'horizon', expected an integer")
+		}
+		params.Horizon = &horizon
+	}
+
+	return params, nil
+}
+
 // respondWithJSON envía una respuesta JSON al cliente
 func respondWithJSON(w http.ResponseWriter, status int, payload interface{}) {
 	response, err := json.Marshal(payload)
@@ -183,3 +453,19 @@ func respondWithJSON(w http.ResponseWriter, status int, payload interface{}) {
 func respondWithError(w http.ResponseWriter, status int, message string) {
 	respondWithJSON(w, status, map[string]string{"error": message})
 }
+
+// respondWithServiceError envía un error de validación del servicio con su código HTTP,
+// devolviendo false si el error no es de validación
+func respondWithServiceError(w http.ResponseWriter, err error) bool {
+	switch {
+	case errors.Is(err, service.ErrInvalidInput):
+		respondWithError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, service.ErrNotFound):
+		respondWithError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, service.ErrConflict):
+		respondWithError(w, http.StatusConflict, err.Error())
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/liferip/stock-analyzer/backend/internal/models"
+	"github.com/liferip/stock-analyzer/backend/internal/service"
+	"github.com/liferip/stock-analyzer/backend/pkg/logger"
+)
+
+const (
+	// streamHeartbeatInterval es cada cuánto se envía un comentario para mantener viva la conexión
+	streamHeartbeatInterval = 15 * time.Second
+	// streamRetry es la espera sugerida al cliente antes de reconectarse, en milisegundos
+	streamRetry = 3000
+)
+
+// StreamHandler maneja las conexiones en vivo a los cambios de stocks
+type StreamHandler struct {
+	stream service.StockStream
+	logger *zap.Logger
+}
+
+// NewStreamHandler crea una nueva instancia de StreamHandler
+func NewStreamHandler(
+	stream service.StockStream,
+	logger *zap.Logger,
+) *StreamHandler {
+	return &StreamHandler{
+		stream: stream,
+		logger: logger.Named("stream_handler"),
+	}
+}
+
+// @Summary		Stream stock changes
+// @Description	Server-Sent Events stream of each stock record created or updated by sync (event "stock"). Sends a heartbeat comment every 15 seconds. Reconnecting with the Last-Event-ID header (or last_event_id query param) replays the recent events missed since that ID
+// @Tags			stocks
+// @Produce		text/event-stream
+// @Param			ticker			query		string	false	"Comma-separated tickers to include"
+// @Param			brokerage		query		string	false	"Comma-separated brokerages to include"
+// @Param			last_event_id	query		int		false	"Resume after this event ID when the Last-Event-ID header cannot be set"
+// @Param			Last-Event-ID	header		int		false	"Resume after this event ID"
+// @Success		200				{object}	models.StockStreamEvent
+// @Failure		400				{object}	map[string]string	"Invalid last event ID"
+// @Router			/stock/stream [get]
+func (h *StreamHandler) StreamStocks(w http.ResponseWriter, r *http.Request) {
+	lastEventID, err := parseLastEventID(r)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid last event ID")
+		return
+	}
+
+	filter := models.StockStreamFilter{
+		Tickers:    splitQueryList(r.URL.Query().Get("ticker")),
+		Brokerages: splitQueryList(r.URL.Query().Get("brokerage")),
+	}
+
+	// Las conexiones de streaming no deben cortarse por el WriteTimeout del servidor
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		logger.Ctx(r.Context(), h.logger).Debug("Could not clear write deadline", zap.Error(err))
+	}
+
+	replay, sub := h.stream.Subscribe(filter, lastEventID)
+	defer h.stream.Unsubscribe(sub)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	fmt.Fprintf(w, "retry: %d\n\n", streamRetry)
+	for _, event := range replay {
+		if err := writeStreamEvent(w, event); err != nil {
+			return
+		}
+	}
+	if err := rc.Flush(); err != nil {
+		logger.Ctx(r.Context(), h.logger).Error("Streaming is not supported by the response writer", zap.Error(err))
+		return
+	}
+
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event, ok := <-sub.Events:
+			if !ok {
+				// El stream se cerró o el cliente quedó atrasado; el cliente se reconectará
+				return
+			}
+			if err := writeStreamEvent(w, event); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		}
+
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// writeStreamEvent escribe un evento en formato Server-Sent Events
+func writeStreamEvent(w http.ResponseWriter, event models.StockStreamEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %d\nevent: stock\ndata: %s\n\n", event.ID, data)
+	return err
+}
+
+// parseLastEventID obtiene el ID desde el que retomar el stream, 0 si no se indicó
+func parseLastEventID(r *http.Request) (uint64, error) {
+	value := r.Header.Get("Last-Event-ID")
+	if value == "" {
+		value = r.URL.Query().Get("last_event_id")
+	}
+	if value == "" {
+		return 0, nil
+	}
+
+	return strconv.ParseUint(value, 10, 64)
+}
+
+// splitQueryList separa un parámetro de consulta con valores separados por comas
+func splitQueryList(value string) []string {
+	var values []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
@@ -0,0 +1,273 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+
+	"github.com/liferip/stock-analyzer/backend/internal/models"
+	"github.com/liferip/stock-analyzer/backend/internal/service"
+	"github.com/liferip/stock-analyzer/backend/pkg/logger"
+)
+
+// TaxonomyHandler maneja las solicitudes de la taxonomía de calificaciones y acciones
+type TaxonomyHandler struct {
+	taxonomyService service.TaxonomyService
+	logger          *zap.Logger
+}
+
+// NewTaxonomyHandler crea una nueva instancia de TaxonomyHandler
+func NewTaxonomyHandler(
+	taxonomyService service.TaxonomyService,
+	logger *zap.Logger,
+) *TaxonomyHandler {
+	return &TaxonomyHandler{
+		taxonomyService: taxonomyService,
+		logger:          logger.Named("taxonomy_handler"),
+	}
+}
+
+// kind obtiene y valida el tipo de etiqueta de la ruta
+func (h *TaxonomyHandler) kind(w http.ResponseWriter, r *http.Request) (string, bool) {
+	kind, err := service.ParseTaxonomyKind(mux.Vars(r)["kind"])
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid kind, expected 'rating' or 'action'")
+		return "", false
+	}
+	return kind, true
+}
+
+// @Summary		List taxonomy labels
+// @Description	Retrieves the canonical rating or action labels with their score and aliases
+// @Tags			taxonomy
+// @Accept			json
+// @Produce		json
+// @Param			kind	path		string	true	"Label kind"	Enums(rating, action)
+// @Success		200		{object}	map[string][]models.TaxonomyLabel
+// @Failure		400		{object}	map[string]string	"Invalid kind"
+// @Failure		500		{object}	map[string]string	"Error getting labels"
+// @Router			/taxonomy/{kind}/labels [get]
+func (h *TaxonomyHandler) ListLabels(w http.ResponseWriter, r *http.Request) {
+	kind, ok := h.kind(w, r)
+	if !ok {
+		return
+	}
+
+	labels, err := h.taxonomyService.ListLabels(r.Context(), kind)
+	if err != nil {
+		logger.Ctx(r.Context(), h.logger).Error("Error getting labels", zap.Error(err))
+		respondWithError(w, http.StatusInternalServerError, "Error getting labels")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"items": labels,
+	})
+}
+
+// @Summary		Create taxonomy label
+// @Description	Creates a canonical rating or action label with its score
+// @Tags			taxonomy
+// @Accept			json
+// @Produce		json
+// @Param			kind	path		string						true	"Label kind"	Enums(rating, action)
+// @Param			label	body		models.TaxonomyLabelInput	true	"Label name and score"
+// @Success		201		{object}	map[string]models.TaxonomyLabel
+// @Failure		400		{object}	map[string]string	"Invalid label"
+// @Failure		409		{object}	map[string]string	"Label already mapped"
+// @Failure		500		{object}	map[string]string	"Error creating label"
+// @Router			/taxonomy/{kind}/labels [post]
+func (h *TaxonomyHandler) CreateLabel(w http.ResponseWriter, r *http.Request) {
+	kind, ok := h.kind(w, r)
+	if !ok {
+		return
+	}
+
+	var input models.TaxonomyLabelInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	label, err := h.taxonomyService.CreateLabel(r.Context(), kind, input)
+	if err != nil {
+		if respondWithServiceError(w, err) {
+			return
+		}
+		logger.Ctx(r.Context(), h.logger).Error("Error creating label", zap.Error(err))
+		respondWithError(w, http.StatusInternalServerError, "Error creating label")
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, map[string]interface{}{
+		"item": label,
+	})
+}
+
+// @Summary		Update taxonomy label
+// @Description	Renames a canonical label or changes its score; omitted fields are kept
+// @Tags			taxonomy
+// @Accept			json
+// @Produce		json
+// @Param			kind	path		string						true	"Label kind"	Enums(rating, action)
+// @Param			id		path		string						true	"Label ID"
+// @Param			label	body		models.TaxonomyLabelInput	true	"Label name and score"
+// @Success		200		{object}	map[string]models.TaxonomyLabel
+// @Failure		400		{object}	map[string]string	"Invalid label"
+// @Failure		404		{object}	map[string]string	"Label not found"
+// @Failure		409		{object}	map[string]string	"Label already mapped"
+// @Failure		500		{object}	map[string]string	"Error updating label"
+// @Router			/taxonomy/{kind}/labels/{id} [put]
+func (h *TaxonomyHandler) UpdateLabel(w http.ResponseWriter, r *http.Request) {
+	kind, ok := h.kind(w, r)
+	if !ok {
+		return
+	}
+
+	var input models.TaxonomyLabelInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	label, err := h.taxonomyService.UpdateLabel(r.Context(), kind, mux.Vars(r)["id"], input)
+	if err != nil {
+		if respondWithServiceError(w, err) {
+			return
+		}
+		logger.Ctx(r.Context(), h.logger).Error("Error updating label", zap.Error(err))
+		respondWithError(w, http.StatusInternalServerError, "Error updating label")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"item": label,
+	})
+}
+
+// @Summary		Delete taxonomy label
+// @Description	Deletes a canonical label and its aliases; matching raw labels will score 0 again
+// @Tags			taxonomy
+// @Accept			json
+// @Produce		json
+// @Param			kind	path	string	true	"Label kind"	Enums(rating, action)
+// @Param			id		path	string	true	"Label ID"
+// @Success		204
+// @Failure		404	{object}	map[string]string	"Label not found"
+// @Failure		500	{object}	map[string]string	"Error deleting label"
+// @Router			/taxonomy/{kind}/labels/{id} [delete]
+func (h *TaxonomyHandler) DeleteLabel(w http.ResponseWriter, r *http.Request) {
+	kind, ok := h.kind(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.taxonomyService.DeleteLabel(r.Context(), kind, mux.Vars(r)["id"]); err != nil {
+		if respondWithServiceError(w, err) {
+			return
+		}
+		logger.Ctx(r.Context(), h.logger).Error("Error deleting label", zap.Error(err))
+		respondWithError(w, http.StatusInternalServerError, "Error deleting label")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary		Create taxonomy alias
+// @Description	Maps a raw upstream label to an existing canonical label, so it gets the same score
+// @Tags			taxonomy
+// @Accept			json
+// @Produce		json
+// @Param			kind	path		string						true	"Label kind"	Enums(rating, action)
+// @Param			alias	body		models.TaxonomyAliasInput	true	"Raw label and canonical label name"
+// @Success		201		{object}	map[string]models.TaxonomyAlias
+// @Failure		400		{object}	map[string]string	"Invalid alias"
+// @Failure		409		{object}	map[string]string	"Label already mapped"
+// @Failure		500		{object}	map[string]string	"Error creating alias"
+// @Router			/taxonomy/{kind}/aliases [post]
+func (h *TaxonomyHandler) CreateAlias(w http.ResponseWriter, r *http.Request) {
+	kind, ok := h.kind(w, r)
+	if !ok {
+		return
+	}
+
+	var input models.TaxonomyAliasInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	alias, err := h.taxonomyService.CreateAlias(r.Context(), kind, input)
+	if err != nil {
+		if respondWithServiceError(w, err) {
+			return
+		}
+		logger.Ctx(r.Context(), h.logger).Error("Error creating alias", zap.Error(err))
+		respondWithError(w, http.StatusInternalServerError, "Error creating alias")
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, map[string]interface{}{
+		"item": alias,
+	})
+}
+
+// @Summary		Delete taxonomy alias
+// @Description	Removes the mapping of a raw upstream label
+// @Tags			taxonomy
+// @Accept			json
+// @Produce		json
+// @Param			kind	path	string	true	"Label kind"	Enums(rating, action)
+// @Param			id		path	string	true	"Alias ID"
+// @Success		204
+// @Failure		404	{object}	map[string]string	"Alias not found"
+// @Failure		500	{object}	map[string]string	"Error deleting alias"
+// @Router			/taxonomy/{kind}/aliases/{id} [delete]
+func (h *TaxonomyHandler) DeleteAlias(w http.ResponseWriter, r *http.Request) {
+	kind, ok := h.kind(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.taxonomyService.DeleteAlias(r.Context(), kind, mux.Vars(r)["id"]); err != nil {
+		if respondWithServiceError(w, err) {
+			return
+		}
+		logger.Ctx(r.Context(), h.logger).Error("Error deleting alias", zap.Error(err))
+		respondWithError(w, http.StatusInternalServerError, "Error deleting alias")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary		List unmapped labels
+// @Description	Retrieves raw rating or action labels seen during sync that are not mapped to any canonical label yet (they score 0)
+// @Tags			taxonomy
+// @Accept			json
+// @Produce		json
+// @Param			kind	path		string	true	"Label kind"	Enums(rating, action)
+// @Success		200		{object}	map[string][]models.UnmappedLabel
+// @Failure		400		{object}	map[string]string	"Invalid kind"
+// @Failure		500		{object}	map[string]string	"Error getting unmapped labels"
+// @Router			/taxonomy/{kind}/unmapped [get]
+func (h *TaxonomyHandler) ListUnmapped(w http.ResponseWriter, r *http.Request) {
+	kind, ok := h.kind(w, r)
+	if !ok {
+		return
+	}
+
+	labels, err := h.taxonomyService.ListUnmapped(r.Context(), kind)
+	if err != nil {
+		logger.Ctx(r.Context(), h.logger).Error("Error getting unmapped labels", zap.Error(err))
+		respondWithError(w, http.StatusInternalServerError, "Error getting unmapped labels")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"items": labels,
+	})
+}
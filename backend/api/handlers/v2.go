@@ -0,0 +1,344 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+
+	"github.com/liferip/stock-analyzer/backend/api/problem"
+	"github.com/liferip/stock-analyzer/backend/internal/models"
+	"github.com/liferip/stock-analyzer/backend/internal/requestid"
+	"github.com/liferip/stock-analyzer/backend/internal/service"
+	"github.com/liferip/stock-analyzer/backend/pkg/logger"
+)
+
+const (
+	// v2DefaultLimit es la cantidad de elementos devueltos por /api/v2 cuando no se indica limit
+	v2DefaultLimit = 20
+	// v2MaxLimit es la cantidad máxima de elementos por página en /api/v2
+	v2MaxLimit = 100
+	// v2DefaultRecommendations es la cantidad de recomendaciones devueltas cuando no se indica limit
+	v2DefaultRecommendations = 5
+)
+
+// Envelope es el cuerpo de todas las respuestas exitosas de /api/v2
+type Envelope struct {
+	Data interface{} `json:"data"`
+	Meta Meta        `json:"meta"`
+}
+
+// Meta contiene los datos de la respuesta que no forman parte del recurso
+type Meta struct {
+	RequestID  string      `json:"request_id" example:"3f1c2b9e-5c1d-4f7a-9d62-0b8f1e0c7a41"`
+	Pagination *Pagination `json:"pagination,omitempty"`
+}
+
+// Pagination describe la página devuelta de una lista
+type Pagination struct {
+	Total  int64 `json:"total" example:"240"`
+	Limit  int   `json:"limit" example:"20"`
+	Offset int   `json:"offset" example:"0"`
+}
+
+// SyncResult es el resultado de una sincronización
+type SyncResult struct {
+	Count int `json:"count" example:"120"`
+}
+
+// StockV2Handler maneja las solicitudes de /api/v2: todas las respuestas usan Envelope,
+// las listas vacías se devuelven como [] y los errores como problem+json
+type StockV2Handler struct {
+	stockService service.StockService
+	logger       *zap.Logger
+}
+
+// NewStockV2Handler crea una nueva instancia de StockV2Handler
+func NewStockV2Handler(
+	stockService service.StockService,
+	logger *zap.Logger,
+) *StockV2Handler {
+	return &StockV2Handler{
+		stockService: stockService,
+		logger:       logger.Named("stock_v2_handler"),
+	}
+}
+
+// @Summary		List stocks (v2)
+// @Description	Retrieves a page of stocks, optionally filtered by ticker and brokerage
+// @Tags			v2
+// @Produce		json
+// @Param			ticker		query		string	false	"Filter by ticker"
+// @Param			brokerage	query		string	false	"Filter by brokerage"
+// @Param			limit		query		int		false	"Page size, from 1 to 100"	default(20)
+// @Param			offset		query		int		false	"Number of items to skip"	default(0)
+// @Success		200			{object}	Envelope{data=[]models.Stock}
+// @Failure		400			{object}	problem.Problem	"Invalid parameters"
+// @Failure		500			{object}	problem.Problem	"Error getting stocks"
+// @Router			/v2/stocks [get]
+func (h *StockV2Handler) ListStocks(w http.ResponseWriter, r *http.Request) {
+	limit, offset, err := pageParams(r, v2DefaultLimit)
+	if err != nil {
+		problem.Write(w, r, http.StatusBadRequest, problem.CodeInvalidRequest, err.Error())
+		return
+	}
+
+	query := r.URL.Query()
+	stocks, total, err := h.stockService.FindStocks(r.Context(), models.StockFilter{
+		Ticker:    query.Get("ticker"),
+		Brokerage: query.Get("brokerage"),
+		Limit:     limit,
+		Offset:    offset,
+	})
+	if err != nil {
+		h.respondWithProblem(w, r, err, "Error getting stocks")
+		return
+	}
+
+	respondWithData(w, r, http.StatusOK, emptyIfNil(stocks), &Pagination{Total: total, Limit: limit, Offset: offset})
+}
+
+// @Summary		Get stock (v2)
+// @Description	Retrieves the latest record of a ticker
+// @Tags			v2
+// @Produce		json
+// @Param			ticker	path		string	true	"Stock ticker symbol (e.g. AAPL)"
+// @Success		200		{object}	Envelope{data=models.Stock}
+// @Failure		404		{object}	problem.Problem	"Stock not found"
+// @Failure		500		{object}	problem.Problem	"Error getting stock"
+// @Router			/v2/stocks/{ticker} [get]
+func (h *StockV2Handler) GetStock(w http.ResponseWriter, r *http.Request) {
+	ticker := mux.Vars(r)["ticker"]
+
+	stock, err := h.stockService.GetStockByTicker(r.Context(), ticker)
+	if err != nil {
+		h.respondWithProblem(w, r, err, "Error getting stock")
+		return
+	}
+	if stock == nil {
+		problem.Write(w, r, http.StatusNotFound, problem.CodeNotFound, fmt.Sprintf("stock %q not found", ticker))
+		return
+	}
+
+	respondWithData(w, r, http.StatusOK, stock, nil)
+}
+
+// @Summary		List recommendations (v2)
+// @Description	Retrieves the current recommendations, or those computed from the records of one day. Recommendations for a day are limited to the top 5
+// @Tags			v2
+// @Produce		json
+// @Param			date	query		string	false	"Day of the records, as YYYY-MM-DD"
+// @Param			sort	query		string	false	"Sort order"	Enums(score, upside)	default(score)
+// @Param			limit	query		int		false	"Number of recommendations, from 1 to 100"	default(5)
+// @Success		200		{object}	Envelope{data=[]models.StockRecommendation}
+// @Failure		400		{object}	problem.Problem	"Invalid parameters"
+// @Failure		500		{object}	problem.Problem	"Error getting recommendations"
+// @Router			/v2/recommendations [get]
+func (h *StockV2Handler) ListRecommendations(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	sortBy, err := service.ParseRecommendationSort(query.Get("sort"))
+	if err != nil {
+		problem.Write(w, r, http.StatusBadRequest, problem.CodeInvalidRequest, "sort must be 'score' or 'upside'")
+		return
+	}
+
+	limit, err := intParam(r, "limit", v2DefaultRecommendations, 1, v2MaxLimit)
+	if err != nil {
+		problem.Write(w, r, http.StatusBadRequest, problem.CodeInvalidRequest, err.Error())
+		return
+	}
+
+	var recommendations []models.StockRecommendation
+	if date := query.Get("date"); date != "" {
+		if _, err := time.Parse(time.DateOnly, date); err != nil {
+			problem.Write(w, r, http.StatusBadRequest, problem.CodeInvalidRequest, "date must be formatted as YYYY-MM-DD")
+			return
+		}
+		recommendations, err = h.stockService.GetRecommendationsByTime(r.Context(), date, sortBy)
+		if len(recommendations) > limit {
+			recommendations = recommendations[:limit]
+		}
+	} else {
+		recommendations, err = h.stockService.GetTopRecommendations(r.Context(), sortBy, limit)
+	}
+	if err != nil {
+		h.respondWithProblem(w, r, err, "Error getting recommendations")
+		return
+	}
+
+	respondWithData(w, r, http.StatusOK, emptyIfNil(recommendations), nil)
+}
+
+// @Summary		List rating events (v2)
+// @Description	Retrieves a page of the rating history, newest first, optionally filtered by ticker, brokerage and time range
+// @Tags			v2
+// @Produce		json
+// @Param			ticker		query		string	false	"Filter by ticker"
+// @Param			brokerage	query		string	false	"Filter by brokerage"
+// @Param			from		query		string	false	"Earliest event time, as RFC 3339 or YYYY-MM-DD"
+// @Param			to			query		string	false	"Latest event time, as RFC 3339 or YYYY-MM-DD"
+// @Param			limit		query		int		false	"Page size, from 1 to 100"	default(20)
+// @Param			offset		query		int		false	"Number of items to skip"	default(0)
+// @Success		200			{object}	Envelope{data=[]models.RatingEvent}
+// @Failure		400			{object}	problem.Problem	"Invalid parameters"
+// @Failure		500			{object}	problem.Problem	"Error getting rating events"
+// @Router			/v2/rating-events [get]
+func (h *StockV2Handler) ListRatingEvents(w http.ResponseWriter, r *http.Request) {
+	limit, offset, err := pageParams(r, v2DefaultLimit)
+	if err != nil {
+		problem.Write(w, r, http.StatusBadRequest, problem.CodeInvalidRequest, err.Error())
+		return
+	}
+
+	query := r.URL.Query()
+	filter := models.RatingEventFilter{
+		Ticker:    query.Get("ticker"),
+		Brokerage: query.Get("brokerage"),
+		Limit:     limit,
+		Offset:    offset,
+	}
+	if filter.From, err = timeParam(r, "from"); err != nil {
+		problem.Write(w, r, http.StatusBadRequest, problem.CodeInvalidRequest, err.Error())
+		return
+	}
+	if filter.To, err = timeParam(r, "to"); err != nil {
+		problem.Write(w, r, http.StatusBadRequest, problem.CodeInvalidRequest, err.Error())
+		return
+	}
+
+	events, total, err := h.stockService.GetRatingHistory(r.Context(), filter)
+	if err != nil {
+		h.respondWithProblem(w, r, err, "Error getting rating events")
+		return
+	}
+
+	respondWithData(w, r, http.StatusOK, emptyIfNil(events), &Pagination{Total: total, Limit: limit, Offset: offset})
+}
+
+// @Summary		List brokerages (v2)
+// @Description	Retrieves the brokerages with their number of rating events and tickers
+// @Tags			v2
+// @Produce		json
+// @Success		200	{object}	Envelope{data=[]models.BrokerageSummary}
+// @Failure		500	{object}	problem.Problem	"Error getting brokerages"
+// @Router			/v2/brokerages [get]
+func (h *StockV2Handler) ListBrokerages(w http.ResponseWriter, r *http.Request) {
+	brokerages, err := h.stockService.GetBrokerages(r.Context())
+	if err != nil {
+		h.respondWithProblem(w, r, err, "Error getting brokerages")
+		return
+	}
+
+	respondWithData(w, r, http.StatusOK, emptyIfNil(brokerages), nil)
+}
+
+// @Summary		Synchronize stocks (v2)
+// @Description	Synchronizes the stocks from the external API. Requires the write:sync scope
+// @Tags			v2
+// @Produce		json
+// @Success		200	{object}	Envelope{data=SyncResult}
+// @Failure		500	{object}	problem.Problem	"Error synchronizing stocks"
+// @Router			/v2/sync [post]
+func (h *StockV2Handler) SyncStocks(w http.ResponseWriter, r *http.Request) {
+	count, err := h.stockService.SyncStocksFromAPI(r.Context())
+	if err != nil {
+		h.respondWithProblem(w, r, err, "Error synchronizing stocks")
+		return
+	}
+
+	respondWithData(w, r, http.StatusOK, SyncResult{Count: count}, nil)
+}
+
+// respondWithProblem envía un error del servicio como problem+json; los errores que no son
+// de validación se registran y se devuelven como 500 con un mensaje genérico
+func (h *StockV2Handler) respondWithProblem(w http.ResponseWriter, r *http.Request, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrInvalidInput):
+		problem.Write(w, r, http.StatusBadRequest, problem.CodeInvalidRequest, err.Error())
+	case errors.Is(err, service.ErrNotFound):
+		problem.Write(w, r, http.StatusNotFound, problem.CodeNotFound, err.Error())
+	case errors.Is(err, service.ErrConflict):
+		problem.Write(w, r, http.StatusConflict, problem.CodeConflict, err.Error())
+	default:
+		logger.Ctx(r.Context(), h.logger).Error(message, zap.String("request_id", requestid.FromContext(r.Context())), zap.Error(err))
+		problem.Write(w, r, http.StatusInternalServerError, problem.CodeInternal, message)
+	}
+}
+
+// respondWithData envía una respuesta exitosa de /api/v2 dentro de Envelope
+func respondWithData(w http.ResponseWriter, r *http.Request, status int, data interface{}, pagination *Pagination) {
+	response, err := json.Marshal(Envelope{
+		Data: data,
+		Meta: Meta{
+			RequestID:  requestid.FromContext(r.Context()),
+			Pagination: pagination,
+		},
+	})
+	if err != nil {
+		problem.Write(w, r, http.StatusInternalServerError, problem.CodeInternal, "Error processing response")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(response)
+}
+
+// emptyIfNil devuelve una lista vacía en lugar de nil, para que se serialice como [] y no como null
+func emptyIfNil[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+	return items
+}
+
+// pageParams obtiene limit y offset de la solicitud
+func pageParams(r *http.Request, defaultLimit int) (int, int, error) {
+	limit, err := intParam(r, "limit", defaultLimit, 1, v2MaxLimit)
+	if err != nil {
+		return 0, 0, err
+	}
+	offset, err := intParam(r, "offset", 0, 0, -1)
+	if err != nil {
+		return 0, 0, err
+	}
+	return limit, offset, nil
+}
+
+// intParam obtiene un parámetro entero entre min y max (max negativo no limita)
+func intParam(r *http.Request, name string, defaultValue, min, max int) (int, error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return defaultValue, nil
+	}
+
+	value, err := strconv.Atoi(raw)
+	if err != nil || value < min || (max >= 0 && value > max) {
+		if max < 0 {
+			return 0, fmt.Errorf("%s must be an integer greater than or equal to %d", name, min)
+		}
+		return 0, fmt.Errorf("%s must be an integer from %d to %d", name, min, max)
+	}
+	return value, nil
+}
+
+// timeParam obtiene un parámetro de fecha con formato RFC 3339 o YYYY-MM-DD; vacío devuelve la fecha cero
+func timeParam(r *http.Request, name string) (time.Time, error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return time.Time{}, nil
+	}
+
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if value, err := time.Parse(layout, raw); err == nil {
+			return value, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%s must be formatted as RFC 3339 or YYYY-MM-DD", name)
+}
@@ -0,0 +1,237 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+
+	"github.com/liferip/stock-analyzer/backend/internal/models"
+	"github.com/liferip/stock-analyzer/backend/internal/service"
+	"github.com/liferip/stock-analyzer/backend/pkg/logger"
+)
+
+// WebhookHandler maneja las solicitudes de webhooks y su registro de entregas
+type WebhookHandler struct {
+	webhookService service.WebhookService
+	logger         *zap.Logger
+}
+
+// NewWebhookHandler crea una nueva instancia de WebhookHandler
+func NewWebhookHandler(
+	webhookService service.WebhookService,
+	logger *zap.Logger,
+) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+		logger:         logger.Named("webhook_handler"),
+	}
+}
+
+// @Summary		List webhooks
+// @Description	Retrieves all registered webhooks; secrets are never returned
+// @Tags			webhooks
+// @Accept			json
+// @Produce		json
+// @Success		200	{object}	map[string][]models.Webhook
+// @Failure		500	{object}	map[string]string	"Error getting webhooks"
+// @Router			/webhooks [get]
+func (h *WebhookHandler) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	webhooks, err := h.webhookService.ListWebhooks(r.Context())
+	if err != nil {
+		logger.Ctx(r.Context(), h.logger).Error("Error getting webhooks", zap.Error(err))
+		respondWithError(w, http.StatusInternalServerError, "Error getting webhooks")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"items": webhooks,
+	})
+}
+
+// @Summary		Get webhook
+// @Description	Retrieves a webhook by its ID
+// @Tags			webhooks
+// @Accept			json
+// @Produce		json
+// @Param			id	path		string	true	"Webhook ID"
+// @Success		200	{object}	map[string]models.Webhook
+// @Failure		404	{object}	map[string]string	"Webhook not found"
+// @Failure		500	{object}	map[string]string	"Error getting webhook"
+// @Router			/webhooks/{id} [get]
+func (h *WebhookHandler) GetWebhook(w http.ResponseWriter, r *http.Request) {
+	webhook, err := h.webhookService.GetWebhook(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		if respondWithServiceError(w, err) {
+			return
+		}
+		logger.Ctx(r.Context(), h.logger).Error("Error getting webhook", zap.Error(err))
+		respondWithError(w, http.StatusInternalServerError, "Error getting webhook")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"item": webhook,
+	})
+}
+
+// @Summary		Create webhook
+// @Description	Registers a webhook endpoint. An empty events list subscribes to all events (sync.completed, stock.saved, alert.triggered). The signing secret is generated when omitted and only returned in this response
+// @Tags			webhooks
+// @Accept			json
+// @Produce		json
+// @Param			webhook	body		models.WebhookInput	true	"Webhook URL, secret and events"
+// @Success		201		{object}	map[string]interface{}
+// @Failure		400		{object}	map[string]string	"Invalid webhook"
+// @Failure		500		{object}	map[string]string	"Error creating webhook"
+// @Router			/webhooks [post]
+func (h *WebhookHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	var input models.WebhookInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	webhook, secret, err := h.webhookService.CreateWebhook(r.Context(), input)
+	if err != nil {
+		if respondWithServiceError(w, err) {
+			return
+		}
+		logger.Ctx(r.Context(), h.logger).Error("Error creating webhook", zap.Error(err))
+		respondWithError(w, http.StatusInternalServerError, "Error creating webhook")
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, map[string]interface{}{
+		"item":   webhook,
+		"secret": secret,
+	})
+}
+
+// @Summary		Update webhook
+// @Description	Replaces the URL and events of a webhook; the secret and enabled flag are kept when omitted
+// @Tags			webhooks
+// @Accept			json
+// @Produce		json
+// @Param			id		path		string				true	"Webhook ID"
+// @Param			webhook	body		models.WebhookInput	true	"Webhook URL, secret and events"
+// @Success		200		{object}	map[string]models.Webhook
+// @Failure		400		{object}	map[string]string	"Invalid webhook"
+// @Failure		404		{object}	map[string]string	"Webhook not found"
+// @Failure		500		{object}	map[string]string	"Error updating webhook"
+// @Router			/webhooks/{id} [put]
+func (h *WebhookHandler) UpdateWebhook(w http.ResponseWriter, r *http.Request) {
+	var input models.WebhookInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	webhook, err := h.webhookService.UpdateWebhook(r.Context(), mux.Vars(r)["id"], input)
+	if err != nil {
+		if respondWithServiceError(w, err) {
+			return
+		}
+		logger.Ctx(r.Context(), h.logger).Error("Error updating webhook", zap.Error(err))
+		respondWithError(w, http.StatusInternalServerError, "Error updating webhook")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"item": webhook,
+	})
+}
+
+// @Summary		Delete webhook
+// @Description	Deletes a webhook and drops its pending deliveries; past deliveries stay in the log
+// @Tags			webhooks
+// @Accept			json
+// @Produce		json
+// @Param			id	path	string	true	"Webhook ID"
+// @Success		204
+// @Failure		404	{object}	map[string]string	"Webhook not found"
+// @Failure		500	{object}	map[string]string	"Error deleting webhook"
+// @Router			/webhooks/{id} [delete]
+func (h *WebhookHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	if err := h.webhookService.DeleteWebhook(r.Context(), mux.Vars(r)["id"]); err != nil {
+		if respondWithServiceError(w, err) {
+			return
+		}
+		logger.Ctx(r.Context(), h.logger).Error("Error deleting webhook", zap.Error(err))
+		respondWithError(w, http.StatusInternalServerError, "Error deleting webhook")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary		Ping webhook
+// @Description	Queues a signed webhook.ping event for the webhook, to check the receiver
+// @Tags			webhooks
+// @Accept			json
+// @Produce		json
+// @Param			id	path		string	true	"Webhook ID"
+// @Success		202	{object}	map[string]models.WebhookDelivery
+// @Failure		404	{object}	map[string]string	"Webhook not found"
+// @Failure		500	{object}	map[string]string	"Error pinging webhook"
+// @Router			/webhooks/{id}/ping [post]
+func (h *WebhookHandler) PingWebhook(w http.ResponseWriter, r *http.Request) {
+	delivery, err := h.webhookService.PingWebhook(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		if respondWithServiceError(w, err) {
+			return
+		}
+		logger.Ctx(r.Context(), h.logger).Error("Error pinging webhook", zap.Error(err))
+		respondWithError(w, http.StatusInternalServerError, "Error pinging webhook")
+		return
+	}
+
+	respondWithJSON(w, http.StatusAccepted, map[string]interface{}{
+		"item": delivery,
+	})
+}
+
+// @Summary		List webhook deliveries
+// @Description	Retrieves the delivery log with attempts, last status code and error, newest first
+// @Tags			webhooks
+// @Accept			json
+// @Produce		json
+// @Param			webhook_id	query		string	false	"Filter by webhook ID"
+// @Param			status		query		string	false	"Filter by status"	Enums(pending, succeeded, failed)
+// @Param			limit		query		int		false	"Maximum number of deliveries (default 100, max 1000)"
+// @Success		200			{object}	map[string][]models.WebhookDelivery
+// @Failure		400			{object}	map[string]string	"Invalid query parameters"
+// @Failure		500			{object}	map[string]string	"Error getting webhook deliveries"
+// @Router			/webhooks/deliveries [get]
+func (h *WebhookHandler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := models.WebhookDeliveryFilter{
+		WebhookID: query.Get("webhook_id"),
+		Status:    query.Get("status"),
+	}
+
+	if limit := query.Get("limit"); limit != "" {
+		parsed, err := strconv.Atoi(limit)
+		if err != nil || parsed <= 0 {
+			respondWithError(w, http.StatusBadRequest, "Invalid limit, expected a positive integer")
+			return
+		}
+		filter.Limit = parsed
+	}
+
+	deliveries, err := h.webhookService.ListDeliveries(r.Context(), filter)
+	if err != nil {
+		if respondWithServiceError(w, err) {
+			return
+		}
+		logger.Ctx(r.Context(), h.logger).Error("Error getting webhook deliveries", zap.Error(err))
+		respondWithError(w, http.StatusInternalServerError, "Error getting webhook deliveries")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"items": deliveries,
+	})
+}
@@ -0,0 +1,372 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"github.com/liferip/stock-analyzer/backend/internal/cors"
+	"github.com/liferip/stock-analyzer/backend/internal/events"
+	"github.com/liferip/stock-analyzer/backend/pkg/logger"
+)
+
+const (
+	// wsSendBuffer es la cantidad de mensajes pendientes por conexión antes de desconectar al cliente
+	wsSendBuffer = 64
+	// wsWriteTimeout es el tiempo máximo para escribir un mensaje
+	wsWriteTimeout = 10 * time.Second
+	// wsPongTimeout es el tiempo máximo sin recibir un pong del cliente
+	wsPongTimeout = 60 * time.Second
+	// wsPingInterval es cada cuánto se envía un ping; debe ser menor que wsPongTimeout
+	wsPingInterval = 30 * time.Second
+	// wsMaxMessageSize es el tamaño máximo de un mensaje del cliente
+	wsMaxMessageSize = 4096
+	// wsAllTickers suscribe a los cambios de todos los tickers
+	wsAllTickers = "*"
+)
+
+// Tipos de mensajes del protocolo WebSocket
+const (
+	wsMessageSubscribe       = "subscribe"
+	wsMessageUnsubscribe     = "unsubscribe"
+	wsMessagePing            = "ping"
+	wsMessagePong            = "pong"
+	wsMessageSubscriptions   = "subscriptions"
+	wsMessageStock           = "stock"
+	wsMessageRecommendations = "recommendations"
+	wsMessageError           = "error"
+)
+
+// wsClientMessage es un mensaje enviado por el cliente
+type wsClientMessage struct {
+	Type            string   `json:"type"`
+	Tickers         []string `json:"tickers"`
+	Recommendations bool     `json:"recommendations"`
+}
+
+// wsServerMessage es un mensaje enviado al cliente
+type wsServerMessage struct {
+	Type            string      `json:"type"`
+	Tickers         []string    `json:"tickers,omitempty"`
+	Recommendations *bool       `json:"recommendations,omitempty"`
+	Data            interface{} `json:"data,omitempty"`
+	Error           string      `json:"error,omitempty"`
+}
+
+// wsClient es una conexión WebSocket con sus suscripciones
+type wsClient struct {
+	conn *websocket.Conn
+	send chan []byte
+	done chan struct{}
+	once sync.Once
+
+	mu              sync.Mutex
+	tickers         map[string]bool
+	recommendations bool
+}
+
+// WebSocketHandler maneja las conexiones WebSocket y les distribuye los eventos suscritos
+type WebSocketHandler struct {
+	upgrader websocket.Upgrader
+	logger   *zap.Logger
+
+	mu      sync.Mutex
+	clients map[*wsClient]struct{}
+	closed  bool
+}
+
+// NewWebSocketHandler crea una nueva instancia de WebSocketHandler, suscrita al bus de eventos
+// y con el cierre de todas las conexiones registrado en el apagado de la aplicación
+func NewWebSocketHandler(
+	lc fx.Lifecycle,
+	bus *events.Bus,
+	policy *cors.Policy,
+	logger *zap.Logger,
+) *WebSocketHandler {
+	h := &WebSocketHandler{
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 1024,
+			// Los navegadores no aplican CORS a los WebSockets: se aceptan el mismo origen y los
+			// orígenes de la política CORS de la API
+			CheckOrigin: func(r *http.Request) bool { return checkOrigin(r, policy) },
+		},
+		logger:  logger.Named("websocket_handler"),
+		clients: make(map[*wsClient]struct{}),
+	}
+
+	bus.Subscribe(h.handleEvent)
+
+	lc.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			h.closeAll(ctx)
+			return nil
+		},
+	})
+
+	return h
+}
+
+// checkOrigin acepta las conexiones sin Origin, que no vienen de un navegador, las del mismo
+// host y las de los orígenes que acepta la política CORS
+func checkOrigin(r *http.Request, policy *cors.Policy) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	if u, err := url.Parse(origin); err == nil && strings.EqualFold(u.Host, r.Host) {
+		return true
+	}
+	return policy.AllowOrigin(origin)
+}
+
+// @Summary		Subscribe over WebSocket
+// @Description	Upgrades to a WebSocket. Clients send {"type":"subscribe","tickers":["AAPL"],"recommendations":true} or {"type":"unsubscribe",...} ("*" means all tickers) and {"type":"ping"}. The server sends "subscriptions" acknowledgements, "stock" messages for subscribed tickers, "recommendations" when the recommendation list changes, "pong" and "error". Clients that fall behind are disconnected with close code 1013
+// @Tags			stocks
+// @Success		101
+// @Failure		400	{object}	map[string]string	"Not a WebSocket handshake"
+// @Failure		403	{string}	string	"Origin not allowed by the CORS policy"
+// @Router			/stock/ws [get]
+func (h *WebSocketHandler) Subscribe(w http.ResponseWriter, r *http.Request) {
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// Upgrade ya respondió con el error al cliente
+		logger.Ctx(r.Context(), h.logger).Debug("WebSocket upgrade failed", zap.Error(err))
+		return
+	}
+
+	client := &wsClient{
+		conn:    conn,
+		send:    make(chan []byte, wsSendBuffer),
+		done:    make(chan struct{}),
+		tickers: make(map[string]bool),
+	}
+
+	if !h.register(client) {
+		conn.WriteControl(websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseGoingAway, "server shutting down"),
+			time.Now().Add(wsWriteTimeout))
+		conn.Close()
+		return
+	}
+
+	go h.writePump(client)
+	h.readPump(client)
+}
+
+// register agrega un cliente, salvo que el servidor se esté apagando
+func (h *WebSocketHandler) register(client *wsClient) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return false
+	}
+	h.clients[client] = struct{}{}
+	return true
+}
+
+// unregister elimina un cliente y detiene su escritura
+func (h *WebSocketHandler) unregister(client *wsClient) {
+	h.mu.Lock()
+	delete(h.clients, client)
+	h.mu.Unlock()
+
+	client.once.Do(func() { close(client.done) })
+}
+
+// readPump procesa los mensajes del cliente hasta que se cierre la conexión
+func (h *WebSocketHandler) readPump(client *wsClient) {
+	defer func() {
+		h.unregister(client)
+		client.conn.Close()
+	}()
+
+	client.conn.SetReadLimit(wsMaxMessageSize)
+	client.conn.SetReadDeadline(time.Now().Add(wsPongTimeout))
+	client.conn.SetPongHandler(func(string) error {
+		return client.conn.SetReadDeadline(time.Now().Add(wsPongTimeout))
+	})
+
+	for {
+		_, data, err := client.conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				h.logger.Debug("WebSocket closed", zap.Error(err))
+			}
+			return
+		}
+
+		var msg wsClientMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			h.enqueue(client, wsServerMessage{Type: wsMessageError, Error: "invalid JSON message"})
+			continue
+		}
+
+		h.handleMessage(client, msg)
+	}
+}
+
+// handleMessage aplica un mensaje del protocolo a las suscripciones del cliente
+func (h *WebSocketHandler) handleMessage(client *wsClient, msg wsClientMessage) {
+	switch msg.Type {
+	case wsMessagePing:
+		h.enqueue(client, wsServerMessage{Type: wsMessagePong})
+		return
+	case wsMessageSubscribe, wsMessageUnsubscribe:
+	default:
+		h.enqueue(client, wsServerMessage{Type: wsMessageError, Error: "unknown message type, expected subscribe, unsubscribe or ping"})
+		return
+	}
+
+	subscribe := msg.Type == wsMessageSubscribe
+
+	client.mu.Lock()
+	for _, ticker := range msg.Tickers {
+		ticker = strings.ToUpper(strings.TrimSpace(ticker))
+		if ticker == "" {
+			continue
+		}
+		if subscribe {
+			client.tickers[ticker] = true
+		} else {
+			delete(client.tickers, ticker)
+		}
+	}
+	if msg.Recommendations {
+		client.recommendations = subscribe
+	}
+
+	tickers := make([]string, 0, len(client.tickers))
+	for ticker := range client.tickers {
+		tickers = append(tickers, ticker)
+	}
+	recommendations := client.recommendations
+	client.mu.Unlock()
+
+	h.enqueue(client, wsServerMessage{
+		Type:            wsMessageSubscriptions,
+		Tickers:         tickers,
+		Recommendations: &recommendations,
+	})
+}
+
+// writePump envía los mensajes pendientes y los pings hasta que se cierre el cliente
+func (h *WebSocketHandler) writePump(client *wsClient) {
+	ping := time.NewTicker(wsPingInterval)
+	defer func() {
+		ping.Stop()
+		client.conn.Close()
+	}()
+
+	for {
+		select {
+		case <-client.done:
+			return
+		case message := <-client.send:
+			client.conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+			if err := client.conn.WriteMessage(websocket.TextMessage, message); err != nil {
+				return
+			}
+		case <-ping.C:
+			client.conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+			if err := client.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// enqueue encola un mensaje sin bloquear; si el cliente no lee a tiempo se lo desconecta
+func (h *WebSocketHandler) enqueue(client *wsClient, msg wsServerMessage) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		h.logger.Error("Error encoding WebSocket message", zap.Error(err))
+		return
+	}
+
+	select {
+	case <-client.done:
+	case client.send <- data:
+	default:
+		h.logger.Warn("Disconnecting slow WebSocket client")
+		h.unregister(client)
+		client.conn.WriteControl(websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "client too slow"),
+			time.Now().Add(wsWriteTimeout))
+		client.conn.Close()
+	}
+}
+
+// handleEvent distribuye los eventos del bus a los clientes suscritos
+func (h *WebSocketHandler) handleEvent(ctx context.Context, event events.Event) {
+	var msg wsServerMessage
+	var match func(*wsClient) bool
+
+	switch event.Type {
+	case events.StockSaved:
+		payload := event.Payload.(events.StockSavedPayload)
+		ticker := strings.ToUpper(payload.Stock.Ticker)
+		msg = wsServerMessage{Type: wsMessageStock, Data: payload}
+		match = func(client *wsClient) bool {
+			return client.tickers[ticker] || client.tickers[wsAllTickers]
+		}
+	case events.RecommendationsChanged:
+		msg = wsServerMessage{Type: wsMessageRecommendations, Data: event.Payload}
+		match = func(client *wsClient) bool {
+			return client.recommendations
+		}
+	default:
+		return
+	}
+
+	h.mu.Lock()
+	clients := make([]*wsClient, 0, len(h.clients))
+	for client := range h.clients {
+		clients = append(clients, client)
+	}
+	h.mu.Unlock()
+
+	for _, client := range clients {
+		client.mu.Lock()
+		ok := match(client)
+		client.mu.Unlock()
+
+		if ok {
+			h.enqueue(client, msg)
+		}
+	}
+}
+
+// closeAll envía un cierre a todos los clientes y rechaza nuevas conexiones
+func (h *WebSocketHandler) closeAll(ctx context.Context) {
+	h.mu.Lock()
+	h.closed = true
+	clients := make([]*wsClient, 0, len(h.clients))
+	for client := range h.clients {
+		clients = append(clients, client)
+	}
+	h.mu.Unlock()
+
+	deadline := time.Now().Add(wsWriteTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+
+	logger.Ctx(ctx, h.logger).Info("Closing WebSocket connections", zap.Int("count", len(clients)))
+	for _, client := range clients {
+		h.unregister(client)
+		client.conn.WriteControl(websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseGoingAway, "server shutting down"),
+			deadline)
+		client.conn.Close()
+	}
+}
@@ -0,0 +1,43 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/liferip/stock-analyzer/backend/internal/health"
+)
+
+// probePaths son las rutas de los chequeos de salud, que el orquestador consulta cada pocos segundos
+var probePaths = map[string]bool{
+	"/healthz": true,
+	"/readyz":  true,
+	"/up":      true,
+}
+
+// livenessHandler indica que el proceso está vivo. No consulta dependencias, para que el
+// orquestador no reinicie la aplicación por una falla de la base de datos.
+func livenessHandler(w http.ResponseWriter, r *http.Request) {
+	respondWithHealth(w, http.StatusOK, map[string]health.Status{"status": health.StatusOK})
+}
+
+// readinessHandler indica si la aplicación puede atender solicitudes, con el resultado de cada
+// chequeo. Responde 503 si la base de datos no está disponible.
+func readinessHandler(checker *health.Checker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		report := checker.Check(r.Context())
+
+		status := http.StatusOK
+		if !report.Ready() {
+			status = http.StatusServiceUnavailable
+		}
+		respondWithHealth(w, status, report)
+	}
+}
+
+// respondWithHealth envía el resultado de un chequeo, que no se debe guardar en caché
+func respondWithHealth(w http.ResponseWriter, status int, payload interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(payload)
+}
@@ -0,0 +1,125 @@
+package api
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/liferip/stock-analyzer/backend/internal/requestid"
+	"github.com/liferip/stock-analyzer/backend/pkg/logger"
+)
+
+// accessLogMiddleware agrega al contexto un logger con el ID de la solicitud y de su traza, que
+// usan los handlers, servicios y repositorios, y registra cada solicitud al terminar con su
+// estado, duración y tamaño. Debe ejecutarse después de requestIDMiddleware y tracingMiddleware.
+func accessLogMiddleware(base *zap.Logger) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			requestLogger := base.With(zap.String("request_id", requestid.FromContext(r.Context())))
+			if spanContext := trace.SpanContextFromContext(r.Context()); spanContext.IsValid() {
+				requestLogger = requestLogger.With(zap.String("trace_id", spanContext.TraceID().String()))
+			}
+			recorder := &statusRecorder{ResponseWriter: w}
+
+			next.ServeHTTP(recorder, r.WithContext(logger.WithContext(r.Context(), requestLogger)))
+
+			status := recorder.status
+			if status == 0 {
+				// El handler no escribió nada: net/http responde 200 sin cuerpo
+				status = http.StatusOK
+			}
+
+			fields := []zap.Field{
+				zap.String("method", r.Method),
+				zap.String("path", r.URL.Path),
+				zap.String("route", routeTemplate(r)),
+				zap.Int("status", status),
+				zap.Duration("duration", time.Since(start)),
+				zap.Int64("bytes", recorder.bytes),
+				zap.String("remote", r.RemoteAddr),
+			}
+
+			accessLogger := requestLogger.Named("http")
+			switch {
+			case status >= http.StatusInternalServerError:
+				accessLogger.Error("Request completed", fields...)
+			case probePaths[r.URL.Path]:
+				// Los chequeos de salud exitosos solo se registran en modo debug
+				accessLogger.Debug("Request completed", fields...)
+			default:
+				accessLogger.Info("Request completed", fields...)
+			}
+		})
+	}
+}
+
+// routeTemplate obtiene la plantilla de la ruta de la solicitud, por ejemplo /api/stock/{ticker}
+func routeTemplate(r *http.Request) string {
+	route := mux.CurrentRoute(r)
+	if route == nil {
+		return ""
+	}
+	template, err := route.GetPathTemplate()
+	if err != nil {
+		return ""
+	}
+	return template
+}
+
+// statusRecorder guarda el estado y la cantidad de bytes de una respuesta. Expone el
+// ResponseWriter original con Unwrap y delega Flush y Hijack, que usan el streaming de
+// eventos y los WebSockets.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+// WriteHeader guarda el estado de la respuesta
+func (sr *statusRecorder) WriteHeader(status int) {
+	if sr.status == 0 {
+		sr.status = status
+	}
+	sr.ResponseWriter.WriteHeader(status)
+}
+
+// Write cuenta los bytes escritos en la respuesta
+func (sr *statusRecorder) Write(b []byte) (int, error) {
+	if sr.status == 0 {
+		sr.status = http.StatusOK
+	}
+	n, err := sr.ResponseWriter.Write(b)
+	sr.bytes += int64(n)
+	return n, err
+}
+
+// Flush envía al cliente los datos almacenados en el buffer
+func (sr *statusRecorder) Flush() {
+	if flusher, ok := sr.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Hijack toma el control de la conexión, como al aceptar un WebSocket
+func (sr *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := sr.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response writer does not support hijacking")
+	}
+	if sr.status == 0 {
+		sr.status = http.StatusSwitchingProtocols
+	}
+	return hijacker.Hijack()
+}
+
+// Unwrap devuelve el ResponseWriter original para http.ResponseController
+func (sr *statusRecorder) Unwrap() http.ResponseWriter {
+	return sr.ResponseWriter
+}
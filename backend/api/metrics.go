@@ -0,0 +1,29 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/liferip/stock-analyzer/backend/internal/metrics"
+)
+
+// metricsMiddleware registra la cantidad y la latencia de las solicitudes por plantilla de
+// ruta, para no crear una serie por cada ticker o ID
+func metricsMiddleware(m *metrics.Metrics) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			recorder := &statusRecorder{ResponseWriter: w}
+
+			next.ServeHTTP(recorder, r)
+
+			status := recorder.status
+			if status == 0 {
+				status = http.StatusOK
+			}
+			m.ObserveHTTPRequest(r.Method, routeTemplate(r), status, time.Since(start))
+		})
+	}
+}
@@ -0,0 +1,55 @@
+// Package problem escribe errores con el formato application/problem+json (RFC 7807) usado por /api/v2.
+package problem
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/liferip/stock-analyzer/backend/internal/requestid"
+)
+
+// ContentType es el tipo de contenido de las respuestas de error
+const ContentType = "application/problem+json"
+
+// Códigos de error legibles por máquina; el tipo del problema se deriva del código
+const (
+	CodeInvalidRequest   = "invalid_request"
+	CodeUnauthenticated  = "unauthenticated"
+	CodeForbidden        = "forbidden"
+	CodeNotFound         = "not_found"
+	CodeMethodNotAllowed = "method_not_allowed"
+	CodeConflict         = "conflict"
+	CodeRateLimited      = "rate_limited"
+	CodeInternal         = "internal_error"
+)
+
+// Problem es el cuerpo de una respuesta de error
+type Problem struct {
+	Type      string `json:"type" example:"urn:stock-analyzer:problem:not_found"`
+	Title     string `json:"title" example:"Not Found"`
+	Status    int    `json:"status" example:"404"`
+	Detail    string `json:"detail,omitempty" example:"stock \"XYZ\" not found"`
+	Instance  string `json:"instance,omitempty" example:"/api/v2/stocks/XYZ"`
+	Code      string `json:"code" example:"not_found"`
+	RequestID string `json:"request_id,omitempty" example:"3f1c2b9e-5c1d-4f7a-9d62-0b8f1e0c7a41"`
+}
+
+// New crea un problema para una solicitud
+func New(r *http.Request, status int, code, detail string) Problem {
+	return Problem{
+		Type:      "urn:stock-analyzer:problem:" + code,
+		Title:     http.StatusText(status),
+		Status:    status,
+		Detail:    detail,
+		Instance:  r.URL.Path,
+		Code:      code,
+		RequestID: requestid.FromContext(r.Context()),
+	}
+}
+
+// Write envía un problema al cliente
+func Write(w http.ResponseWriter, r *http.Request, status int, code, detail string) {
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(New(r, status, code, detail))
+}
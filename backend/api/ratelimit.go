@@ -0,0 +1,100 @@
+package api
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+
+	"github.com/liferip/stock-analyzer/backend/api/problem"
+	"github.com/liferip/stock-analyzer/backend/internal/auth"
+	"github.com/liferip/stock-analyzer/backend/internal/ratelimit"
+)
+
+// rateLimitMiddleware limita las solicitudes de cada cliente según el grupo de la ruta. Los
+// clientes autenticados se identifican por su credencial y los anónimos por su IP. Debe
+// ejecutarse después de authMiddleware.
+func rateLimitMiddleware(limiter *ratelimit.Limiter, prefix string, trustForwarded bool, logger *zap.Logger) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		if !limiter.Enabled() {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodOptions {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			client := rateLimitClient(r, trustForwarded)
+			result, ok := limiter.Allow(client, strings.TrimPrefix(r.URL.Path, prefix))
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// Headers RateLimit del borrador de la IETF (draft-ietf-httpapi-ratelimit-headers)
+			w.Header().Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d", result.Limit, ceilSeconds(result.Rule.Period)))
+			w.Header().Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+			w.Header().Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+			w.Header().Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.Reset)))
+
+			if !result.Allowed {
+				logger.Warn("Rate limit exceeded",
+					zap.String("client", client),
+					zap.String("group", result.Rule.Prefix),
+					zap.String("path", r.URL.Path))
+				respondRateLimited(w, r, result)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// respondRateLimited responde 429 con el tiempo que falta para que el cliente pueda reintentar
+func respondRateLimited(w http.ResponseWriter, r *http.Request, result ratelimit.Result) {
+	retryAfter := ceilSeconds(result.RetryAfter)
+	w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+	respondWithError(w, r, http.StatusTooManyRequests, problem.CodeRateLimited,
+		fmt.Sprintf("Rate limit exceeded, retry in %d seconds", retryAfter))
+}
+
+// rateLimitClient identifica al cliente de una solicitud para el limitador
+func rateLimitClient(r *http.Request, trustForwarded bool) string {
+	if principal := auth.PrincipalFromContext(r.Context()); principal != nil && principal.Method != auth.MethodAnonymous {
+		return principal.Subject
+	}
+	return "ip:" + clientIP(r, trustForwarded)
+}
+
+// clientIP obtiene la IP del cliente. Detrás de un proxy se usa la última dirección de
+// X-Forwarded-For, que es la que agregó el proxy y el cliente no puede falsificar.
+func clientIP(r *http.Request, trustForwarded bool) string {
+	if trustForwarded {
+		if forwarded := r.Header.Values("X-Forwarded-For"); len(forwarded) > 0 {
+			addresses := strings.Split(forwarded[len(forwarded)-1], ",")
+			if ip := strings.TrimSpace(addresses[len(addresses)-1]); ip != "" {
+				return ip
+			}
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// ceilSeconds redondea una duración hacia arriba a segundos enteros
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
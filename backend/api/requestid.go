@@ -0,0 +1,21 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/liferip/stock-analyzer/backend/internal/requestid"
+)
+
+// requestIDMiddleware asigna a cada solicitud el ID recibido en X-Request-ID, o uno nuevo si
+// no viene o no es válido, y lo devuelve en la respuesta
+func requestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(requestid.Header)
+		if !requestid.Valid(id) {
+			id = requestid.New()
+		}
+
+		w.Header().Set(requestid.Header, id)
+		next.ServeHTTP(w, r.WithContext(requestid.WithRequestID(r.Context(), id)))
+	})
+}
@@ -1,74 +1,96 @@
 package api
 
 import (
+	"encoding/json"
 	"net/http"
+	"strings"
 
 	"github.com/gorilla/mux"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 
-	"github.com/liferip/stock-analyzer/backend/api/handlers"
+	"github.com/liferip/stock-analyzer/backend/api/problem"
+	"github.com/liferip/stock-analyzer/backend/config"
+	"github.com/liferip/stock-analyzer/backend/internal/auth"
+	"github.com/liferip/stock-analyzer/backend/internal/cors"
+	"github.com/liferip/stock-analyzer/backend/internal/health"
+	"github.com/liferip/stock-analyzer/backend/internal/metrics"
+	"github.com/liferip/stock-analyzer/backend/internal/ratelimit"
 )
 
 // RegisterRoutesFn es un tipo para funciones que registran rutas
-type RegisterRoutesFn func(*mux.Router, *handlers.StockHandler)
+type RegisterRoutesFn func(*mux.Router)
 
 // NewRouter crea un nuevo router con todas las rutas configuradas
 func NewRouter(
 	logger *zap.Logger,
-	stockHandler *handlers.StockHandler,
-	registerStockRoutes RegisterRoutesFn,
+	cfg *config.Config,
+	authenticator *auth.Authenticator,
+	limiter *ratelimit.Limiter,
+	metrics *metrics.Metrics,
+	checker *health.Checker,
+	policy *cors.Policy,
+	registerRoutes RegisterRoutesFn,
 ) *mux.Router {
 	router := mux.NewRouter()
 
-	// Middleware healthcheck
-	router.HandleFunc("/up", func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusOK)
-	}).Methods(http.MethodGet)
+	// Middleware para el ID de cada solicitud
+	router.Use(requestIDMiddleware)
 
-	// Middleware para CORS
-	router.Use(corsMiddleware)
+	// Middleware para el trazado distribuido
+	router.Use(tracingMiddleware())
 
-	// Middleware para logging
-	router.Use(loggingMiddleware(logger))
+	// Middleware para logging de cada solicitud con su ID
+	router.Use(accessLogMiddleware(logger))
+
+	// Middleware para las métricas HTTP
+	router.Use(metricsMiddleware(metrics))
+
+	// Métricas en el formato de Prometheus. Exponen el tráfico de cada ruta, así que requieren
+	// el scope read:metrics y tienen los mismos límites que /api
+	metricsRouter := router.Path("/metrics").Subrouter()
+	metricsRouter.Use(authMiddleware(authenticator, limiter, cfg.RateLimitTrustForwarded, logger.Named("auth")))
+	metricsRouter.Use(rateLimitMiddleware(limiter, "", cfg.RateLimitTrustForwarded, logger.Named("rate_limit")))
+	RequireScope(metricsRouter.Methods(http.MethodGet).Handler(metrics.Handler()), auth.ScopeReadMetrics)
+
+	// Chequeos de salud: /healthz indica que el proceso está vivo y /readyz que puede atender
+	// solicitudes. /up se mantiene como alias de /healthz.
+	router.HandleFunc("/healthz", livenessHandler).Methods(http.MethodGet, http.MethodHead)
+	router.HandleFunc("/up", livenessHandler).Methods(http.MethodGet, http.MethodHead)
+	router.HandleFunc("/readyz", readinessHandler(checker)).Methods(http.MethodGet, http.MethodHead)
+
+	// Middleware para CORS. Las solicitudes OPTIONS se responden antes de llegar a /api, sin
+	// autenticación, porque mux no ejecuta los middlewares si ninguna ruta acepta el método.
+	router.Use(corsMiddleware(policy))
+	router.Methods(http.MethodOptions).HandlerFunc(preflightHandler(router, policy, logger.Named("cors")))
 
 	// API endpoints
 	api := router.PathPrefix("/api").Subrouter()
 
-	// Registrar rutas
-	registerStockRoutes(api, stockHandler)
+	// Middleware de autenticación y autorización, que también limita las credenciales
+	// inválidas por IP antes de autenticar
+	api.Use(authMiddleware(authenticator, limiter, cfg.RateLimitTrustForwarded, logger.Named("auth")))
 
-	return router
-}
-
-// corsMiddleware agrega los headers CORS necesarios
-func corsMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+	// Middleware de límite de solicitudes por cliente autenticado, o por IP si es anónimo
+	api.Use(rateLimitMiddleware(limiter, "/api", cfg.RateLimitTrustForwarded, logger.Named("rate_limit")))
 
-		if r.Method == "OPTIONS" {
-			w.WriteHeader(http.StatusOK)
-			return
-		}
+	// Registrar rutas
+	registerRoutes(api)
 
-		next.ServeHTTP(w, r)
-	})
+	return router
 }
 
-// loggingMiddleware registra información sobre cada solicitud HTTP
-func loggingMiddleware(logger *zap.Logger) mux.MiddlewareFunc {
-	return func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			logger.Info("Request",
-				zap.String("method", r.Method),
-				zap.String("path", r.URL.Path),
-				zap.String("remote", r.RemoteAddr),
-			)
-			next.ServeHTTP(w, r)
-		})
+// respondWithError envía un error con el formato de la versión de la API de la solicitud:
+// problem+json con un código legible por máquina en /api/v2, o {"error": ...} en /api
+func respondWithError(w http.ResponseWriter, r *http.Request, status int, code, message string) {
+	if strings.HasPrefix(r.URL.Path, "/api/v2/") {
+		problem.Write(w, r, status, code, message)
+		return
 	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
 }
 
 // Module proporciona las dependencias del router
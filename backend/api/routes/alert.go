@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/liferip/stock-analyzer/backend/api/handlers"
+)
+
+// RegisterAlertRoutes registra las rutas de reglas y eventos de alerta
+func RegisterAlertRoutes(router *mux.Router, alertHandler *handlers.AlertHandler) {
+
+	// Rutas para reglas de alerta
+	router.HandleFunc("/alerts/rules", alertHandler.ListRules).Methods(http.MethodGet)
+	router.HandleFunc("/alerts/rules", alertHandler.CreateRule).Methods(http.MethodPost)
+	router.HandleFunc("/alerts/rules/{id}", alertHandler.GetRule).Methods(http.MethodGet)
+	router.HandleFunc("/alerts/rules/{id}", alertHandler.UpdateRule).Methods(http.MethodPut)
+	router.HandleFunc("/alerts/rules/{id}", alertHandler.DeleteRule).Methods(http.MethodDelete)
+
+	// Rutas para alertas generadas
+	router.HandleFunc("/alerts/events", alertHandler.ListEvents).Methods(http.MethodGet)
+}
@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/liferip/stock-analyzer/backend/api"
+	"github.com/liferip/stock-analyzer/backend/api/handlers"
+	"github.com/liferip/stock-analyzer/backend/internal/auth"
+)
+
+// RegisterAPIKeyRoutes registra las rutas de administración de API keys; todas requieren el scope admin
+func RegisterAPIKeyRoutes(router *mux.Router, apiKeyHandler *handlers.APIKeyHandler) {
+	api.RequireScope(router.HandleFunc("/api-keys", apiKeyHandler.ListAPIKeys).Methods(http.MethodGet), auth.ScopeAdmin)
+	api.RequireScope(router.HandleFunc("/api-keys", apiKeyHandler.CreateAPIKey).Methods(http.MethodPost), auth.ScopeAdmin)
+	api.RequireScope(router.HandleFunc("/api-keys/{id}", apiKeyHandler.GetAPIKey).Methods(http.MethodGet), auth.ScopeAdmin)
+	api.RequireScope(router.HandleFunc("/api-keys/{id}", apiKeyHandler.RevokeAPIKey).Methods(http.MethodDelete), auth.ScopeAdmin)
+	api.RequireScope(router.HandleFunc("/api-keys/{id}/rotate", apiKeyHandler.RotateAPIKey).Methods(http.MethodPost), auth.ScopeAdmin)
+}
@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/liferip/stock-analyzer/backend/api/handlers"
+)
+
+// RegisterDigestRoutes registra las rutas del resumen diario por correo
+func RegisterDigestRoutes(router *mux.Router, digestHandler *handlers.DigestHandler) {
+	router.HandleFunc("/digest/preview", digestHandler.PreviewDigest).Methods(http.MethodGet)
+	router.HandleFunc("/digest/send", digestHandler.SendDigest).Methods(http.MethodPost)
+}
@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/liferip/stock-analyzer/backend/api"
+	"github.com/liferip/stock-analyzer/backend/api/handlers"
+	"github.com/liferip/stock-analyzer/backend/internal/auth"
+)
+
+// RegisterGraphQLRoutes registra las rutas de la API GraphQL
+func RegisterGraphQLRoutes(router *mux.Router, graphQLHandler *handlers.GraphQLHandler) {
+	// El esquema solo tiene consultas, por eso POST no requiere el scope admin
+	api.RequireScope(router.HandleFunc("/graphql", graphQLHandler.Query).Methods(http.MethodGet, http.MethodPost), auth.ScopeReadStocks)
+}
@@ -0,0 +1,38 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+	"go.uber.org/fx"
+
+	"github.com/liferip/stock-analyzer/backend/api"
+	"github.com/liferip/stock-analyzer/backend/api/handlers"
+)
+
+// NewRegisterRoutes crea la función que registra todas las rutas de la API
+func NewRegisterRoutes(
+	stockHandler *handlers.StockHandler,
+	taxonomyHandler *handlers.TaxonomyHandler,
+	alertHandler *handlers.AlertHandler,
+	webhookHandler *handlers.WebhookHandler,
+	digestHandler *handlers.DigestHandler,
+	streamHandler *handlers.StreamHandler,
+	webSocketHandler *handlers.WebSocketHandler,
+	graphQLHandler *handlers.GraphQLHandler,
+	apiKeyHandler *handlers.APIKeyHandler,
+	stockV2Handler *handlers.StockV2Handler,
+) api.RegisterRoutesFn {
+	return func(router *mux.Router) {
+		RegisterStockRoutes(router, stockHandler)
+		RegisterTaxonomyRoutes(router, taxonomyHandler)
+		RegisterAlertRoutes(router, alertHandler)
+		RegisterWebhookRoutes(router, webhookHandler)
+		RegisterDigestRoutes(router, digestHandler)
+		RegisterStreamRoutes(router, streamHandler, webSocketHandler)
+		RegisterGraphQLRoutes(router, graphQLHandler)
+		RegisterAPIKeyRoutes(router, apiKeyHandler)
+		RegisterV2Routes(router.PathPrefix("/v2").Subrouter(), stockV2Handler)
+	}
+}
+
+// Module proporciona las dependencias de las rutas
+var Module = fx.Provide(NewRegisterRoutes)
@@ -4,10 +4,10 @@ import (
 	"net/http"
 
 	"github.com/gorilla/mux"
-	"go.uber.org/fx"
 
 	"github.com/liferip/stock-analyzer/backend/api"
 	"github.com/liferip/stock-analyzer/backend/api/handlers"
+	"github.com/liferip/stock-analyzer/backend/internal/auth"
 )
 
 // RegisterStockRoutes registra las rutas relacionadas con stocks
@@ -17,12 +17,10 @@ func RegisterStockRoutes(router *mux.Router, stockHandler *handlers.StockHandler
 	router.HandleFunc("/stock", stockHandler.GetStocks).Methods(http.MethodGet)
 	router.HandleFunc("/stock/ticker/{ticker}", stockHandler.GetStockByTicker).Methods(http.MethodGet)
 	router.HandleFunc("/stock/recommendations", stockHandler.GetRecommendations).Methods(http.MethodGet)
-	router.HandleFunc("/stock/sync", stockHandler.SyncStocks).Methods(http.MethodPost)
+	router.HandleFunc("/stock/recommendations/snapshots", stockHandler.GetSnapshotDates).Methods(http.MethodGet)
+	router.HandleFunc("/stock/recommendations/snapshots/diff", stockHandler.DiffSnapshots).Methods(http.MethodGet)
+	router.HandleFunc("/stock/recommendations/snapshots/{date:[0-9]{4}-[0-9]{2}-[0-9]{2}}", stockHandler.GetSnapshot).Methods(http.MethodGet)
+	api.RequireScope(router.HandleFunc("/stock/sync", stockHandler.SyncStocks).Methods(http.MethodPost), auth.ScopeWriteSync)
+	router.HandleFunc("/stock/prices", stockHandler.UpsertPrices).Methods(http.MethodPost)
+	router.HandleFunc("/stock/backtest", stockHandler.RunBacktest).Methods(http.MethodGet)
 }
-
-// Module proporciona las dependencias de las rutas
-var Module = fx.Provide(
-	func() api.RegisterRoutesFn {
-		return RegisterStockRoutes
-	},
-)
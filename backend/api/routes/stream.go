@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/liferip/stock-analyzer/backend/api"
+	"github.com/liferip/stock-analyzer/backend/api/handlers"
+)
+
+// RegisterStreamRoutes registra las rutas de cambios de stocks en vivo
+func RegisterStreamRoutes(
+	router *mux.Router,
+	streamHandler *handlers.StreamHandler,
+	webSocketHandler *handlers.WebSocketHandler,
+) {
+	// Los navegadores no permiten headers en EventSource ni WebSocket: la credencial puede ir en access_token
+	api.AllowQueryToken(router.HandleFunc("/stock/stream", streamHandler.StreamStocks).Methods(http.MethodGet))
+	api.AllowQueryToken(router.HandleFunc("/stock/ws", webSocketHandler.Subscribe).Methods(http.MethodGet))
+}
@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/liferip/stock-analyzer/backend/api/handlers"
+)
+
+// RegisterTaxonomyRoutes registra las rutas de la taxonomía de calificaciones y acciones
+func RegisterTaxonomyRoutes(router *mux.Router, taxonomyHandler *handlers.TaxonomyHandler) {
+
+	// Rutas para etiquetas canónicas
+	router.HandleFunc("/taxonomy/{kind}/labels", taxonomyHandler.ListLabels).Methods(http.MethodGet)
+	router.HandleFunc("/taxonomy/{kind}/labels", taxonomyHandler.CreateLabel).Methods(http.MethodPost)
+	router.HandleFunc("/taxonomy/{kind}/labels/{id}", taxonomyHandler.UpdateLabel).Methods(http.MethodPut)
+	router.HandleFunc("/taxonomy/{kind}/labels/{id}", taxonomyHandler.DeleteLabel).Methods(http.MethodDelete)
+
+	// Rutas para alias y etiquetas sin asignar
+	router.HandleFunc("/taxonomy/{kind}/aliases", taxonomyHandler.CreateAlias).Methods(http.MethodPost)
+	router.HandleFunc("/taxonomy/{kind}/aliases/{id}", taxonomyHandler.DeleteAlias).Methods(http.MethodDelete)
+	router.HandleFunc("/taxonomy/{kind}/unmapped", taxonomyHandler.ListUnmapped).Methods(http.MethodGet)
+}
@@ -0,0 +1,38 @@
+package routes
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/liferip/stock-analyzer/backend/api"
+	"github.com/liferip/stock-analyzer/backend/api/handlers"
+	"github.com/liferip/stock-analyzer/backend/api/problem"
+	"github.com/liferip/stock-analyzer/backend/internal/auth"
+)
+
+// RegisterV2Routes registra las rutas de /api/v2, que responden con un sobre uniforme y
+// errores problem+json. Las rutas de /api se mantienen por compatibilidad.
+func RegisterV2Routes(router *mux.Router, stockV2Handler *handlers.StockV2Handler) {
+	router.HandleFunc("/stocks", stockV2Handler.ListStocks).Methods(http.MethodGet)
+	router.HandleFunc("/stocks/{ticker}", stockV2Handler.GetStock).Methods(http.MethodGet)
+	router.HandleFunc("/recommendations", stockV2Handler.ListRecommendations).Methods(http.MethodGet)
+	router.HandleFunc("/rating-events", stockV2Handler.ListRatingEvents).Methods(http.MethodGet)
+	router.HandleFunc("/brokerages", stockV2Handler.ListBrokerages).Methods(http.MethodGet)
+	api.RequireScope(router.HandleFunc("/sync", stockV2Handler.SyncStocks).Methods(http.MethodPost), auth.ScopeWriteSync)
+
+	// Las rutas inexistentes también responden con problem+json. mux responde 404 en lugar de
+	// 405 en algunos casos cuando un subrouter tiene NotFoundHandler, por eso ambos handlers
+	// buscan los métodos permitidos.
+	notFound := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if allowed := api.AllowedMethods(router, r); len(allowed) > 0 {
+			w.Header().Set("Allow", strings.Join(allowed, ", "))
+			problem.Write(w, r, http.StatusMethodNotAllowed, problem.CodeMethodNotAllowed, r.Method+" is not allowed on "+r.URL.Path)
+			return
+		}
+		problem.Write(w, r, http.StatusNotFound, problem.CodeNotFound, "No route matches "+r.URL.Path)
+	})
+	router.NotFoundHandler = notFound
+	router.MethodNotAllowedHandler = notFound
+}
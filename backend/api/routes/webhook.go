@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/liferip/stock-analyzer/backend/api/handlers"
+)
+
+// RegisterWebhookRoutes registra las rutas de webhooks y su registro de entregas
+func RegisterWebhookRoutes(router *mux.Router, webhookHandler *handlers.WebhookHandler) {
+
+	// Registro de entregas (antes de /webhooks/{id} para que no se tome como ID)
+	router.HandleFunc("/webhooks/deliveries", webhookHandler.ListDeliveries).Methods(http.MethodGet)
+
+	// Rutas para webhooks
+	router.HandleFunc("/webhooks", webhookHandler.ListWebhooks).Methods(http.MethodGet)
+	router.HandleFunc("/webhooks", webhookHandler.CreateWebhook).Methods(http.MethodPost)
+	router.HandleFunc("/webhooks/{id}", webhookHandler.GetWebhook).Methods(http.MethodGet)
+	router.HandleFunc("/webhooks/{id}", webhookHandler.UpdateWebhook).Methods(http.MethodPut)
+	router.HandleFunc("/webhooks/{id}", webhookHandler.DeleteWebhook).Methods(http.MethodDelete)
+	router.HandleFunc("/webhooks/{id}/ping", webhookHandler.PingWebhook).Methods(http.MethodPost)
+}
@@ -0,0 +1,126 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/liferip/stock-analyzer/backend/api/rpc/stockanalyzerv1"
+	"github.com/liferip/stock-analyzer/backend/internal/auth"
+	"github.com/liferip/stock-analyzer/backend/internal/ratelimit"
+)
+
+// methodScopes contiene los scopes de los métodos que no solo consultan datos
+var methodScopes = map[string]auth.Scope{
+	pb.StockAnalyzer_SyncStocks_FullMethodName: auth.ScopeWriteSync,
+}
+
+// authenticate autentica una llamada con la misma credencial que la API HTTP, enviada en los
+// metadatos authorization (Bearer) o x-api-key, y verifica el scope requerido por el método.
+// Las IPs que superan el límite de credenciales inválidas se rechazan sin autenticar.
+func authenticate(ctx context.Context, authenticator *auth.Authenticator, limiter *ratelimit.Limiter, method string) (context.Context, error) {
+	principal := authenticator.Anonymous()
+	if credential := metadataCredential(ctx); credential != "" {
+		client := "ip:" + peerIP(ctx)
+		if result, ok := limiter.CheckFailures(client); ok && !result.Allowed {
+			return nil, status.Errorf(codes.ResourceExhausted, "too many invalid credentials, retry in %d seconds",
+				int(math.Ceil(result.RetryAfter.Seconds())))
+		}
+
+		var err error
+		principal, err = authenticator.Authenticate(ctx, credential)
+		if errors.Is(err, auth.ErrUnauthenticated) {
+			limiter.RecordFailure(client)
+			return nil, status.Error(codes.Unauthenticated, "invalid credentials")
+		}
+		if err != nil {
+			return nil, status.Error(codes.Internal, "error authenticating request")
+		}
+	}
+	if principal == nil {
+		return nil, status.Error(codes.Unauthenticated, "missing credentials")
+	}
+
+	required, ok := methodScopes[method]
+	if !ok {
+		required = auth.ScopeReadStocks
+	}
+	if !principal.Allows(required) {
+		return nil, status.Errorf(codes.PermissionDenied, "this method requires the %s scope", required)
+	}
+
+	return auth.WithPrincipal(ctx, principal), nil
+}
+
+// peerIP obtiene la IP del cliente de una llamada
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+// metadataCredential obtiene la credencial de los metadatos de la llamada
+func metadataCredential(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	if values := md.Get("x-api-key"); len(values) > 0 && values[0] != "" {
+		return values[0]
+	}
+	if values := md.Get("authorization"); len(values) > 0 {
+		scheme, credential, ok := strings.Cut(values[0], " ")
+		if ok && strings.EqualFold(scheme, "Bearer") {
+			return strings.TrimSpace(credential)
+		}
+	}
+	return ""
+}
+
+// unaryAuthInterceptor autentica las llamadas unarias
+func unaryAuthInterceptor(authenticator *auth.Authenticator, limiter *ratelimit.Limiter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := authenticate(ctx, authenticator, limiter, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// streamAuthInterceptor autentica las llamadas de streaming
+func streamAuthInterceptor(authenticator *auth.Authenticator, limiter *ratelimit.Limiter) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(ss.Context(), authenticator, limiter, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authenticatedStream reemplaza el contexto del stream por uno con el cliente autenticado
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context devuelve el contexto con el cliente autenticado
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,73 @@
+package rpc
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/liferip/stock-analyzer/backend/api/rpc/stockanalyzerv1"
+	"github.com/liferip/stock-analyzer/backend/internal/models"
+)
+
+// toProtoStock convierte un stock al mensaje protobuf
+func toProtoStock(stock *models.Stock) *pb.Stock {
+	return &pb.Stock{
+		Id:         stock.ID,
+		Ticker:     stock.Ticker,
+		Company:    stock.Company,
+		Brokerage:  stock.Brokerage,
+		Action:     stock.Action,
+		RatingFrom: stock.RatingFrom,
+		RatingTo:   stock.RatingTo,
+		TargetFrom: stock.TargetFrom,
+		TargetTo:   stock.TargetTo,
+		Time:       timestamppb.New(stock.Time),
+	}
+}
+
+// toProtoRatingEvent convierte un evento de calificación al mensaje protobuf
+func toProtoRatingEvent(event *models.RatingEvent) *pb.RatingEvent {
+	return &pb.RatingEvent{
+		Id:         event.ID,
+		Ticker:     event.Ticker,
+		Company:    event.Company,
+		Brokerage:  event.Brokerage,
+		Action:     event.Action,
+		RatingFrom: event.RatingFrom,
+		RatingTo:   event.RatingTo,
+		TargetFrom: event.TargetFrom,
+		TargetTo:   event.TargetTo,
+		Time:       timestamppb.New(event.Time),
+	}
+}
+
+// toProtoRecommendation convierte una recomendación al mensaje protobuf
+func toProtoRecommendation(recommendation *models.StockRecommendation) *pb.StockRecommendation {
+	msg := &pb.StockRecommendation{
+		Stock:        toProtoStock(&recommendation.Stock),
+		Score:        recommendation.Score,
+		Reasons:      recommendation.Reason,
+		CurrentPrice: recommendation.CurrentPrice,
+		PotentialUp:  recommendation.PotentialUp,
+		AboveTarget:  recommendation.AboveTarget,
+		TargetChange: recommendation.TargetChange,
+	}
+	for _, factor := range recommendation.Breakdown {
+		msg.Breakdown = append(msg.Breakdown, &pb.ScoreFactor{
+			Factor:       factor.Factor,
+			Input:        factor.Input,
+			Value:        factor.Value,
+			Weight:       factor.Weight,
+			Contribution: factor.Contribution,
+		})
+	}
+	return msg
+}
+
+// toProtoUpdate convierte un evento del stream de stocks al mensaje protobuf
+func toProtoUpdate(event *models.StockStreamEvent) *pb.RatingEventUpdate {
+	return &pb.RatingEventUpdate{
+		Id:      event.ID,
+		Time:    timestamppb.New(event.Time),
+		Created: event.Created,
+		Stock:   toProtoStock(&event.Stock),
+	}
+}
@@ -0,0 +1,263 @@
+// Package rpc implementa el servicio gRPC StockAnalyzer sobre los servicios de la aplicación.
+//
+// El código en stockanalyzerv1 se genera desde proto/stockanalyzer/v1/stock_analyzer.proto.
+package rpc
+
+//go:generate protoc -I ../../proto --go_out=stockanalyzerv1 --go_opt=paths=source_relative --go-grpc_out=stockanalyzerv1 --go-grpc_opt=paths=source_relative stockanalyzer/v1/stock_analyzer.proto
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/liferip/stock-analyzer/backend/api/rpc/stockanalyzerv1"
+	"github.com/liferip/stock-analyzer/backend/internal/auth"
+	"github.com/liferip/stock-analyzer/backend/internal/models"
+	"github.com/liferip/stock-analyzer/backend/internal/ratelimit"
+	"github.com/liferip/stock-analyzer/backend/internal/service"
+)
+
+const (
+	// defaultPageSize es la cantidad de elementos devueltos cuando no se indica page_size
+	defaultPageSize = 20
+	// maxPageSize es la cantidad máxima de elementos por página
+	maxPageSize = 100
+)
+
+// Module proporciona las dependencias del servidor gRPC
+var Module = fx.Provide(NewServer, NewGRPCServer)
+
+// Server implementa el servicio gRPC StockAnalyzer
+type Server struct {
+	pb.UnimplementedStockAnalyzerServer
+
+	stockService service.StockService
+	stockStream  service.StockStream
+	logger       *zap.Logger
+
+	// done se cierra al apagar el servidor para terminar los streams abiertos
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewServer crea una nueva instancia de Server
+func NewServer(
+	stockService service.StockService,
+	stockStream service.StockStream,
+	logger *zap.Logger,
+) *Server {
+	return &Server{
+		stockService: stockService,
+		stockStream:  stockStream,
+		logger:       logger.Named("grpc_server"),
+		done:         make(chan struct{}),
+	}
+}
+
+// NewGRPCServer crea el servidor gRPC con el servicio StockAnalyzer y la reflexión registrados,
+// autenticando cada llamada igual que la API HTTP, con el mismo límite de credenciales inválidas
+func NewGRPCServer(server *Server, authenticator *auth.Authenticator, limiter *ratelimit.Limiter) *grpc.Server {
+	grpcServer := grpc.NewServer(
+		grpc.UnaryInterceptor(unaryAuthInterceptor(authenticator, limiter)),
+		grpc.StreamInterceptor(streamAuthInterceptor(authenticator, limiter)),
+	)
+	pb.RegisterStockAnalyzerServer(grpcServer, server)
+	reflection.Register(grpcServer)
+	return grpcServer
+}
+
+// Close termina los streams abiertos; debe llamarse antes de GracefulStop, que espera a que terminen
+func (s *Server) Close() {
+	s.closeOnce.Do(func() { close(s.done) })
+}
+
+// ListStocks devuelve una página de stocks que cumplen los filtros
+func (s *Server) ListStocks(ctx context.Context, req *pb.ListStocksRequest) (*pb.ListStocksResponse, error) {
+	limit, offset := pageBounds(req.GetPageSize(), req.GetOffset())
+
+	stocks, total, err := s.stockService.FindStocks(ctx, models.StockFilter{
+		Ticker:    req.GetTicker(),
+		Brokerage: req.GetBrokerage(),
+		Limit:     limit,
+		Offset:    offset,
+	})
+	if err != nil {
+		return nil, s.toStatus("Error finding stocks", err)
+	}
+
+	resp := &pb.ListStocksResponse{Total: total}
+	for i := range stocks {
+		resp.Stocks = append(resp.Stocks, toProtoStock(&stocks[i]))
+	}
+	return resp, nil
+}
+
+// GetStock devuelve el último registro de un ticker
+func (s *Server) GetStock(ctx context.Context, req *pb.GetStockRequest) (*pb.Stock, error) {
+	if req.GetTicker() == "" {
+		return nil, status.Error(codes.InvalidArgument, "ticker is required")
+	}
+
+	stock, err := s.stockService.GetStockByTicker(ctx, req.GetTicker())
+	if err != nil {
+		return nil, s.toStatus("Error getting stock", err)
+	}
+	if stock == nil {
+		return nil, status.Errorf(codes.NotFound, "stock %q not found", req.GetTicker())
+	}
+
+	return toProtoStock(stock), nil
+}
+
+// ListRatingEvents devuelve una página del historial de calificaciones que cumple los filtros
+func (s *Server) ListRatingEvents(ctx context.Context, req *pb.ListRatingEventsRequest) (*pb.ListRatingEventsResponse, error) {
+	limit, offset := pageBounds(req.GetPageSize(), req.GetOffset())
+
+	filter := models.RatingEventFilter{
+		Ticker:    req.GetTicker(),
+		Brokerage: req.GetBrokerage(),
+		Limit:     limit,
+		Offset:    offset,
+	}
+	if req.GetFrom() != nil {
+		filter.From = req.GetFrom().AsTime()
+	}
+	if req.GetTo() != nil {
+		filter.To = req.GetTo().AsTime()
+	}
+
+	events, total, err := s.stockService.GetRatingHistory(ctx, filter)
+	if err != nil {
+		return nil, s.toStatus("Error finding rating events", err)
+	}
+
+	resp := &pb.ListRatingEventsResponse{Total: total}
+	for i := range events {
+		resp.Events = append(resp.Events, toProtoRatingEvent(&events[i]))
+	}
+	return resp, nil
+}
+
+// GetRecommendations devuelve las recomendaciones actuales o las de los registros de un día
+func (s *Server) GetRecommendations(ctx context.Context, req *pb.GetRecommendationsRequest) (*pb.GetRecommendationsResponse, error) {
+	sortBy := service.SortByScore
+	switch req.GetSort() {
+	case pb.RecommendationSort_RECOMMENDATION_SORT_UNSPECIFIED, pb.RecommendationSort_RECOMMENDATION_SORT_SCORE:
+	case pb.RecommendationSort_RECOMMENDATION_SORT_UPSIDE:
+		sortBy = service.SortByUpside
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown sort %v", req.GetSort())
+	}
+	if req.GetLimit() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "limit must not be negative")
+	}
+
+	var recommendations []models.StockRecommendation
+	var err error
+	if req.GetDate() != "" {
+		if _, parseErr := time.Parse(time.DateOnly, req.GetDate()); parseErr != nil {
+			return nil, status.Error(codes.InvalidArgument, "date must be formatted as YYYY-MM-DD")
+		}
+		recommendations, err = s.stockService.GetRecommendationsByTime(ctx, req.GetDate(), sortBy)
+	} else {
+		recommendations, err = s.stockService.GetRecommendations(ctx, sortBy)
+	}
+	if err != nil {
+		return nil, s.toStatus("Error getting recommendations", err)
+	}
+
+	if limit := int(req.GetLimit()); limit > 0 && len(recommendations) > limit {
+		recommendations = recommendations[:limit]
+	}
+
+	resp := &pb.GetRecommendationsResponse{}
+	for i := range recommendations {
+		resp.Recommendations = append(resp.Recommendations, toProtoRecommendation(&recommendations[i]))
+	}
+	return resp, nil
+}
+
+// SyncStocks sincroniza los stocks desde la API externa
+func (s *Server) SyncStocks(ctx context.Context, req *pb.SyncStocksRequest) (*pb.SyncStocksResponse, error) {
+	count, err := s.stockService.SyncStocksFromAPI(ctx)
+	if err != nil {
+		return nil, s.toStatus("Error synchronizing stocks", err)
+	}
+
+	return &pb.SyncStocksResponse{Count: int32(count)}, nil
+}
+
+// StreamRatingEvents envía cada stock creado o actualizado por la sincronización hasta que
+// el cliente se desconecte, el cliente quede atrasado o el servidor se apague
+func (s *Server) StreamRatingEvents(req *pb.StreamRatingEventsRequest, stream grpc.ServerStreamingServer[pb.RatingEventUpdate]) error {
+	replay, sub := s.stockStream.Subscribe(models.StockStreamFilter{
+		Tickers:    req.GetTickers(),
+		Brokerages: req.GetBrokerages(),
+	}, req.GetLastEventId())
+	defer s.stockStream.Unsubscribe(sub)
+
+	for i := range replay {
+		if err := stream.Send(toProtoUpdate(&replay[i])); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-s.done:
+			return status.Error(codes.Unavailable, "server shutting down")
+		case event, ok := <-sub.Events:
+			if !ok {
+				// El stream se cerró o el cliente quedó atrasado; puede reconectarse con last_event_id
+				return status.Error(codes.Unavailable, "stream closed, reconnect with last_event_id")
+			}
+			if err := stream.Send(toProtoUpdate(&event)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// toStatus convierte un error del servicio en un estado gRPC
+func (s *Server) toStatus(message string, err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidInput):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrConflict):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+
+	s.logger.Error(message, zap.Error(err))
+	return status.Error(codes.Internal, message)
+}
+
+// pageBounds normaliza el tamaño de página y el desplazamiento de una solicitud
+func pageBounds(pageSize, offset int32) (int, int) {
+	limit := int(pageSize)
+	if limit <= 0 {
+		limit = defaultPageSize
+	}
+	if limit > maxPageSize {
+		limit = maxPageSize
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return limit, int(offset)
+}
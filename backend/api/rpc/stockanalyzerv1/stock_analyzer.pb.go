@@ -0,0 +1,1309 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: stockanalyzer/v1/stock_analyzer.proto
+
+package stockanalyzerv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// RecommendationSort is the order of the recommendations.
+type RecommendationSort int32
+
+const (
+	RecommendationSort_RECOMMENDATION_SORT_UNSPECIFIED RecommendationSort = 0
+	// Highest score first (the default).
+	RecommendationSort_RECOMMENDATION_SORT_SCORE RecommendationSort = 1
+	// Highest upside from the latest close first.
+	RecommendationSort_RECOMMENDATION_SORT_UPSIDE RecommendationSort = 2
+)
+
+// Enum value maps for RecommendationSort.
+var (
+	RecommendationSort_name = map[int32]string{
+		0: "RECOMMENDATION_SORT_UNSPECIFIED",
+		1: "RECOMMENDATION_SORT_SCORE",
+		2: "RECOMMENDATION_SORT_UPSIDE",
+	}
+	RecommendationSort_value = map[string]int32{
+		"RECOMMENDATION_SORT_UNSPECIFIED": 0,
+		"RECOMMENDATION_SORT_SCORE":       1,
+		"RECOMMENDATION_SORT_UPSIDE":      2,
+	}
+)
+
+func (x RecommendationSort) Enum() *RecommendationSort {
+	p := new(RecommendationSort)
+	*p = x
+	return p
+}
+
+func (x RecommendationSort) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RecommendationSort) Descriptor() protoreflect.EnumDescriptor {
+	return file_stockanalyzer_v1_stock_analyzer_proto_enumTypes[0].Descriptor()
+}
+
+func (RecommendationSort) Type() protoreflect.EnumType {
+	return &file_stockanalyzer_v1_stock_analyzer_proto_enumTypes[0]
+}
+
+func (x RecommendationSort) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RecommendationSort.Descriptor instead.
+func (RecommendationSort) EnumDescriptor() ([]byte, []int) {
+	return file_stockanalyzer_v1_stock_analyzer_proto_rawDescGZIP(), []int{0}
+}
+
+// Stock is the latest rating record of a ticker.
+type Stock struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Ticker        string                 `protobuf:"bytes,2,opt,name=ticker,proto3" json:"ticker,omitempty"`
+	Company       string                 `protobuf:"bytes,3,opt,name=company,proto3" json:"company,omitempty"`
+	Brokerage     string                 `protobuf:"bytes,4,opt,name=brokerage,proto3" json:"brokerage,omitempty"`
+	Action        string                 `protobuf:"bytes,5,opt,name=action,proto3" json:"action,omitempty"`
+	RatingFrom    string                 `protobuf:"bytes,6,opt,name=rating_from,json=ratingFrom,proto3" json:"rating_from,omitempty"`
+	RatingTo      string                 `protobuf:"bytes,7,opt,name=rating_to,json=ratingTo,proto3" json:"rating_to,omitempty"`
+	TargetFrom    string                 `protobuf:"bytes,8,opt,name=target_from,json=targetFrom,proto3" json:"target_from,omitempty"`
+	TargetTo      string                 `protobuf:"bytes,9,opt,name=target_to,json=targetTo,proto3" json:"target_to,omitempty"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=time,proto3" json:"time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Stock) Reset() {
+	*x = Stock{}
+	mi := &file_stockanalyzer_v1_stock_analyzer_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Stock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Stock) ProtoMessage() {}
+
+func (x *Stock) ProtoReflect() protoreflect.Message {
+	mi := &file_stockanalyzer_v1_stock_analyzer_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Stock.ProtoReflect.Descriptor instead.
+func (*Stock) Descriptor() ([]byte, []int) {
+	return file_stockanalyzer_v1_stock_analyzer_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Stock) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Stock) GetTicker() string {
+	if x != nil {
+		return x.Ticker
+	}
+	return ""
+}
+
+func (x *Stock) GetCompany() string {
+	if x != nil {
+		return x.Company
+	}
+	return ""
+}
+
+func (x *Stock) GetBrokerage() string {
+	if x != nil {
+		return x.Brokerage
+	}
+	return ""
+}
+
+func (x *Stock) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *Stock) GetRatingFrom() string {
+	if x != nil {
+		return x.RatingFrom
+	}
+	return ""
+}
+
+func (x *Stock) GetRatingTo() string {
+	if x != nil {
+		return x.RatingTo
+	}
+	return ""
+}
+
+func (x *Stock) GetTargetFrom() string {
+	if x != nil {
+		return x.TargetFrom
+	}
+	return ""
+}
+
+func (x *Stock) GetTargetTo() string {
+	if x != nil {
+		return x.TargetTo
+	}
+	return ""
+}
+
+func (x *Stock) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+// RatingEvent is a rating change recorded from the upstream API.
+type RatingEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Ticker        string                 `protobuf:"bytes,2,opt,name=ticker,proto3" json:"ticker,omitempty"`
+	Company       string                 `protobuf:"bytes,3,opt,name=company,proto3" json:"company,omitempty"`
+	Brokerage     string                 `protobuf:"bytes,4,opt,name=brokerage,proto3" json:"brokerage,omitempty"`
+	Action        string                 `protobuf:"bytes,5,opt,name=action,proto3" json:"action,omitempty"`
+	RatingFrom    string                 `protobuf:"bytes,6,opt,name=rating_from,json=ratingFrom,proto3" json:"rating_from,omitempty"`
+	RatingTo      string                 `protobuf:"bytes,7,opt,name=rating_to,json=ratingTo,proto3" json:"rating_to,omitempty"`
+	TargetFrom    string                 `protobuf:"bytes,8,opt,name=target_from,json=targetFrom,proto3" json:"target_from,omitempty"`
+	TargetTo      string                 `protobuf:"bytes,9,opt,name=target_to,json=targetTo,proto3" json:"target_to,omitempty"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=time,proto3" json:"time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RatingEvent) Reset() {
+	*x = RatingEvent{}
+	mi := &file_stockanalyzer_v1_stock_analyzer_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RatingEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RatingEvent) ProtoMessage() {}
+
+func (x *RatingEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_stockanalyzer_v1_stock_analyzer_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RatingEvent.ProtoReflect.Descriptor instead.
+func (*RatingEvent) Descriptor() ([]byte, []int) {
+	return file_stockanalyzer_v1_stock_analyzer_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RatingEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RatingEvent) GetTicker() string {
+	if x != nil {
+		return x.Ticker
+	}
+	return ""
+}
+
+func (x *RatingEvent) GetCompany() string {
+	if x != nil {
+		return x.Company
+	}
+	return ""
+}
+
+func (x *RatingEvent) GetBrokerage() string {
+	if x != nil {
+		return x.Brokerage
+	}
+	return ""
+}
+
+func (x *RatingEvent) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *RatingEvent) GetRatingFrom() string {
+	if x != nil {
+		return x.RatingFrom
+	}
+	return ""
+}
+
+func (x *RatingEvent) GetRatingTo() string {
+	if x != nil {
+		return x.RatingTo
+	}
+	return ""
+}
+
+func (x *RatingEvent) GetTargetFrom() string {
+	if x != nil {
+		return x.TargetFrom
+	}
+	return ""
+}
+
+func (x *RatingEvent) GetTargetTo() string {
+	if x != nil {
+		return x.TargetTo
+	}
+	return ""
+}
+
+func (x *RatingEvent) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+// ScoreFactor is the contribution of one factor to a recommendation score.
+type ScoreFactor struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Factor        string                 `protobuf:"bytes,1,opt,name=factor,proto3" json:"factor,omitempty"`
+	Input         string                 `protobuf:"bytes,2,opt,name=input,proto3" json:"input,omitempty"`
+	Value         float64                `protobuf:"fixed64,3,opt,name=value,proto3" json:"value,omitempty"`
+	Weight        float64                `protobuf:"fixed64,4,opt,name=weight,proto3" json:"weight,omitempty"`
+	Contribution  float64                `protobuf:"fixed64,5,opt,name=contribution,proto3" json:"contribution,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScoreFactor) Reset() {
+	*x = ScoreFactor{}
+	mi := &file_stockanalyzer_v1_stock_analyzer_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScoreFactor) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScoreFactor) ProtoMessage() {}
+
+func (x *ScoreFactor) ProtoReflect() protoreflect.Message {
+	mi := &file_stockanalyzer_v1_stock_analyzer_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScoreFactor.ProtoReflect.Descriptor instead.
+func (*ScoreFactor) Descriptor() ([]byte, []int) {
+	return file_stockanalyzer_v1_stock_analyzer_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ScoreFactor) GetFactor() string {
+	if x != nil {
+		return x.Factor
+	}
+	return ""
+}
+
+func (x *ScoreFactor) GetInput() string {
+	if x != nil {
+		return x.Input
+	}
+	return ""
+}
+
+func (x *ScoreFactor) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *ScoreFactor) GetWeight() float64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *ScoreFactor) GetContribution() float64 {
+	if x != nil {
+		return x.Contribution
+	}
+	return 0
+}
+
+// StockRecommendation is the investment recommendation computed for a stock.
+type StockRecommendation struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Stock     *Stock                 `protobuf:"bytes,1,opt,name=stock,proto3" json:"stock,omitempty"`
+	Score     float64                `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	Reasons   []string               `protobuf:"bytes,3,rep,name=reasons,proto3" json:"reasons,omitempty"`
+	Breakdown []*ScoreFactor         `protobuf:"bytes,4,rep,name=breakdown,proto3" json:"breakdown,omitempty"`
+	// Latest close, unset when no price is known.
+	CurrentPrice *float64 `protobuf:"fixed64,5,opt,name=current_price,json=currentPrice,proto3,oneof" json:"current_price,omitempty"`
+	// Percent upside from the latest close to the target, unset when no price is known.
+	PotentialUp   *float64 `protobuf:"fixed64,6,opt,name=potential_up,json=potentialUp,proto3,oneof" json:"potential_up,omitempty"`
+	AboveTarget   bool     `protobuf:"varint,7,opt,name=above_target,json=aboveTarget,proto3" json:"above_target,omitempty"`
+	TargetChange  float64  `protobuf:"fixed64,8,opt,name=target_change,json=targetChange,proto3" json:"target_change,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockRecommendation) Reset() {
+	*x = StockRecommendation{}
+	mi := &file_stockanalyzer_v1_stock_analyzer_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockRecommendation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockRecommendation) ProtoMessage() {}
+
+func (x *StockRecommendation) ProtoReflect() protoreflect.Message {
+	mi := &file_stockanalyzer_v1_stock_analyzer_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockRecommendation.ProtoReflect.Descriptor instead.
+func (*StockRecommendation) Descriptor() ([]byte, []int) {
+	return file_stockanalyzer_v1_stock_analyzer_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *StockRecommendation) GetStock() *Stock {
+	if x != nil {
+		return x.Stock
+	}
+	return nil
+}
+
+func (x *StockRecommendation) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *StockRecommendation) GetReasons() []string {
+	if x != nil {
+		return x.Reasons
+	}
+	return nil
+}
+
+func (x *StockRecommendation) GetBreakdown() []*ScoreFactor {
+	if x != nil {
+		return x.Breakdown
+	}
+	return nil
+}
+
+func (x *StockRecommendation) GetCurrentPrice() float64 {
+	if x != nil && x.CurrentPrice != nil {
+		return *x.CurrentPrice
+	}
+	return 0
+}
+
+func (x *StockRecommendation) GetPotentialUp() float64 {
+	if x != nil && x.PotentialUp != nil {
+		return *x.PotentialUp
+	}
+	return 0
+}
+
+func (x *StockRecommendation) GetAboveTarget() bool {
+	if x != nil {
+		return x.AboveTarget
+	}
+	return false
+}
+
+func (x *StockRecommendation) GetTargetChange() float64 {
+	if x != nil {
+		return x.TargetChange
+	}
+	return 0
+}
+
+type ListStocksRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Ticker    string                 `protobuf:"bytes,1,opt,name=ticker,proto3" json:"ticker,omitempty"`
+	Brokerage string                 `protobuf:"bytes,2,opt,name=brokerage,proto3" json:"brokerage,omitempty"`
+	// Maximum number of stocks to return (default 20, at most 100).
+	PageSize      int32 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Offset        int32 `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStocksRequest) Reset() {
+	*x = ListStocksRequest{}
+	mi := &file_stockanalyzer_v1_stock_analyzer_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStocksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStocksRequest) ProtoMessage() {}
+
+func (x *ListStocksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stockanalyzer_v1_stock_analyzer_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStocksRequest.ProtoReflect.Descriptor instead.
+func (*ListStocksRequest) Descriptor() ([]byte, []int) {
+	return file_stockanalyzer_v1_stock_analyzer_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListStocksRequest) GetTicker() string {
+	if x != nil {
+		return x.Ticker
+	}
+	return ""
+}
+
+func (x *ListStocksRequest) GetBrokerage() string {
+	if x != nil {
+		return x.Brokerage
+	}
+	return ""
+}
+
+func (x *ListStocksRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListStocksRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type ListStocksResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Stocks []*Stock               `protobuf:"bytes,1,rep,name=stocks,proto3" json:"stocks,omitempty"`
+	// Total number of stocks matching the filters.
+	Total         int64 `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStocksResponse) Reset() {
+	*x = ListStocksResponse{}
+	mi := &file_stockanalyzer_v1_stock_analyzer_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStocksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStocksResponse) ProtoMessage() {}
+
+func (x *ListStocksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stockanalyzer_v1_stock_analyzer_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStocksResponse.ProtoReflect.Descriptor instead.
+func (*ListStocksResponse) Descriptor() ([]byte, []int) {
+	return file_stockanalyzer_v1_stock_analyzer_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListStocksResponse) GetStocks() []*Stock {
+	if x != nil {
+		return x.Stocks
+	}
+	return nil
+}
+
+func (x *ListStocksResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type GetStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ticker        string                 `protobuf:"bytes,1,opt,name=ticker,proto3" json:"ticker,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStockRequest) Reset() {
+	*x = GetStockRequest{}
+	mi := &file_stockanalyzer_v1_stock_analyzer_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStockRequest) ProtoMessage() {}
+
+func (x *GetStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stockanalyzer_v1_stock_analyzer_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStockRequest.ProtoReflect.Descriptor instead.
+func (*GetStockRequest) Descriptor() ([]byte, []int) {
+	return file_stockanalyzer_v1_stock_analyzer_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetStockRequest) GetTicker() string {
+	if x != nil {
+		return x.Ticker
+	}
+	return ""
+}
+
+type ListRatingEventsRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Ticker    string                 `protobuf:"bytes,1,opt,name=ticker,proto3" json:"ticker,omitempty"`
+	Brokerage string                 `protobuf:"bytes,2,opt,name=brokerage,proto3" json:"brokerage,omitempty"`
+	From      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	To        *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`
+	// Maximum number of events to return (default 20, at most 100).
+	PageSize      int32 `protobuf:"varint,5,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Offset        int32 `protobuf:"varint,6,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRatingEventsRequest) Reset() {
+	*x = ListRatingEventsRequest{}
+	mi := &file_stockanalyzer_v1_stock_analyzer_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRatingEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRatingEventsRequest) ProtoMessage() {}
+
+func (x *ListRatingEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stockanalyzer_v1_stock_analyzer_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRatingEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListRatingEventsRequest) Descriptor() ([]byte, []int) {
+	return file_stockanalyzer_v1_stock_analyzer_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListRatingEventsRequest) GetTicker() string {
+	if x != nil {
+		return x.Ticker
+	}
+	return ""
+}
+
+func (x *ListRatingEventsRequest) GetBrokerage() string {
+	if x != nil {
+		return x.Brokerage
+	}
+	return ""
+}
+
+func (x *ListRatingEventsRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *ListRatingEventsRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *ListRatingEventsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListRatingEventsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type ListRatingEventsResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Events []*RatingEvent         `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	// Total number of events matching the filters.
+	Total         int64 `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRatingEventsResponse) Reset() {
+	*x = ListRatingEventsResponse{}
+	mi := &file_stockanalyzer_v1_stock_analyzer_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRatingEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRatingEventsResponse) ProtoMessage() {}
+
+func (x *ListRatingEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stockanalyzer_v1_stock_analyzer_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRatingEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListRatingEventsResponse) Descriptor() ([]byte, []int) {
+	return file_stockanalyzer_v1_stock_analyzer_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListRatingEventsResponse) GetEvents() []*RatingEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *ListRatingEventsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type GetRecommendationsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Sort  RecommendationSort     `protobuf:"varint,1,opt,name=sort,proto3,enum=stockanalyzer.v1.RecommendationSort" json:"sort,omitempty"`
+	// Maximum number of recommendations to return; 0 returns all of them.
+	Limit int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Day (YYYY-MM-DD) whose records are ranked; empty ranks the current stocks.
+	Date          string `protobuf:"bytes,3,opt,name=date,proto3" json:"date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRecommendationsRequest) Reset() {
+	*x = GetRecommendationsRequest{}
+	mi := &file_stockanalyzer_v1_stock_analyzer_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRecommendationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRecommendationsRequest) ProtoMessage() {}
+
+func (x *GetRecommendationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stockanalyzer_v1_stock_analyzer_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRecommendationsRequest.ProtoReflect.Descriptor instead.
+func (*GetRecommendationsRequest) Descriptor() ([]byte, []int) {
+	return file_stockanalyzer_v1_stock_analyzer_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetRecommendationsRequest) GetSort() RecommendationSort {
+	if x != nil {
+		return x.Sort
+	}
+	return RecommendationSort_RECOMMENDATION_SORT_UNSPECIFIED
+}
+
+func (x *GetRecommendationsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetRecommendationsRequest) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+type GetRecommendationsResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Recommendations []*StockRecommendation `protobuf:"bytes,1,rep,name=recommendations,proto3" json:"recommendations,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetRecommendationsResponse) Reset() {
+	*x = GetRecommendationsResponse{}
+	mi := &file_stockanalyzer_v1_stock_analyzer_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRecommendationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRecommendationsResponse) ProtoMessage() {}
+
+func (x *GetRecommendationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stockanalyzer_v1_stock_analyzer_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRecommendationsResponse.ProtoReflect.Descriptor instead.
+func (*GetRecommendationsResponse) Descriptor() ([]byte, []int) {
+	return file_stockanalyzer_v1_stock_analyzer_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetRecommendationsResponse) GetRecommendations() []*StockRecommendation {
+	if x != nil {
+		return x.Recommendations
+	}
+	return nil
+}
+
+type SyncStocksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyncStocksRequest) Reset() {
+	*x = SyncStocksRequest{}
+	mi := &file_stockanalyzer_v1_stock_analyzer_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncStocksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncStocksRequest) ProtoMessage() {}
+
+func (x *SyncStocksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stockanalyzer_v1_stock_analyzer_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncStocksRequest.ProtoReflect.Descriptor instead.
+func (*SyncStocksRequest) Descriptor() ([]byte, []int) {
+	return file_stockanalyzer_v1_stock_analyzer_proto_rawDescGZIP(), []int{11}
+}
+
+type SyncStocksResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of records processed.
+	Count         int32 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyncStocksResponse) Reset() {
+	*x = SyncStocksResponse{}
+	mi := &file_stockanalyzer_v1_stock_analyzer_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncStocksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncStocksResponse) ProtoMessage() {}
+
+func (x *SyncStocksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stockanalyzer_v1_stock_analyzer_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncStocksResponse.ProtoReflect.Descriptor instead.
+func (*SyncStocksResponse) Descriptor() ([]byte, []int) {
+	return file_stockanalyzer_v1_stock_analyzer_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SyncStocksResponse) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type StreamRatingEventsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only stream these tickers; empty streams every ticker.
+	Tickers []string `protobuf:"bytes,1,rep,name=tickers,proto3" json:"tickers,omitempty"`
+	// Only stream these brokerages; empty streams every brokerage.
+	Brokerages []string `protobuf:"bytes,2,rep,name=brokerages,proto3" json:"brokerages,omitempty"`
+	// Replay the recent updates after this ID before streaming new ones.
+	LastEventId   uint64 `protobuf:"varint,3,opt,name=last_event_id,json=lastEventId,proto3" json:"last_event_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamRatingEventsRequest) Reset() {
+	*x = StreamRatingEventsRequest{}
+	mi := &file_stockanalyzer_v1_stock_analyzer_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamRatingEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamRatingEventsRequest) ProtoMessage() {}
+
+func (x *StreamRatingEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stockanalyzer_v1_stock_analyzer_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamRatingEventsRequest.ProtoReflect.Descriptor instead.
+func (*StreamRatingEventsRequest) Descriptor() ([]byte, []int) {
+	return file_stockanalyzer_v1_stock_analyzer_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *StreamRatingEventsRequest) GetTickers() []string {
+	if x != nil {
+		return x.Tickers
+	}
+	return nil
+}
+
+func (x *StreamRatingEventsRequest) GetBrokerages() []string {
+	if x != nil {
+		return x.Brokerages
+	}
+	return nil
+}
+
+func (x *StreamRatingEventsRequest) GetLastEventId() uint64 {
+	if x != nil {
+		return x.LastEventId
+	}
+	return 0
+}
+
+// RatingEventUpdate is a record created or updated by sync.
+type RatingEventUpdate struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Increasing ID, used to resume the stream.
+	Id   uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Time *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	// True when the ticker was new, false when its latest record was replaced.
+	Created       bool   `protobuf:"varint,3,opt,name=created,proto3" json:"created,omitempty"`
+	Stock         *Stock `protobuf:"bytes,4,opt,name=stock,proto3" json:"stock,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RatingEventUpdate) Reset() {
+	*x = RatingEventUpdate{}
+	mi := &file_stockanalyzer_v1_stock_analyzer_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RatingEventUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RatingEventUpdate) ProtoMessage() {}
+
+func (x *RatingEventUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_stockanalyzer_v1_stock_analyzer_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RatingEventUpdate.ProtoReflect.Descriptor instead.
+func (*RatingEventUpdate) Descriptor() ([]byte, []int) {
+	return file_stockanalyzer_v1_stock_analyzer_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RatingEventUpdate) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *RatingEventUpdate) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *RatingEventUpdate) GetCreated() bool {
+	if x != nil {
+		return x.Created
+	}
+	return false
+}
+
+func (x *RatingEventUpdate) GetStock() *Stock {
+	if x != nil {
+		return x.Stock
+	}
+	return nil
+}
+
+var File_stockanalyzer_v1_stock_analyzer_proto protoreflect.FileDescriptor
+
+const file_stockanalyzer_v1_stock_analyzer_proto_rawDesc = "" +
+	"\n" +
+	"%stockanalyzer/v1/stock_analyzer.proto\x12\x10stockanalyzer.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xab\x02\n" +
+	"\x05Stock\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06ticker\x18\x02 \x01(\tR\x06ticker\x12\x18\n" +
+	"\acompany\x18\x03 \x01(\tR\acompany\x12\x1c\n" +
+	"\tbrokerage\x18\x04 \x01(\tR\tbrokerage\x12\x16\n" +
+	"\x06action\x18\x05 \x01(\tR\x06action\x12\x1f\n" +
+	"\vrating_from\x18\x06 \x01(\tR\n" +
+	"ratingFrom\x12\x1b\n" +
+	"\trating_to\x18\a \x01(\tR\bratingTo\x12\x1f\n" +
+	"\vtarget_from\x18\b \x01(\tR\n" +
+	"targetFrom\x12\x1b\n" +
+	"\ttarget_to\x18\t \x01(\tR\btargetTo\x12.\n" +
+	"\x04time\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\x04time\"\xb1\x02\n" +
+	"\vRatingEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06ticker\x18\x02 \x01(\tR\x06ticker\x12\x18\n" +
+	"\acompany\x18\x03 \x01(\tR\acompany\x12\x1c\n" +
+	"\tbrokerage\x18\x04 \x01(\tR\tbrokerage\x12\x16\n" +
+	"\x06action\x18\x05 \x01(\tR\x06action\x12\x1f\n" +
+	"\vrating_from\x18\x06 \x01(\tR\n" +
+	"ratingFrom\x12\x1b\n" +
+	"\trating_to\x18\a \x01(\tR\bratingTo\x12\x1f\n" +
+	"\vtarget_from\x18\b \x01(\tR\n" +
+	"targetFrom\x12\x1b\n" +
+	"\ttarget_to\x18\t \x01(\tR\btargetTo\x12.\n" +
+	"\x04time\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\x04time\"\x8d\x01\n" +
+	"\vScoreFactor\x12\x16\n" +
+	"\x06factor\x18\x01 \x01(\tR\x06factor\x12\x14\n" +
+	"\x05input\x18\x02 \x01(\tR\x05input\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\x01R\x05value\x12\x16\n" +
+	"\x06weight\x18\x04 \x01(\x01R\x06weight\x12\"\n" +
+	"\fcontribution\x18\x05 \x01(\x01R\fcontribution\"\xee\x02\n" +
+	"\x13StockRecommendation\x12-\n" +
+	"\x05stock\x18\x01 \x01(\v2\x17.stockanalyzer.v1.StockR\x05stock\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\x12\x18\n" +
+	"\areasons\x18\x03 \x03(\tR\areasons\x12;\n" +
+	"\tbreakdown\x18\x04 \x03(\v2\x1d.stockanalyzer.v1.ScoreFactorR\tbreakdown\x12(\n" +
+	"\rcurrent_price\x18\x05 \x01(\x01H\x00R\fcurrentPrice\x88\x01\x01\x12&\n" +
+	"\fpotential_up\x18\x06 \x01(\x01H\x01R\vpotentialUp\x88\x01\x01\x12!\n" +
+	"\fabove_target\x18\a \x01(\bR\vaboveTarget\x12#\n" +
+	"\rtarget_change\x18\b \x01(\x01R\ftargetChangeB\x10\n" +
+	"\x0e_current_priceB\x0f\n" +
+	"\r_potential_up\"~\n" +
+	"\x11ListStocksRequest\x12\x16\n" +
+	"\x06ticker\x18\x01 \x01(\tR\x06ticker\x12\x1c\n" +
+	"\tbrokerage\x18\x02 \x01(\tR\tbrokerage\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\x05R\x06offset\"[\n" +
+	"\x12ListStocksResponse\x12/\n" +
+	"\x06stocks\x18\x01 \x03(\v2\x17.stockanalyzer.v1.StockR\x06stocks\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\")\n" +
+	"\x0fGetStockRequest\x12\x16\n" +
+	"\x06ticker\x18\x01 \x01(\tR\x06ticker\"\xe0\x01\n" +
+	"\x17ListRatingEventsRequest\x12\x16\n" +
+	"\x06ticker\x18\x01 \x01(\tR\x06ticker\x12\x1c\n" +
+	"\tbrokerage\x18\x02 \x01(\tR\tbrokerage\x12.\n" +
+	"\x04from\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12\x1b\n" +
+	"\tpage_size\x18\x05 \x01(\x05R\bpageSize\x12\x16\n" +
+	"\x06offset\x18\x06 \x01(\x05R\x06offset\"g\n" +
+	"\x18ListRatingEventsResponse\x125\n" +
+	"\x06events\x18\x01 \x03(\v2\x1d.stockanalyzer.v1.RatingEventR\x06events\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\"\x7f\n" +
+	"\x19GetRecommendationsRequest\x128\n" +
+	"\x04sort\x18\x01 \x01(\x0e2$.stockanalyzer.v1.RecommendationSortR\x04sort\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x12\n" +
+	"\x04date\x18\x03 \x01(\tR\x04date\"m\n" +
+	"\x1aGetRecommendationsResponse\x12O\n" +
+	"\x0frecommendations\x18\x01 \x03(\v2%.stockanalyzer.v1.StockRecommendationR\x0frecommendations\"\x13\n" +
+	"\x11SyncStocksRequest\"*\n" +
+	"\x12SyncStocksResponse\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x05R\x05count\"y\n" +
+	"\x19StreamRatingEventsRequest\x12\x18\n" +
+	"\atickers\x18\x01 \x03(\tR\atickers\x12\x1e\n" +
+	"\n" +
+	"brokerages\x18\x02 \x03(\tR\n" +
+	"brokerages\x12\"\n" +
+	"\rlast_event_id\x18\x03 \x01(\x04R\vlastEventId\"\x9c\x01\n" +
+	"\x11RatingEventUpdate\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12.\n" +
+	"\x04time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x18\n" +
+	"\acreated\x18\x03 \x01(\bR\acreated\x12-\n" +
+	"\x05stock\x18\x04 \x01(\v2\x17.stockanalyzer.v1.StockR\x05stock*x\n" +
+	"\x12RecommendationSort\x12#\n" +
+	"\x1fRECOMMENDATION_SORT_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19RECOMMENDATION_SORT_SCORE\x10\x01\x12\x1e\n" +
+	"\x1aRECOMMENDATION_SORT_UPSIDE\x10\x022\xcf\x04\n" +
+	"\rStockAnalyzer\x12W\n" +
+	"\n" +
+	"ListStocks\x12#.stockanalyzer.v1.ListStocksRequest\x1a$.stockanalyzer.v1.ListStocksResponse\x12F\n" +
+	"\bGetStock\x12!.stockanalyzer.v1.GetStockRequest\x1a\x17.stockanalyzer.v1.Stock\x12i\n" +
+	"\x10ListRatingEvents\x12).stockanalyzer.v1.ListRatingEventsRequest\x1a*.stockanalyzer.v1.ListRatingEventsResponse\x12o\n" +
+	"\x12GetRecommendations\x12+.stockanalyzer.v1.GetRecommendationsRequest\x1a,.stockanalyzer.v1.GetRecommendationsResponse\x12W\n" +
+	"\n" +
+	"SyncStocks\x12#.stockanalyzer.v1.SyncStocksRequest\x1a$.stockanalyzer.v1.SyncStocksResponse\x12h\n" +
+	"\x12StreamRatingEvents\x12+.stockanalyzer.v1.StreamRatingEventsRequest\x1a#.stockanalyzer.v1.RatingEventUpdate0\x01BSZQgithub.com/liferip/stock-analyzer/backend/api/rpc/stockanalyzerv1;stockanalyzerv1b\x06proto3"
+
+var (
+	file_stockanalyzer_v1_stock_analyzer_proto_rawDescOnce sync.Once
+	file_stockanalyzer_v1_stock_analyzer_proto_rawDescData []byte
+)
+
+func file_stockanalyzer_v1_stock_analyzer_proto_rawDescGZIP() []byte {
+	file_stockanalyzer_v1_stock_analyzer_proto_rawDescOnce.Do(func() {
+		file_stockanalyzer_v1_stock_analyzer_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_stockanalyzer_v1_stock_analyzer_proto_rawDesc), len(file_stockanalyzer_v1_stock_analyzer_proto_rawDesc)))
+	})
+	return file_stockanalyzer_v1_stock_analyzer_proto_rawDescData
+}
+
+var file_stockanalyzer_v1_stock_analyzer_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_stockanalyzer_v1_stock_analyzer_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_stockanalyzer_v1_stock_analyzer_proto_goTypes = []any{
+	(RecommendationSort)(0),            // 0: stockanalyzer.v1.RecommendationSort
+	(*Stock)(nil),                      // 1: stockanalyzer.v1.Stock
+	(*RatingEvent)(nil),                // 2: stockanalyzer.v1.RatingEvent
+	(*ScoreFactor)(nil),                // 3: stockanalyzer.v1.ScoreFactor
+	(*StockRecommendation)(nil),        // 4: stockanalyzer.v1.StockRecommendation
+	(*ListStocksRequest)(nil),          // 5: stockanalyzer.v1.ListStocksRequest
+	(*ListStocksResponse)(nil),         // 6: stockanalyzer.v1.ListStocksResponse
+	(*GetStockRequest)(nil),            // 7: stockanalyzer.v1.GetStockRequest
+	(*ListRatingEventsRequest)(nil),    // 8: stockanalyzer.v1.ListRatingEventsRequest
+	(*ListRatingEventsResponse)(nil),   // 9: stockanalyzer.v1.ListRatingEventsResponse
+	(*GetRecommendationsRequest)(nil),  // 10: stockanalyzer.v1.GetRecommendationsRequest
+	(*GetRecommendationsResponse)(nil), // 11: stockanalyzer.v1.GetRecommendationsResponse
+	(*SyncStocksRequest)(nil),          // 12: stockanalyzer.v1.SyncStocksRequest
+	(*SyncStocksResponse)(nil),         // 13: stockanalyzer.v1.SyncStocksResponse
+	(*StreamRatingEventsRequest)(nil),  // 14: stockanalyzer.v1.StreamRatingEventsRequest
+	(*RatingEventUpdate)(nil),          // 15: stockanalyzer.v1.RatingEventUpdate
+	(*timestamppb.Timestamp)(nil),      // 16: google.protobuf.Timestamp
+}
+var file_stockanalyzer_v1_stock_analyzer_proto_depIdxs = []int32{
+	16, // 0: stockanalyzer.v1.Stock.time:type_name -> google.protobuf.Timestamp
+	16, // 1: stockanalyzer.v1.RatingEvent.time:type_name -> google.protobuf.Timestamp
+	1,  // 2: stockanalyzer.v1.StockRecommendation.stock:type_name -> stockanalyzer.v1.Stock
+	3,  // 3: stockanalyzer.v1.StockRecommendation.breakdown:type_name -> stockanalyzer.v1.ScoreFactor
+	1,  // 4: stockanalyzer.v1.ListStocksResponse.stocks:type_name -> stockanalyzer.v1.Stock
+	16, // 5: stockanalyzer.v1.ListRatingEventsRequest.from:type_name -> google.protobuf.Timestamp
+	16, // 6: stockanalyzer.v1.ListRatingEventsRequest.to:type_name -> google.protobuf.Timestamp
+	2,  // 7: stockanalyzer.v1.ListRatingEventsResponse.events:type_name -> stockanalyzer.v1.RatingEvent
+	0,  // 8: stockanalyzer.v1.GetRecommendationsRequest.sort:type_name -> stockanalyzer.v1.RecommendationSort
+	4,  // 9: stockanalyzer.v1.GetRecommendationsResponse.recommendations:type_name -> stockanalyzer.v1.StockRecommendation
+	16, // 10: stockanalyzer.v1.RatingEventUpdate.time:type_name -> google.protobuf.Timestamp
+	1,  // 11: stockanalyzer.v1.RatingEventUpdate.stock:type_name -> stockanalyzer.v1.Stock
+	5,  // 12: stockanalyzer.v1.StockAnalyzer.ListStocks:input_type -> stockanalyzer.v1.ListStocksRequest
+	7,  // 13: stockanalyzer.v1.StockAnalyzer.GetStock:input_type -> stockanalyzer.v1.GetStockRequest
+	8,  // 14: stockanalyzer.v1.StockAnalyzer.ListRatingEvents:input_type -> stockanalyzer.v1.ListRatingEventsRequest
+	10, // 15: stockanalyzer.v1.StockAnalyzer.GetRecommendations:input_type -> stockanalyzer.v1.GetRecommendationsRequest
+	12, // 16: stockanalyzer.v1.StockAnalyzer.SyncStocks:input_type -> stockanalyzer.v1.SyncStocksRequest
+	14, // 17: stockanalyzer.v1.StockAnalyzer.StreamRatingEvents:input_type -> stockanalyzer.v1.StreamRatingEventsRequest
+	6,  // 18: stockanalyzer.v1.StockAnalyzer.ListStocks:output_type -> stockanalyzer.v1.ListStocksResponse
+	1,  // 19: stockanalyzer.v1.StockAnalyzer.GetStock:output_type -> stockanalyzer.v1.Stock
+	9,  // 20: stockanalyzer.v1.StockAnalyzer.ListRatingEvents:output_type -> stockanalyzer.v1.ListRatingEventsResponse
+	11, // 21: stockanalyzer.v1.StockAnalyzer.GetRecommendations:output_type -> stockanalyzer.v1.GetRecommendationsResponse
+	13, // 22: stockanalyzer.v1.StockAnalyzer.SyncStocks:output_type -> stockanalyzer.v1.SyncStocksResponse
+	15, // 23: stockanalyzer.v1.StockAnalyzer.StreamRatingEvents:output_type -> stockanalyzer.v1.RatingEventUpdate
+	18, // [18:24] is the sub-list for method output_type
+	12, // [12:18] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
+}
+
+func init() { file_stockanalyzer_v1_stock_analyzer_proto_init() }
+func file_stockanalyzer_v1_stock_analyzer_proto_init() {
+	if File_stockanalyzer_v1_stock_analyzer_proto != nil {
+		return
+	}
+	file_stockanalyzer_v1_stock_analyzer_proto_msgTypes[3].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_stockanalyzer_v1_stock_analyzer_proto_rawDesc), len(file_stockanalyzer_v1_stock_analyzer_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   15,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_stockanalyzer_v1_stock_analyzer_proto_goTypes,
+		DependencyIndexes: file_stockanalyzer_v1_stock_analyzer_proto_depIdxs,
+		EnumInfos:         file_stockanalyzer_v1_stock_analyzer_proto_enumTypes,
+		MessageInfos:      file_stockanalyzer_v1_stock_analyzer_proto_msgTypes,
+	}.Build()
+	File_stockanalyzer_v1_stock_analyzer_proto = out.File
+	file_stockanalyzer_v1_stock_analyzer_proto_goTypes = nil
+	file_stockanalyzer_v1_stock_analyzer_proto_depIdxs = nil
+}
@@ -0,0 +1,331 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: stockanalyzer/v1/stock_analyzer.proto
+
+package stockanalyzerv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	StockAnalyzer_ListStocks_FullMethodName         = "/stockanalyzer.v1.StockAnalyzer/ListStocks"
+	StockAnalyzer_GetStock_FullMethodName           = "/stockanalyzer.v1.StockAnalyzer/GetStock"
+	StockAnalyzer_ListRatingEvents_FullMethodName   = "/stockanalyzer.v1.StockAnalyzer/ListRatingEvents"
+	StockAnalyzer_GetRecommendations_FullMethodName = "/stockanalyzer.v1.StockAnalyzer/GetRecommendations"
+	StockAnalyzer_SyncStocks_FullMethodName         = "/stockanalyzer.v1.StockAnalyzer/SyncStocks"
+	StockAnalyzer_StreamRatingEvents_FullMethodName = "/stockanalyzer.v1.StockAnalyzer/StreamRatingEvents"
+)
+
+// StockAnalyzerClient is the client API for StockAnalyzer service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// StockAnalyzer exposes stocks, rating history, recommendations and sync to internal services.
+type StockAnalyzerClient interface {
+	// ListStocks returns the latest record of each ticker, newest first.
+	ListStocks(ctx context.Context, in *ListStocksRequest, opts ...grpc.CallOption) (*ListStocksResponse, error)
+	// GetStock returns the latest record of a ticker, or NOT_FOUND.
+	GetStock(ctx context.Context, in *GetStockRequest, opts ...grpc.CallOption) (*Stock, error)
+	// ListRatingEvents returns the rating change history, newest first.
+	ListRatingEvents(ctx context.Context, in *ListRatingEventsRequest, opts ...grpc.CallOption) (*ListRatingEventsResponse, error)
+	// GetRecommendations returns the current recommendations, or those for the latest records of a day.
+	GetRecommendations(ctx context.Context, in *GetRecommendationsRequest, opts ...grpc.CallOption) (*GetRecommendationsResponse, error)
+	// SyncStocks fetches the upstream API and stores new records.
+	SyncStocks(ctx context.Context, in *SyncStocksRequest, opts ...grpc.CallOption) (*SyncStocksResponse, error)
+	// StreamRatingEvents streams each record created or updated by sync until the client disconnects.
+	StreamRatingEvents(ctx context.Context, in *StreamRatingEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RatingEventUpdate], error)
+}
+
+type stockAnalyzerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewStockAnalyzerClient(cc grpc.ClientConnInterface) StockAnalyzerClient {
+	return &stockAnalyzerClient{cc}
+}
+
+func (c *stockAnalyzerClient) ListStocks(ctx context.Context, in *ListStocksRequest, opts ...grpc.CallOption) (*ListStocksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListStocksResponse)
+	err := c.cc.Invoke(ctx, StockAnalyzer_ListStocks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockAnalyzerClient) GetStock(ctx context.Context, in *GetStockRequest, opts ...grpc.CallOption) (*Stock, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Stock)
+	err := c.cc.Invoke(ctx, StockAnalyzer_GetStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockAnalyzerClient) ListRatingEvents(ctx context.Context, in *ListRatingEventsRequest, opts ...grpc.CallOption) (*ListRatingEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRatingEventsResponse)
+	err := c.cc.Invoke(ctx, StockAnalyzer_ListRatingEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockAnalyzerClient) GetRecommendations(ctx context.Context, in *GetRecommendationsRequest, opts ...grpc.CallOption) (*GetRecommendationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRecommendationsResponse)
+	err := c.cc.Invoke(ctx, StockAnalyzer_GetRecommendations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockAnalyzerClient) SyncStocks(ctx context.Context, in *SyncStocksRequest, opts ...grpc.CallOption) (*SyncStocksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SyncStocksResponse)
+	err := c.cc.Invoke(ctx, StockAnalyzer_SyncStocks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockAnalyzerClient) StreamRatingEvents(ctx context.Context, in *StreamRatingEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RatingEventUpdate], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &StockAnalyzer_ServiceDesc.Streams[0], StockAnalyzer_StreamRatingEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamRatingEventsRequest, RatingEventUpdate]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StockAnalyzer_StreamRatingEventsClient = grpc.ServerStreamingClient[RatingEventUpdate]
+
+// StockAnalyzerServer is the server API for StockAnalyzer service.
+// All implementations must embed UnimplementedStockAnalyzerServer
+// for forward compatibility.
+//
+// StockAnalyzer exposes stocks, rating history, recommendations and sync to internal services.
+type StockAnalyzerServer interface {
+	// ListStocks returns the latest record of each ticker, newest first.
+	ListStocks(context.Context, *ListStocksRequest) (*ListStocksResponse, error)
+	// GetStock returns the latest record of a ticker, or NOT_FOUND.
+	GetStock(context.Context, *GetStockRequest) (*Stock, error)
+	// ListRatingEvents returns the rating change history, newest first.
+	ListRatingEvents(context.Context, *ListRatingEventsRequest) (*ListRatingEventsResponse, error)
+	// GetRecommendations returns the current recommendations, or those for the latest records of a day.
+	GetRecommendations(context.Context, *GetRecommendationsRequest) (*GetRecommendationsResponse, error)
+	// SyncStocks fetches the upstream API and stores new records.
+	SyncStocks(context.Context, *SyncStocksRequest) (*SyncStocksResponse, error)
+	// StreamRatingEvents streams each record created or updated by sync until the client disconnects.
+	StreamRatingEvents(*StreamRatingEventsRequest, grpc.ServerStreamingServer[RatingEventUpdate]) error
+	mustEmbedUnimplementedStockAnalyzerServer()
+}
+
+// UnimplementedStockAnalyzerServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedStockAnalyzerServer struct{}
+
+func (UnimplementedStockAnalyzerServer) ListStocks(context.Context, *ListStocksRequest) (*ListStocksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListStocks not implemented")
+}
+func (UnimplementedStockAnalyzerServer) GetStock(context.Context, *GetStockRequest) (*Stock, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStock not implemented")
+}
+func (UnimplementedStockAnalyzerServer) ListRatingEvents(context.Context, *ListRatingEventsRequest) (*ListRatingEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRatingEvents not implemented")
+}
+func (UnimplementedStockAnalyzerServer) GetRecommendations(context.Context, *GetRecommendationsRequest) (*GetRecommendationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRecommendations not implemented")
+}
+func (UnimplementedStockAnalyzerServer) SyncStocks(context.Context, *SyncStocksRequest) (*SyncStocksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SyncStocks not implemented")
+}
+func (UnimplementedStockAnalyzerServer) StreamRatingEvents(*StreamRatingEventsRequest, grpc.ServerStreamingServer[RatingEventUpdate]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamRatingEvents not implemented")
+}
+func (UnimplementedStockAnalyzerServer) mustEmbedUnimplementedStockAnalyzerServer() {}
+func (UnimplementedStockAnalyzerServer) testEmbeddedByValue()                       {}
+
+// UnsafeStockAnalyzerServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to StockAnalyzerServer will
+// result in compilation errors.
+type UnsafeStockAnalyzerServer interface {
+	mustEmbedUnimplementedStockAnalyzerServer()
+}
+
+func RegisterStockAnalyzerServer(s grpc.ServiceRegistrar, srv StockAnalyzerServer) {
+	// If the following call pancis, it indicates UnimplementedStockAnalyzerServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&StockAnalyzer_ServiceDesc, srv)
+}
+
+func _StockAnalyzer_ListStocks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListStocksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockAnalyzerServer).ListStocks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockAnalyzer_ListStocks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockAnalyzerServer).ListStocks(ctx, req.(*ListStocksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockAnalyzer_GetStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockAnalyzerServer).GetStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockAnalyzer_GetStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockAnalyzerServer).GetStock(ctx, req.(*GetStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockAnalyzer_ListRatingEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRatingEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockAnalyzerServer).ListRatingEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockAnalyzer_ListRatingEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockAnalyzerServer).ListRatingEvents(ctx, req.(*ListRatingEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockAnalyzer_GetRecommendations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRecommendationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockAnalyzerServer).GetRecommendations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockAnalyzer_GetRecommendations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockAnalyzerServer).GetRecommendations(ctx, req.(*GetRecommendationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockAnalyzer_SyncStocks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SyncStocksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockAnalyzerServer).SyncStocks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockAnalyzer_SyncStocks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockAnalyzerServer).SyncStocks(ctx, req.(*SyncStocksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockAnalyzer_StreamRatingEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamRatingEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StockAnalyzerServer).StreamRatingEvents(m, &grpc.GenericServerStream[StreamRatingEventsRequest, RatingEventUpdate]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StockAnalyzer_StreamRatingEventsServer = grpc.ServerStreamingServer[RatingEventUpdate]
+
+// StockAnalyzer_ServiceDesc is the grpc.ServiceDesc for StockAnalyzer service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var StockAnalyzer_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "stockanalyzer.v1.StockAnalyzer",
+	HandlerType: (*StockAnalyzerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListStocks",
+			Handler:    _StockAnalyzer_ListStocks_Handler,
+		},
+		{
+			MethodName: "GetStock",
+			Handler:    _StockAnalyzer_GetStock_Handler,
+		},
+		{
+			MethodName: "ListRatingEvents",
+			Handler:    _StockAnalyzer_ListRatingEvents_Handler,
+		},
+		{
+			MethodName: "GetRecommendations",
+			Handler:    _StockAnalyzer_GetRecommendations_Handler,
+		},
+		{
+			MethodName: "SyncStocks",
+			Handler:    _StockAnalyzer_SyncStocks_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamRatingEvents",
+			Handler:       _StockAnalyzer_StreamRatingEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "stockanalyzer/v1/stock_analyzer.proto",
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"go.uber.org/fx"
+
+	"github.com/liferip/stock-analyzer/backend/config"
+	"github.com/liferip/stock-analyzer/backend/db"
+	"github.com/liferip/stock-analyzer/backend/internal/cache"
+	"github.com/liferip/stock-analyzer/backend/internal/events"
+	"github.com/liferip/stock-analyzer/backend/internal/metrics"
+	"github.com/liferip/stock-analyzer/backend/internal/models"
+	"github.com/liferip/stock-analyzer/backend/internal/repository"
+	"github.com/liferip/stock-analyzer/backend/internal/service"
+	"github.com/liferip/stock-analyzer/backend/pkg/httpclient"
+	"github.com/liferip/stock-analyzer/backend/pkg/logger"
+)
+
+// runBacktest ejecuta un backtest desde la línea de comandos y escribe el resultado en JSON
+//
+//	backend backtest -from 2025-01-01 -to 2025-03-31 -top 5 -horizon 5 -strategy score -out result.json
+func runBacktest(args []string) error {
+	flags := flag.NewFlagSet("backtest", flag.ContinueOnError)
+	from := flags.String("from", "", "start date in YYYY-MM-DD format (default: 90 days before -to)")
+	to := flags.String("to", "", "end date in YYYY-MM-DD format (default: today)")
+	top := flags.Int("top", 5, "number of recommendations per day")
+	horizon := flags.Int("horizon", 5, "forward return horizon in days, at most 365")
+	strategy := flags.String("strategy", "score", "ranking strategy: score or upside")
+	benchmark := flags.String("benchmark", "", "benchmark ticker (default: BACKTEST_BENCHMARK)")
+	out := flags.String("out", "", "file to write the JSON result to (default: stdout)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	params := models.BacktestParams{
+		Strategy:  *strategy,
+		Benchmark: *benchmark,
+	}
+	// Solo se envían los valores indicados, para que el servicio aplique sus valores por defecto
+	flags.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "top":
+			params.Top = top
+		case "horizon":
+			params.Horizon = horizon
+		}
+	})
+
+	var err error
+	if *from != "" {
+		if params.From, err = time.Parse(time.DateOnly, *from); err != nil {
+			return fmt.Errorf("invalid -from: %w", err)
+		}
+	}
+	if *to != "" {
+		if params.To, err = time.Parse(time.DateOnly, *to); err != nil {
+			return fmt.Errorf("invalid -to: %w", err)
+		}
+	}
+
+	// Construir solo las dependencias necesarias, sin servidor HTTP ni los trabajos en segundo
+	// plano de service.Module, que podrían enviar webhooks o correos durante el backtest
+	var stockService service.StockService
+	app := fx.New(
+		config.Module,
+		logger.Module,
+		db.Module,
+		httpclient.Module,
+		events.Module,
+		cache.Module,
+		metrics.Module,
+		repository.Module,
+		fx.Provide(service.NewStockService, service.NewTaxonomyService),
+		fx.Populate(&stockService),
+		fx.NopLogger,
+	)
+
+	ctx := context.Background()
+	if err := app.Start(ctx); err != nil {
+		return fmt.Errorf("error starting application: %w", err)
+	}
+	defer app.Stop(ctx)
+
+	result, err := stockService.RunBacktest(ctx, params)
+	if err != nil {
+		return fmt.Errorf("error running backtest: %w", err)
+	}
+
+	var w io.Writer = os.Stdout
+	if *out != "" {
+		file, err := os.Create(*out)
+		if err != nil {
+			return fmt.Errorf("error creating output file: %w", err)
+		}
+		defer file.Close()
+		w = file
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(result)
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/liferip/stock-analyzer/backend/config"
+)
+
+// runConfig ejecuta los subcomandos de configuración. print escribe la configuración efectiva
+// en YAML, con los secretos ocultos y el origen de cada valor, y falla si no es válida.
+//
+//	backend config print -file config.yaml
+func runConfig(args []string) error {
+	if len(args) == 0 || args[0] != "print" {
+		return errors.New("usage: backend config print [-file config.yaml]")
+	}
+
+	flags := flag.NewFlagSet("config print", flag.ContinueOnError)
+	file := flags.String("file", os.Getenv("CONFIG_FILE"), "YAML configuration file (default: CONFIG_FILE)")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	cfg, sources, loadErr := config.Load(*file)
+	if err := cfg.Print(os.Stdout, sources); err != nil {
+		return fmt.Errorf("error printing configuration: %w", err)
+	}
+
+	return loadErr
+}
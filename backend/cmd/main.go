@@ -3,26 +3,37 @@ package main
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
-	"os/signal"
-	"syscall"
 	"time"
 
 	"github.com/gorilla/mux"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 
 	"github.com/liferip/stock-analyzer/backend/api"
+	"github.com/liferip/stock-analyzer/backend/api/graph"
 	"github.com/liferip/stock-analyzer/backend/api/handlers"
 	"github.com/liferip/stock-analyzer/backend/api/routes"
+	"github.com/liferip/stock-analyzer/backend/api/rpc"
 	"github.com/liferip/stock-analyzer/backend/api/swagger"
 	"github.com/liferip/stock-analyzer/backend/config"
 	"github.com/liferip/stock-analyzer/backend/db"
+	"github.com/liferip/stock-analyzer/backend/internal/auth"
+	"github.com/liferip/stock-analyzer/backend/internal/cache"
+	"github.com/liferip/stock-analyzer/backend/internal/cors"
+	"github.com/liferip/stock-analyzer/backend/internal/events"
+	"github.com/liferip/stock-analyzer/backend/internal/health"
+	"github.com/liferip/stock-analyzer/backend/internal/metrics"
+	"github.com/liferip/stock-analyzer/backend/internal/ratelimit"
 	"github.com/liferip/stock-analyzer/backend/internal/repository"
 	"github.com/liferip/stock-analyzer/backend/internal/service"
+	"github.com/liferip/stock-analyzer/backend/internal/tracing"
 	"github.com/liferip/stock-analyzer/backend/pkg/httpclient"
 	"github.com/liferip/stock-analyzer/backend/pkg/logger"
+	"github.com/liferip/stock-analyzer/backend/pkg/mailer"
 
 	_ "github.com/liferip/stock-analyzer/backend/docs" // Importar documentos generados
 )
@@ -42,22 +53,62 @@ import (
 //	@host		stock-analyzer.ddns.net:8081
 //	@BasePath	/api
 //	@schemes	http
+
+//	@securityDefinitions.apikey	ApiKeyAuth
+//	@in							header
+//	@name						X-API-Key
+//	@description				API key. GET, HEAD and read-only routes require the read:stocks scope, sync requires write:sync and every other method requires admin
+
+//	@securityDefinitions.apikey	BearerAuth
+//	@in							header
+//	@name						Authorization
+//	@description				"Bearer " followed by a JWT (HS256 or RS256) or an API key
+
+//	@security	ApiKeyAuth
+//	@security	BearerAuth
 func main() {
+	// Ejecutar subcomandos de línea de comandos
+	if len(os.Args) > 1 && os.Args[1] == "backtest" {
+		if err := runBacktest(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "config" {
+		if err := runConfig(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	fx.New(
 		// Incluir módulos
 		config.Module,
 		logger.Module,
 		db.Module,
 		httpclient.Module,
+		mailer.Module,
+		events.Module,
+		auth.Module,
+		ratelimit.Module,
+		cors.Module,
+		metrics.Module,
+		tracing.Module,
+		health.Module,
+		cache.Module,
 		repository.Module,
 		service.Module,
+		graph.Module,
 		handlers.Module,
 		routes.Module,
 		api.Module,
 		swagger.Module,
+		rpc.Module,
 
 		// Registrar hooks
-		fx.Invoke(register),
+		fx.Invoke(register, registerGRPC),
 	).Run()
 }
 
@@ -67,22 +118,29 @@ func register(
 	router *mux.Router,
 	logger *zap.Logger,
 	stockService service.StockService,
+	stockStream service.StockStream,
 ) {
 	// Crear servidor HTTP
 	server := &http.Server{
-		Addr:         fmt.Sprintf(":%s", cfg.ServerPort),
+		Addr:         fmt.Sprintf(":%d", cfg.ServerPort),
 		Handler:      router,
 		ReadTimeout:  15 * time.Second,
 		WriteTimeout: 300 * time.Second,
 		IdleTimeout:  60 * time.Second,
 	}
 
+	// Registrar la configuración efectiva, con los secretos ocultos
+	logger.Info("Configuration loaded", zap.Any("config", cfg.Redacted()))
+
+	// Cerrar las conexiones de streaming al iniciar el apagado, ya que nunca quedan inactivas
+	server.RegisterOnShutdown(stockStream.Close)
+
 	// Registrar hooks del ciclo de vida
 	lc.Append(fx.Hook{
 		OnStart: func(ctx context.Context) error {
 			// Iniciar servidor en una goroutine
 			go func() {
-				logger.Info("Server running", zap.String("port", cfg.ServerPort))
+				logger.Info("Server running", zap.Int("port", cfg.ServerPort))
 				if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 					logger.Fatal("Error running the server", zap.Error(err))
 				}
@@ -121,13 +179,50 @@ func register(
 			return nil
 		},
 	})
+}
+
+func registerGRPC(
+	lc fx.Lifecycle,
+	cfg *config.Config,
+	grpcServer *grpc.Server,
+	rpcServer *rpc.Server,
+	logger *zap.Logger,
+) {
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			listener, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.GRPCPort))
+			if err != nil {
+				return fmt.Errorf("error listening for gRPC: %w", err)
+			}
 
-	// Manejar señales de cierre
-	go func() {
-		sigChan := make(chan os.Signal, 1)
-		signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
-		sig := <-sigChan
-		logger.Info("Signal received, initiating server shutdown", zap.String("signal", sig.String()))
-		os.Exit(0)
-	}()
+			// Iniciar servidor gRPC en una goroutine
+			go func() {
+				logger.Info("gRPC server running", zap.Int("port", cfg.GRPCPort))
+				if err := grpcServer.Serve(listener); err != nil {
+					logger.Fatal("Error running the gRPC server", zap.Error(err))
+				}
+			}()
+
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			logger.Info("Stopping gRPC server...")
+
+			// Terminar los streams abiertos, ya que GracefulStop espera a que terminen
+			rpcServer.Close()
+
+			stopped := make(chan struct{})
+			go func() {
+				grpcServer.GracefulStop()
+				close(stopped)
+			}()
+
+			select {
+			case <-stopped:
+			case <-ctx.Done():
+				grpcServer.Stop()
+			}
+			return nil
+		},
+	})
 }
@@ -1,7 +1,9 @@
 package config
 
 import (
+	"net/url"
 	"os"
+	"time"
 
 	"github.com/joho/godotenv"
 	"go.uber.org/fx"
@@ -10,47 +12,149 @@ import (
 // Module proporciona las dependencias de la configuración
 var Module = fx.Provide(LoadConfig)
 
-// Config contiene la configuración de la aplicación
+// envFile es el archivo de variables de entorno que se carga si existe
+const envFile = ".env"
+
+// Config contiene la configuración de la aplicación. Cada campo se lee, en orden de prioridad,
+// de la variable de entorno del tag env, del archivo YAML indicado en CONFIG_FILE con la clave
+// del tag key, o del valor del tag default. Los campos con el tag secret se ocultan al mostrar
+// la configuración.
 type Config struct {
-	DatabaseUser    string
-	DatabasePass    string
-	DatabaseHost    string
-	DatabasePort    string
-	DatabaseName    string
-	DatabaseSSLMode string
-	DatabaseURL     string
-	APIEndpoint     string
-	APIKey          string
-	ServerPort      string
-	Environment     string
-	SwaggerHost     string
+	// DatabaseDriver es el almacenamiento de los datos: cockroach, sqlite (un archivo en
+	// DatabasePath) o memory (se pierde al detener el backend)
+	DatabaseDriver  string `key:"database.driver" env:"DATABASE_DRIVER" default:"cockroach"`
+	DatabasePath    string `key:"database.path" env:"DATABASE_PATH" default:"stock_analyzer.db"`
+	DatabaseUser    string `key:"database.user" env:"DATABASE_USER" default:"root"`
+	DatabasePass    string `key:"database.pass" env:"DATABASE_PASS" secret:"true"`
+	DatabaseHost    string `key:"database.host" env:"DATABASE_HOST" default:"cockroach"`
+	DatabasePort    int    `key:"database.port" env:"DATABASE_PORT" default:"26257"`
+	DatabaseName    string `key:"database.name" env:"DATABASE_NAME" default:"stock_analyzer_db"`
+	DatabaseSSLMode string `key:"database.ssl_mode" env:"DATABASE_SSL_MODE" default:"disable"`
+
+	// APIEndpoint y APIKey son la URL y la credencial de la API externa de stocks
+	APIEndpoint *url.URL `key:"api.endpoint" env:"API_ENDPOINT" default:"http://localhost:8083"`
+	APIKey      string   `key:"api.key" env:"API_KEY" secret:"true"`
+
+	ServerPort  int    `key:"server.port" env:"PORT" default:"8080"`
+	GRPCPort    int    `key:"server.grpc_port" env:"GRPC_PORT" default:"9090"`
+	Environment string `key:"server.environment" env:"ENVIRONMENT" default:"development"`
+	SwaggerHost string `key:"server.swagger_host" env:"SWAGGER_HOST" default:"localhost:8080"`
+
+	// BacktestBenchmark es el ticker usado como referencia en los backtests
+	BacktestBenchmark string `key:"backtest.benchmark" env:"BACKTEST_BENCHMARK" default:"SPY"`
+
+	// SnapshotSize es la cantidad de recomendaciones guardadas en la foto diaria
+	SnapshotSize int `key:"snapshot.size" env:"SNAPSHOT_SIZE" default:"10"`
+
+	// TaxonomyRefresh es cada cuánto se vuelve a leer la taxonomía, para ver los cambios hechos
+	// desde otras instancias; cero lo desactiva
+	TaxonomyRefresh time.Duration `key:"taxonomy.refresh" env:"TAXONOMY_REFRESH_SECONDS" default:"1m"`
+
+	// WebhookMaxAttempts es la cantidad máxima de intentos de entrega de un webhook
+	WebhookMaxAttempts int `key:"webhook.max_attempts" env:"WEBHOOK_MAX_ATTEMPTS" default:"6"`
+	// WebhookBackoff es la espera antes del primer reintento; se duplica en cada intento
+	WebhookBackoff time.Duration `key:"webhook.backoff" env:"WEBHOOK_BACKOFF_SECONDS" default:"30s"`
+
+	// Configuración del servidor SMTP usado para el resumen diario por correo
+	SMTPHost string `key:"smtp.host" env:"SMTP_HOST"`
+	SMTPPort int    `key:"smtp.port" env:"SMTP_PORT" default:"25"`
+	SMTPUser string `key:"smtp.user" env:"SMTP_USER"`
+	SMTPPass string `key:"smtp.pass" env:"SMTP_PASS" secret:"true"`
+	SMTPFrom string `key:"smtp.from" env:"SMTP_FROM" default:"stock-analyzer@localhost"`
+
+	// DigestRecipients son los destinatarios del resumen diario; sin destinatarios no se envía
+	DigestRecipients []string `key:"digest.recipients" env:"DIGEST_RECIPIENTS"`
+
+	// AuthAPIKeys son las API keys aceptadas, con el formato rol:key
+	AuthAPIKeys []string `key:"auth.api_keys" env:"AUTH_API_KEYS" secret:"true"`
+	// AuthAnonymousRole es el rol de las solicitudes sin credenciales; vacío para rechazarlas
+	AuthAnonymousRole string `key:"auth.anonymous_role" env:"AUTH_ANONYMOUS_ROLE"`
+
+	// Claves locales para validar tokens JWT; sin claves no se aceptan tokens
+	JWTHS256Secret        string `key:"jwt.hs256_secret" env:"JWT_HS256_SECRET" secret:"true"`
+	JWTRS256PublicKeyFile string `key:"jwt.rs256_public_key_file" env:"JWT_RS256_PUBLIC_KEY_FILE"`
+	// JWTIssuer y JWTAudience, si se configuran, deben coincidir con los claims iss y aud
+	JWTIssuer   string `key:"jwt.issuer" env:"JWT_ISSUER"`
+	JWTAudience string `key:"jwt.audience" env:"JWT_AUDIENCE"`
+	// JWTRoleClaim es el claim que contiene el rol (o la lista de roles) del token
+	JWTRoleClaim string `key:"jwt.role_claim" env:"JWT_ROLE_CLAIM" default:"role"`
+
+	// GraphQLMaxDepth es la profundidad máxima de anidamiento de una consulta GraphQL
+	GraphQLMaxDepth int `key:"graphql.max_depth" env:"GRAPHQL_MAX_DEPTH" default:"6"`
+	// GraphQLMaxComplexity es la complejidad máxima de una consulta GraphQL
+	GraphQLMaxComplexity int `key:"graphql.max_complexity" env:"GRAPHQL_MAX_COMPLEXITY" default:"1000"`
+
+	// RateLimits son los límites por grupo de rutas, con el formato prefijo=solicitudes/periodo
+	RateLimits []string `key:"rate_limit.rules" env:"RATE_LIMITS" default:"/=120/1m,/stock=60/1m,/stock/sync=6/1m"`
+	// RateLimitAuthFailures limita las credenciales inválidas por IP, con el formato solicitudes/periodo; vacío no limita
+	RateLimitAuthFailures string `key:"rate_limit.auth_failures" env:"RATE_LIMIT_AUTH_FAILURES" default:"10/1m"`
+	// RateLimitTrustForwarded usa X-Forwarded-For para identificar al cliente detrás de un proxy
+	RateLimitTrustForwarded bool `key:"rate_limit.trust_forwarded" env:"RATE_LIMIT_TRUST_FORWARDED" default:"false"`
+
+	// CORSAllowedOrigins son los orígenes que pueden llamar a la API desde un navegador: exactos
+	// (https://app.example.com), con comodín de subdominio (https://*.example.com) o * para todos
+	CORSAllowedOrigins []string `key:"cors.allowed_origins" env:"CORS_ALLOWED_ORIGINS" default:"http://localhost:8082"`
+	// CORSAllowedMethods y CORSAllowedHeaders son los métodos y headers aceptados en preflight
+	CORSAllowedMethods []string `key:"cors.allowed_methods" env:"CORS_ALLOWED_METHODS" default:"GET,HEAD,POST,PUT,DELETE"`
+	CORSAllowedHeaders []string `key:"cors.allowed_headers" env:"CORS_ALLOWED_HEADERS" default:"Content-Type,Authorization,X-API-Key,X-Request-ID,If-None-Match,If-Modified-Since"`
+	// CORSExposedHeaders son los headers de las respuestas que puede leer el navegador
+	CORSExposedHeaders []string `key:"cors.exposed_headers" env:"CORS_EXPOSED_HEADERS" default:"ETag,X-Request-ID,Retry-After,RateLimit-Policy,RateLimit-Limit,RateLimit-Remaining,RateLimit-Reset"`
+	// CORSAllowCredentials permite enviar cookies y autenticación HTTP desde otros orígenes
+	CORSAllowCredentials bool `key:"cors.allow_credentials" env:"CORS_ALLOW_CREDENTIALS" default:"false"`
+	// CORSMaxAge es el tiempo que el navegador guarda la respuesta de una solicitud preflight
+	CORSMaxAge time.Duration `key:"cors.max_age" env:"CORS_MAX_AGE_SECONDS" default:"10m"`
+
+	// CacheBackend es el almacenamiento de la caché de listados y recomendaciones: memory o none
+	CacheBackend string `key:"cache.backend" env:"CACHE_BACKEND" default:"memory"`
+	// CacheTTL es el tiempo máximo que se guarda un resultado, aunque no haya sincronizaciones
+	CacheTTL time.Duration `key:"cache.ttl" env:"CACHE_TTL_SECONDS" default:"10m"`
+	// CacheMaxEntries es la cantidad máxima de resultados guardados en memoria
+	CacheMaxEntries int `key:"cache.max_entries" env:"CACHE_MAX_ENTRIES" default:"1000"`
+
+	// UpstreamBreakerThreshold es la cantidad de fallas seguidas de la API externa que abren el
+	// circuito; cero lo desactiva
+	UpstreamBreakerThreshold int `key:"upstream.breaker_threshold" env:"UPSTREAM_BREAKER_THRESHOLD" default:"5"`
+	// UpstreamBreakerCooldown es la espera con el circuito abierto antes de volver a probar
+	UpstreamBreakerCooldown time.Duration `key:"upstream.breaker_cooldown" env:"UPSTREAM_BREAKER_COOLDOWN_SECONDS" default:"1m"`
+
+	// ReadyDBTimeout es el tiempo máximo del chequeo de la base de datos en /readyz
+	ReadyDBTimeout time.Duration `key:"health.ready_db_timeout" env:"READY_DB_TIMEOUT_SECONDS" default:"2s"`
+	// SyncStaleAfter es la antigüedad a partir de la cual la última sincronización exitosa se
+	// considera desactualizada
+	SyncStaleAfter time.Duration `key:"health.sync_stale_after" env:"SYNC_STALE_AFTER_SECONDS" default:"24h"`
+
+	// TracingExporter es el destino de las trazas: none, stdout u otlp
+	TracingExporter string `key:"tracing.exporter" env:"TRACING_EXPORTER" default:"none"`
+	// TracingSampleRatio es la fracción de trazas nuevas que se registran, entre 0 y 1
+	TracingSampleRatio float64 `key:"tracing.sample_ratio" env:"TRACING_SAMPLE_RATIO" default:"1"`
 }
 
-// LoadConfig carga la configuración desde variables de entorno
+// LoadConfig carga la configuración desde el archivo de CONFIG_FILE, si se indica, y las
+// variables de entorno, incluidas las de .env si existe. Devuelve todos los valores inválidos
+// en un solo error.
 func LoadConfig() (*Config, error) {
-	// Cargar variables de entorno desde .env si existe
-	_ = godotenv.Load()
-
-	return &Config{
-		DatabaseUser:    getEnv("DATABASE_USER", "root"),
-		DatabasePass:    getEnv("DATABASE_PASS", ""),
-		DatabaseHost:    getEnv("DATABASE_HOST", "cockroach"),
-		DatabasePort:    getEnv("DATABASE_PORT", "26257"),
-		DatabaseName:    getEnv("DATABASE_NAME", "stock_analyzer_db"),
-		DatabaseSSLMode: getEnv("DATABASE_SSL_MODE", "disable"),
-		APIEndpoint:     getEnv("API_ENDPOINT", "http://localhost:8083"),
-		APIKey:          getEnv("API_KEY", "exampleApiKey"),
-		ServerPort:      getEnv("PORT", "8080"),
-		Environment:     getEnv("ENVIRONMENT", "development"),
-		SwaggerHost:     getEnv("SWAGGER_HOST", "localhost:8080"),
-	}, nil
+	cfg, _, err := Load(os.Getenv("CONFIG_FILE"))
+	return cfg, err
 }
 
-// getEnv obtiene una variable de entorno o devuelve un valor por defecto
-func getEnv(key, defaultValue string) string {
-	value := os.Getenv(key)
-	if value == "" {
-		return defaultValue
+// Load carga la configuración como LoadConfig desde el archivo YAML indicado (vacío para no usar
+// ninguno) y devuelve también el origen de cada valor, por clave. Si la configuración es
+// inválida, devuelve la configuración cargada junto con el error.
+func Load(file string) (*Config, Sources, error) {
+	// Cargar variables de entorno desde .env si existe, sin reemplazar las ya definidas
+	_ = godotenv.Load(envFile)
+
+	l := newLoader()
+	cfg := &Config{}
+
+	l.applyDefaults(cfg)
+	if file != "" {
+		l.applyFile(cfg, file)
 	}
-	return value
+	l.applyEnv(cfg, os.LookupEnv)
+	l.checkEnvFile(envFile)
+
+	cfg.validate(l.problems)
+
+	return cfg, l.sources, l.problems.err()
 }
@@ -0,0 +1,330 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+// Sources indica de dónde salió cada valor de la configuración, por clave
+type Sources map[string]string
+
+// Orígenes posibles de un valor
+const (
+	sourceDefault = "default"
+	sourceFile    = "file"
+	sourceEnv     = "env"
+)
+
+// envPrefixes son los prefijos de variables de entorno que lee otra biblioteca, como
+// OTEL_EXPORTER_OTLP_ENDPOINT, y que por eso se aceptan en .env
+var envPrefixes = []string{"OTEL_"}
+
+// otherEnvKeys son variables de entorno que no forman parte de Config pero se aceptan en .env
+var otherEnvKeys = map[string]bool{"CONFIG_FILE": true}
+
+// field describe un campo de Config
+type field struct {
+	index   int
+	name    string
+	key     string
+	env     string
+	def     string
+	defined bool
+	secret  bool
+}
+
+// fields obtiene los campos de Config en el orden en que se declaran
+func fields() []field {
+	t := reflect.TypeOf(Config{})
+	result := make([]field, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		def, defined := f.Tag.Lookup("default")
+		result = append(result, field{
+			index:   i,
+			name:    f.Name,
+			key:     f.Tag.Get("key"),
+			env:     f.Tag.Get("env"),
+			def:     def,
+			defined: defined,
+			secret:  f.Tag.Get("secret") == "true",
+		})
+	}
+	return result
+}
+
+// loader aplica cada fuente de valores y acumula los problemas encontrados
+type loader struct {
+	fields   []field
+	sources  Sources
+	problems *problems
+}
+
+// newLoader crea un loader para los campos de Config
+func newLoader() *loader {
+	fields := fields()
+	return &loader{
+		fields:   fields,
+		sources:  Sources{},
+		problems: newProblems(fields),
+	}
+}
+
+// applyDefaults asigna el valor del tag default de cada campo
+func (l *loader) applyDefaults(cfg *Config) {
+	for _, f := range l.fields {
+		if !f.defined {
+			continue
+		}
+		if err := setField(cfg, f, f.def); err != nil {
+			// Un default inválido es un error de programación
+			panic(fmt.Sprintf("invalid default for %s: %v", f.name, err))
+		}
+		l.sources[f.key] = sourceDefault
+	}
+}
+
+// applyFile asigna los valores de un archivo YAML. Las claves se pueden anidar (database:
+// port: 26257) o escribir con puntos (database.port: 26257); las claves desconocidas son un error.
+func (l *loader) applyFile(cfg *Config, file string) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		l.problems.add("CONFIG_FILE", "cannot read %s: %v", file, err)
+		return
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		l.problems.add("CONFIG_FILE", "invalid YAML in %s: %v", file, err)
+		return
+	}
+	if len(root.Content) == 0 {
+		return
+	}
+
+	values := map[string]*yaml.Node{}
+	if err := flattenYAML("", root.Content[0], values); err != nil {
+		l.problems.add("CONFIG_FILE", "%s: %v", file, err)
+		return
+	}
+
+	byKey := make(map[string]field, len(l.fields))
+	byEnv := make(map[string]field, len(l.fields))
+	for _, f := range l.fields {
+		byKey[f.key] = f
+		byEnv[f.env] = f
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		node := values[key]
+		f, ok := byKey[key]
+		if !ok {
+			if f, isEnv := byEnv[key]; isEnv {
+				l.problems.add("CONFIG_FILE", "%s line %d: %s is an environment variable, use the key %s",
+					file, node.Line, key, f.key)
+				continue
+			}
+			l.problems.add(key, "unknown setting in %s (line %d)", file, node.Line)
+			continue
+		}
+
+		value, err := yamlValue(node)
+		if err != nil {
+			l.problems.add(f.key, "%v (%s line %d)", err, file, node.Line)
+			continue
+		}
+		if err := setField(cfg, f, value); err != nil {
+			l.problems.add(f.key, "%v (%s line %d)", err, file, node.Line)
+			continue
+		}
+		l.sources[f.key] = sourceFile + " " + file
+	}
+}
+
+// applyEnv asigna los valores de las variables de entorno. Una variable vacía se ignora, salvo
+// en las listas, donde significa una lista vacía.
+func (l *loader) applyEnv(cfg *Config, lookup func(string) (string, bool)) {
+	for _, f := range l.fields {
+		value, ok := lookup(f.env)
+		if !ok || (value == "" && kindOf(cfg, f) != reflect.Slice) {
+			continue
+		}
+		if err := setField(cfg, f, value); err != nil {
+			l.problems.add(f.env, "%v", err)
+			continue
+		}
+		l.sources[f.key] = sourceEnv + " " + f.env
+	}
+}
+
+// checkEnvFile rechaza las variables de .env que no corresponden a ningún campo, como un nombre
+// mal escrito que de otra forma se ignoraría en silencio
+func (l *loader) checkEnvFile(file string) {
+	values, err := godotenv.Read(file)
+	if err != nil {
+		return
+	}
+
+	known := make(map[string]bool, len(l.fields))
+	for _, f := range l.fields {
+		known[f.env] = true
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if known[name] || otherEnvKeys[name] || hasEnvPrefix(name) {
+			continue
+		}
+		l.problems.add(name, "unknown setting in %s", file)
+	}
+}
+
+// hasEnvPrefix indica si una variable pertenece a otra biblioteca
+func hasEnvPrefix(name string) bool {
+	for _, prefix := range envPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// flattenYAML convierte los mapas anidados de YAML en claves con puntos
+func flattenYAML(prefix string, node *yaml.Node, values map[string]*yaml.Node) error {
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: expected a mapping of settings", node.Line)
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := node.Content[i].Value
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		value := node.Content[i+1]
+		if value.Kind == yaml.MappingNode {
+			if err := flattenYAML(key, value, values); err != nil {
+				return err
+			}
+			continue
+		}
+		values[key] = value
+	}
+	return nil
+}
+
+// yamlValue obtiene el texto de un valor de YAML; las secuencias se unen con comas
+func yamlValue(node *yaml.Node) (string, error) {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		return node.Value, nil
+	case yaml.SequenceNode:
+		items := make([]string, 0, len(node.Content))
+		for _, item := range node.Content {
+			if item.Kind != yaml.ScalarNode {
+				return "", fmt.Errorf("expected a list of values")
+			}
+			items = append(items, item.Value)
+		}
+		return strings.Join(items, ","), nil
+	default:
+		return "", fmt.Errorf("expected a value or a list of values")
+	}
+}
+
+// kindOf obtiene el tipo de un campo
+func kindOf(cfg *Config, f field) reflect.Kind {
+	return reflect.ValueOf(cfg).Elem().Field(f.index).Kind()
+}
+
+// setField interpreta el texto de un valor según el tipo del campo y lo asigna
+func setField(cfg *Config, f field, value string) error {
+	target := reflect.ValueOf(cfg).Elem().Field(f.index)
+
+	switch target.Interface().(type) {
+	case string:
+		target.SetString(value)
+	case int:
+		n, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			return fmt.Errorf("%q is not an integer", value)
+		}
+		target.SetInt(int64(n))
+	case float64:
+		n, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return fmt.Errorf("%q is not a number", value)
+		}
+		target.SetFloat(n)
+	case bool:
+		b, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return fmt.Errorf("%q is not a boolean (true or false)", value)
+		}
+		target.SetBool(b)
+	case time.Duration:
+		d, err := parseDuration(value)
+		if err != nil {
+			return err
+		}
+		target.SetInt(int64(d))
+	case []string:
+		target.Set(reflect.ValueOf(splitList(value)))
+	case *url.URL:
+		u, err := url.Parse(strings.TrimSpace(value))
+		if err != nil {
+			return fmt.Errorf("%q is not a valid URL", value)
+		}
+		target.Set(reflect.ValueOf(u))
+	default:
+		return fmt.Errorf("unsupported setting type %s", target.Type())
+	}
+
+	return nil
+}
+
+// parseDuration interpreta una duración como 30s o 10m. Un número sin unidad se interpreta en
+// segundos, para aceptar las variables con sufijo _SECONDS.
+func parseDuration(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second, nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a duration such as 30s, 10m or 24h", value)
+	}
+	return d, nil
+}
+
+// splitList separa valores separados por comas, sin espacios ni valores vacíos
+func splitList(value string) []string {
+	values := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			values = append(values, item)
+		}
+	}
+	return values
+}
@@ -0,0 +1,120 @@
+package config
+
+import (
+	"io"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// redacted reemplaza los valores secretos al mostrar la configuración
+const redacted = "********"
+
+// Redacted devuelve la configuración efectiva por clave, con los secretos ocultos, para
+// registrarla en los logs
+func (c *Config) Redacted() map[string]interface{} {
+	values := make(map[string]interface{})
+	for _, f := range fields() {
+		values[f.key] = c.displayValue(f)
+	}
+	return values
+}
+
+// Print escribe la configuración efectiva en YAML, con los secretos ocultos y el origen de cada
+// valor como comentario. El resultado se puede usar como archivo de CONFIG_FILE.
+func (c *Config) Print(w io.Writer, sources Sources) error {
+	root := &yaml.Node{Kind: yaml.MappingNode}
+	sections := map[string]*yaml.Node{}
+
+	for _, f := range fields() {
+		section, name, _ := strings.Cut(f.key, ".")
+		parent, ok := sections[section]
+		if !ok {
+			parent = &yaml.Node{Kind: yaml.MappingNode}
+			sections[section] = parent
+			root.Content = append(root.Content, scalarNode(section), parent)
+		}
+
+		value := valueNode(c.displayValue(f))
+		// El comentario indica el origen y, si no es una variable de entorno, cuál lo reemplazaría
+		source := sources[f.key]
+		if source == "" {
+			source = "unset"
+		}
+		if !strings.HasPrefix(source, sourceEnv) {
+			source += ", " + f.env
+		}
+		value.LineComment = source
+		parent.Content = append(parent.Content, scalarNode(name), value)
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(root); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// displayValue obtiene el valor de un campo para mostrarlo, ocultando los secretos. En las API
+// keys se muestra el rol, que no es secreto.
+func (c *Config) displayValue(f field) interface{} {
+	value := reflect.ValueOf(c).Elem().Field(f.index).Interface()
+
+	switch v := value.(type) {
+	case string:
+		if f.secret && v != "" {
+			return redacted
+		}
+		return v
+	case []string:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = item
+			if f.secret {
+				role, _, _ := strings.Cut(item, ":")
+				items[i] = role + ":" + redacted
+			}
+		}
+		return items
+	case time.Duration:
+		return v.String()
+	case *url.URL:
+		if v == nil {
+			return ""
+		}
+		return v.Redacted()
+	default:
+		return v
+	}
+}
+
+// scalarNode crea un nodo YAML con un texto
+func scalarNode(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
+
+// valueNode crea un nodo YAML con el tipo del valor
+func valueNode(value interface{}) *yaml.Node {
+	switch v := value.(type) {
+	case []string:
+		node := &yaml.Node{Kind: yaml.SequenceNode, Style: yaml.FlowStyle}
+		for _, item := range v {
+			node.Content = append(node.Content, scalarNode(item))
+		}
+		return node
+	case int:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.Itoa(v)}
+	case float64:
+		// Sin tag, para que 1 no se escriba como !!float 1
+		return &yaml.Node{Kind: yaml.ScalarNode, Value: strconv.FormatFloat(v, 'g', -1, 64)}
+	case bool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: strconv.FormatBool(v)}
+	default:
+		return scalarNode(value.(string))
+	}
+}
@@ -0,0 +1,203 @@
+package config
+
+import (
+	"fmt"
+	"net/mail"
+	"os"
+	"strings"
+)
+
+// ValidationError reúne todos los problemas de la configuración, para corregirlos de una vez
+type ValidationError struct {
+	Problems []string
+}
+
+// Error devuelve un problema por línea
+func (e *ValidationError) Error() string {
+	return "invalid configuration:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+// problems acumula los problemas encontrados al cargar y validar la configuración
+type problems struct {
+	// names identifica cada valor por su variable de entorno y su clave, a partir de cualquiera de las dos
+	names map[string]string
+	list  []string
+}
+
+// newProblems crea un acumulador de problemas para los campos indicados
+func newProblems(fields []field) *problems {
+	names := make(map[string]string, 2*len(fields))
+	for _, f := range fields {
+		name := f.env + " (" + f.key + ")"
+		names[f.env] = name
+		names[f.key] = name
+	}
+	return &problems{names: names}
+}
+
+// add registra un problema de un valor, identificado por su clave o variable de entorno
+func (p *problems) add(setting, format string, args ...interface{}) {
+	if name, ok := p.names[setting]; ok {
+		setting = name
+	}
+	p.list = append(p.list, setting+": "+fmt.Sprintf(format, args...))
+}
+
+// err devuelve un ValidationError si hubo problemas
+func (p *problems) err() error {
+	if len(p.list) == 0 {
+		return nil
+	}
+	return &ValidationError{Problems: p.list}
+}
+
+// Validator comprueba valores que interpreta otro paquete, como las reglas de RATE_LIMITS, que
+// config no puede importar porque ese paquete depende de config. report registra el error de
+// un valor, identificado por su variable de entorno o su clave.
+type Validator func(c *Config, report func(setting string, err error))
+
+// validators contiene las comprobaciones registradas con RegisterValidator
+var validators []Validator
+
+// RegisterValidator agrega una comprobación a la validación de Load, para que los valores
+// inválidos de otros paquetes se informen junto con los demás. Se llama desde init.
+func RegisterValidator(v Validator) {
+	validators = append(validators, v)
+}
+
+// validate comprueba que los valores sean coherentes, además de tener el tipo correcto
+func (c *Config) validate(p *problems) {
+	oneOf(p, "DATABASE_DRIVER", c.DatabaseDriver, "cockroach", "sqlite", "memory")
+	switch c.DatabaseDriver {
+	case "cockroach":
+		required(p, "DATABASE_USER", c.DatabaseUser)
+		required(p, "DATABASE_HOST", c.DatabaseHost)
+		required(p, "DATABASE_NAME", c.DatabaseName)
+		port(p, "DATABASE_PORT", c.DatabasePort)
+		oneOf(p, "DATABASE_SSL_MODE", c.DatabaseSSLMode, "disable", "allow", "prefer", "require", "verify-ca", "verify-full")
+	case "sqlite":
+		required(p, "DATABASE_PATH", c.DatabasePath)
+	}
+
+	if c.APIEndpoint == nil || c.APIEndpoint.Host == "" ||
+		(c.APIEndpoint.Scheme != "http" && c.APIEndpoint.Scheme != "https") {
+		p.add("API_ENDPOINT", "must be an absolute http or https URL")
+	}
+	required(p, "API_KEY", c.APIKey)
+
+	port(p, "PORT", c.ServerPort)
+	port(p, "GRPC_PORT", c.GRPCPort)
+	if c.ServerPort == c.GRPCPort {
+		p.add("GRPC_PORT", "must be different from PORT (%d)", c.ServerPort)
+	}
+	oneOf(p, "ENVIRONMENT", c.Environment, "development", "production")
+
+	required(p, "BACKTEST_BENCHMARK", c.BacktestBenchmark)
+	atLeast(p, "SNAPSHOT_SIZE", c.SnapshotSize, 1)
+
+	if c.TaxonomyRefresh < 0 {
+		p.add("TAXONOMY_REFRESH_SECONDS", "must not be negative")
+	}
+
+	atLeast(p, "WEBHOOK_MAX_ATTEMPTS", c.WebhookMaxAttempts, 1)
+	if c.WebhookBackoff <= 0 {
+		p.add("WEBHOOK_BACKOFF_SECONDS", "must be positive")
+	}
+
+	port(p, "SMTP_PORT", c.SMTPPort)
+	if c.SMTPHost != "" {
+		email(p, "SMTP_FROM", c.SMTPFrom)
+	}
+	for _, recipient := range c.DigestRecipients {
+		email(p, "DIGEST_RECIPIENTS", recipient)
+	}
+	if len(c.DigestRecipients) > 0 && c.SMTPHost == "" {
+		p.add("SMTP_HOST", "is required to send the digest to DIGEST_RECIPIENTS")
+	}
+
+	for _, entry := range c.AuthAPIKeys {
+		if role, key, ok := strings.Cut(entry, ":"); !ok || role == "" || key == "" {
+			p.add("AUTH_API_KEYS", "entries must have the format role:key")
+			break
+		}
+	}
+	if c.JWTRS256PublicKeyFile != "" {
+		if _, err := os.Stat(c.JWTRS256PublicKeyFile); err != nil {
+			p.add("JWT_RS256_PUBLIC_KEY_FILE", "cannot read %s: %v", c.JWTRS256PublicKeyFile, err)
+		}
+	}
+
+	atLeast(p, "GRAPHQL_MAX_DEPTH", c.GraphQLMaxDepth, 1)
+	atLeast(p, "GRAPHQL_MAX_COMPLEXITY", c.GraphQLMaxComplexity, 1)
+
+	if c.CORSMaxAge < 0 {
+		p.add("CORS_MAX_AGE_SECONDS", "must not be negative")
+	}
+
+	oneOf(p, "CACHE_BACKEND", c.CacheBackend, "memory", "none")
+	if c.CacheTTL <= 0 {
+		p.add("CACHE_TTL_SECONDS", "must be positive")
+	}
+	atLeast(p, "CACHE_MAX_ENTRIES", c.CacheMaxEntries, 1)
+
+	atLeast(p, "UPSTREAM_BREAKER_THRESHOLD", c.UpstreamBreakerThreshold, 0)
+	if c.UpstreamBreakerCooldown <= 0 {
+		p.add("UPSTREAM_BREAKER_COOLDOWN_SECONDS", "must be positive")
+	}
+
+	if c.ReadyDBTimeout <= 0 {
+		p.add("READY_DB_TIMEOUT_SECONDS", "must be positive")
+	}
+	if c.SyncStaleAfter <= 0 {
+		p.add("SYNC_STALE_AFTER_SECONDS", "must be positive")
+	}
+
+	oneOf(p, "TRACING_EXPORTER", c.TracingExporter, "none", "stdout", "otlp")
+	if c.TracingSampleRatio < 0 || c.TracingSampleRatio > 1 {
+		p.add("TRACING_SAMPLE_RATIO", "must be between 0 and 1")
+	}
+
+	for _, validator := range validators {
+		validator(c, func(setting string, err error) {
+			p.add(setting, "%v", err)
+		})
+	}
+}
+
+// required comprueba que un valor no esté vacío
+func required(p *problems, setting, value string) {
+	if strings.TrimSpace(value) == "" {
+		p.add(setting, "is required")
+	}
+}
+
+// port comprueba que un puerto esté entre 1 y 65535
+func port(p *problems, setting string, value int) {
+	if value < 1 || value > 65535 {
+		p.add(setting, "%d is not a valid port (1-65535)", value)
+	}
+}
+
+// atLeast comprueba que un número no sea menor que min
+func atLeast(p *problems, setting string, value, min int) {
+	if value < min {
+		p.add(setting, "must be at least %d, got %d", min, value)
+	}
+}
+
+// oneOf comprueba que un valor sea una de las opciones
+func oneOf(p *problems, setting, value string, options ...string) {
+	for _, option := range options {
+		if value == option {
+			return
+		}
+	}
+	p.add(setting, "%q is not one of %s", value, strings.Join(options, ", "))
+}
+
+// email comprueba que un valor sea una dirección de correo
+func email(p *problems, setting, value string) {
+	if _, err := mail.ParseAddress(value); err != nil {
+		p.add(setting, "%q is not a valid email address", value)
+	}
+}
@@ -2,12 +2,15 @@ package db
 
 import (
 	"fmt"
-	"log"
+	"time"
 
+	"github.com/glebarez/sqlite"
 	"go.uber.org/fx"
+	"go.uber.org/zap"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
+	"gorm.io/gorm/clause"
+	gormlogger "gorm.io/gorm/logger"
 
 	"github.com/liferip/stock-analyzer/backend/config"
 	"github.com/liferip/stock-analyzer/backend/internal/models"
@@ -16,18 +19,48 @@ import (
 // Module proporciona las dependencias de la base de datos
 var Module = fx.Provide(NewDatabase)
 
-// NewDatabase inicializa y configura la conexión a la base de datos
-func NewDatabase(cfg *config.Config) (*gorm.DB, error) {
+// Drivers de almacenamiento disponibles
+const (
+	DriverCockroach = "cockroach"
+	DriverSQLite    = "sqlite"
+	DriverMemory    = "memory"
+)
+
+// memoryDSN abre una base de datos SQLite en memoria, que existe mientras su conexión siga abierta
+const memoryDSN = ":memory:"
+
+// NewDatabase inicializa y configura la conexión a la base de datos de DATABASE_DRIVER
+func NewDatabase(cfg *config.Config, logger *zap.Logger) (*gorm.DB, error) {
 	// Configurar el logger de GORM
 	gormConfig := &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Info),
+		Logger: gormlogger.Default.LogMode(gormlogger.Info),
+		// Traducir las violaciones de restricciones únicas a gorm.ErrDuplicatedKey
+		TranslateError: true,
 	}
 
 	// Si estamos en producción, reducir el nivel de log
 	if cfg.Environment == "production" {
-		gormConfig.Logger = logger.Default.LogMode(logger.Error)
+		gormConfig.Logger = gormlogger.Default.LogMode(gormlogger.Error)
 	}
 
+	logger = logger.Named("database")
+
+	switch cfg.DatabaseDriver {
+	case DriverCockroach, "":
+		return newCockroach(cfg, gormConfig, logger)
+	case DriverSQLite:
+		return NewSQLite(cfg.DatabasePath, gormConfig, logger)
+	case DriverMemory:
+		// Los datos de StockRepository se guardan en memoria en el repositorio; el resto de los
+		// repositorios usa una base de datos SQLite en memoria
+		return NewSQLite(memoryDSN, gormConfig, logger)
+	default:
+		return nil, fmt.Errorf("unknown DATABASE_DRIVER %q, expected 'cockroach', 'sqlite' or 'memory'", cfg.DatabaseDriver)
+	}
+}
+
+// newCockroach se conecta a CockroachDB, crea la base de datos si no existe y migra el esquema
+func newCockroach(cfg *config.Config, gormConfig *gorm.Config, logger *zap.Logger) (*gorm.DB, error) {
 	// Primero intentamos conectarnos a la base de datos postgres (default)
 	tempConfig := *cfg
 	tempConfig.DatabaseName = "defaultdb"
@@ -51,7 +84,7 @@ func NewDatabase(cfg *config.Config) (*gorm.DB, error) {
 	if result != "" {
 		// Si la base de datos no existe, crearla
 		tempDB.Exec(result)
-		log.Printf("Database %s created successfully", cfg.DatabaseName)
+		logger.Info("Database created", zap.String("database", cfg.DatabaseName))
 	}
 
 	// Cerrar la conexión temporal
@@ -78,13 +111,47 @@ func NewDatabase(cfg *config.Config) (*gorm.DB, error) {
 		return nil, fmt.Errorf("error migrating schema: %w", err)
 	}
 
-	log.Println("Connection to the database established successfully")
+	logger.Info("Connection to the database established")
+	return db, nil
+}
+
+// NewSQLite abre una base de datos SQLite en un archivo, que se crea si no existe, o en
+// memoria con ":memory:", y migra el esquema. El driver está escrito en Go, sin cgo.
+func NewSQLite(path string, gormConfig *gorm.Config, logger *zap.Logger) (*gorm.DB, error) {
+	dsn := path
+	if path != memoryDSN {
+		// Esperar a que se libere un bloqueo en lugar de fallar con "database is locked"
+		dsn = "file:" + path + "?_pragma=busy_timeout(5000)&_pragma=foreign_keys(1)"
+	}
+
+	db, err := gorm.Open(sqlite.Open(dsn), gormConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error opening SQLite database %s: %w", path, err)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("error getting SQL connection: %w", err)
+	}
+
+	// SQLite admite un solo escritor a la vez, y una base de datos en memoria solo existe en
+	// su conexión, así que se usa una sola conexión que nunca se cierra
+	sqlDB.SetMaxOpenConns(1)
+	sqlDB.SetMaxIdleConns(1)
+	sqlDB.SetConnMaxLifetime(0)
+	sqlDB.SetConnMaxIdleTime(0)
+
+	if err := migrateSchema(db); err != nil {
+		return nil, fmt.Errorf("error migrating schema: %w", err)
+	}
+
+	logger.Info("SQLite database opened", zap.String("path", path))
 	return db, nil
 }
 
 // createUrl crea la URL de conexión a la base de datos
 func createURL(cfg *config.Config) string {
-	return fmt.Sprintf("postgresql://%s:%s@%s:%s/%s?sslmode=%s",
+	return fmt.Sprintf("postgresql://%s:%s@%s:%d/%s?sslmode=%s",
 		cfg.DatabaseUser,
 		cfg.DatabasePass,
 		cfg.DatabaseHost,
@@ -94,9 +161,32 @@ func createURL(cfg *config.Config) string {
 	)
 }
 
-// migrateSchema migra el esquema de la base de datos
+// SchemaVersion es la versión del esquema que espera esta versión de la aplicación. Se debe
+// incrementar al cambiar los modelos migrados.
+const SchemaVersion = 1
+
+// migrateSchema migra el esquema de la base de datos y registra la versión aplicada
 func migrateSchema(db *gorm.DB) error {
-	return db.AutoMigrate(
+	if err := db.AutoMigrate(
 		&models.Stock{},
-	)
+		&models.StockPrice{},
+		&models.RatingEvent{},
+		&models.TaxonomyLabel{},
+		&models.TaxonomyAlias{},
+		&models.UnmappedLabel{},
+		&models.RecommendationSnapshot{},
+		&models.AlertRule{},
+		&models.AlertEvent{},
+		&models.Webhook{},
+		&models.WebhookDelivery{},
+		&models.DigestRun{},
+		&models.APIKey{},
+		&models.SyncRun{},
+		&models.SchemaMigration{},
+	); err != nil {
+		return err
+	}
+
+	return db.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.SchemaMigration{Version: SchemaVersion, AppliedAt: time.Now()}).Error
 }
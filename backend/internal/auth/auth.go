@@ -0,0 +1,310 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"github.com/liferip/stock-analyzer/backend/config"
+	"github.com/liferip/stock-analyzer/backend/internal/repository"
+)
+
+// Module proporciona las dependencias de autenticación
+var Module = fx.Provide(NewAuthenticator)
+
+func init() {
+	config.RegisterValidator(validateConfig)
+}
+
+// validateConfig comprueba los roles de AUTH_API_KEYS y AUTH_ANONYMOUS_ROLE al cargar la
+// configuración; config ya comprueba el formato role:key
+func validateConfig(cfg *config.Config, report func(setting string, err error)) {
+	for _, entry := range cfg.AuthAPIKeys {
+		if role, _, ok := strings.Cut(entry, ":"); ok && role != "" {
+			if _, err := ParseRole(role); err != nil {
+				report("AUTH_API_KEYS", err)
+			}
+		}
+	}
+	if cfg.AuthAnonymousRole != "" {
+		if _, err := ParseRole(cfg.AuthAnonymousRole); err != nil {
+			report("AUTH_ANONYMOUS_ROLE", err)
+		}
+	}
+}
+
+// Scope define un permiso otorgado a un cliente
+type Scope string
+
+const (
+	// ScopeReadStocks permite consultar datos
+	ScopeReadStocks Scope = "read:stocks"
+	// ScopeWriteSync permite sincronizar los stocks desde la API externa
+	ScopeWriteSync Scope = "write:sync"
+	// ScopeReadMetrics permite leer las métricas de /metrics
+	ScopeReadMetrics Scope = "read:metrics"
+	// ScopeAdmin permite todas las operaciones, incluidas las de escritura y administración
+	ScopeAdmin Scope = "admin"
+)
+
+// Scopes contiene todos los scopes válidos
+var Scopes = []Scope{ScopeReadStocks, ScopeWriteSync, ScopeReadMetrics, ScopeAdmin}
+
+// ParseScope valida un scope
+func ParseScope(value string) (Scope, error) {
+	for _, scope := range Scopes {
+		if Scope(value) == scope {
+			return scope, nil
+		}
+	}
+	return "", fmt.Errorf("unknown scope %q, expected 'read:stocks', 'write:sync', 'read:metrics' or 'admin'", value)
+}
+
+// Role define un conjunto de scopes usado por las API keys configuradas y los tokens JWT
+type Role string
+
+const (
+	// RoleViewer permite consultar datos
+	RoleViewer Role = "viewer"
+	// RoleAdmin permite además sincronizar y modificar datos
+	RoleAdmin Role = "admin"
+)
+
+// roleScopes contiene los scopes de cada rol
+var roleScopes = map[Role][]Scope{
+	RoleViewer: {ScopeReadStocks},
+	RoleAdmin:  {ScopeAdmin},
+}
+
+// roleRanks ordena los roles de menor a mayor acceso
+var roleRanks = map[Role]int{
+	RoleViewer: 1,
+	RoleAdmin:  2,
+}
+
+// ParseRole valida un rol
+func ParseRole(value string) (Role, error) {
+	role := Role(strings.ToLower(strings.TrimSpace(value)))
+	if _, ok := roleRanks[role]; !ok {
+		return "", fmt.Errorf("unknown role %q, expected 'viewer' or 'admin'", value)
+	}
+	return role, nil
+}
+
+// Includes indica si el rol tiene al menos el acceso del otro rol
+func (r Role) Includes(other Role) bool {
+	return roleRanks[r] >= roleRanks[other]
+}
+
+// Métodos de autenticación
+const (
+	MethodAnonymous = "anonymous"
+	MethodAPIKey    = "api_key"
+	MethodJWT       = "jwt"
+)
+
+// Principal representa al cliente autenticado de una solicitud
+type Principal struct {
+	Subject string  `json:"subject"`
+	Method  string  `json:"method"`
+	Scopes  []Scope `json:"scopes"`
+}
+
+// Allows indica si el cliente tiene el scope requerido; el scope admin permite todo
+func (p *Principal) Allows(required Scope) bool {
+	for _, scope := range p.Scopes {
+		if scope == required || scope == ScopeAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+// ErrUnauthenticated indica que la solicitud no tiene credenciales válidas
+var ErrUnauthenticated = errors.New("unauthenticated")
+
+type principalKey struct{}
+
+// WithPrincipal devuelve un contexto con el cliente autenticado
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext obtiene el cliente autenticado del contexto, o nil si no hay
+func PrincipalFromContext(ctx context.Context) *Principal {
+	principal, _ := ctx.Value(principalKey{}).(*Principal)
+	return principal
+}
+
+const (
+	// apiKeyPrefix identifica las API keys emitidas por la aplicación
+	apiKeyPrefix = "sa_"
+	// apiKeyBytes es la cantidad de bytes aleatorios de una API key
+	apiKeyBytes = 32
+	// apiKeyDisplayLength es la cantidad de caracteres de la key que se guardan para identificarla
+	apiKeyDisplayLength = 11
+	// lastUsedResolution evita escribir la fecha de último uso en cada solicitud
+	lastUsedResolution = time.Minute
+)
+
+// GenerateAPIKey crea una API key aleatoria y devuelve su valor, el prefijo para identificarla y su hash
+func GenerateAPIKey() (key, prefix, hash string, err error) {
+	buf := make([]byte, apiKeyBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", "", fmt.Errorf("generating API key: %w", err)
+	}
+
+	key = apiKeyPrefix + base64.RawURLEncoding.EncodeToString(buf)
+	return key, key[:apiKeyDisplayLength], HashAPIKey(key), nil
+}
+
+// HashAPIKey calcula el hash con el que se guarda una API key. Las keys tienen suficiente
+// entropía para que SHA-256 alcance y se puedan buscar por su hash.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// staticKey es una API key configurada por variable de entorno; se guarda solo su hash
+type staticKey struct {
+	hash [sha256.Size]byte
+	role Role
+}
+
+// Authenticator valida API keys y tokens JWT
+type Authenticator struct {
+	keys          repository.APIKeyRepository
+	staticKeys    []staticKey
+	anonymousRole Role
+	jwt           *jwtVerifier
+	logger        *zap.Logger
+}
+
+// NewAuthenticator crea una nueva instancia de Authenticator a partir de la configuración
+func NewAuthenticator(
+	cfg *config.Config,
+	keys repository.APIKeyRepository,
+	logger *zap.Logger,
+) (*Authenticator, error) {
+	a := &Authenticator{
+		keys:   keys,
+		logger: logger.Named("auth"),
+	}
+
+	for _, entry := range cfg.AuthAPIKeys {
+		role, key, ok := strings.Cut(entry, ":")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid AUTH_API_KEYS entry, expected 'role:key'")
+		}
+		parsed, err := ParseRole(role)
+		if err != nil {
+			return nil, fmt.Errorf("invalid AUTH_API_KEYS entry: %w", err)
+		}
+		a.staticKeys = append(a.staticKeys, staticKey{hash: sha256.Sum256([]byte(key)), role: parsed})
+	}
+
+	if cfg.AuthAnonymousRole != "" {
+		role, err := ParseRole(cfg.AuthAnonymousRole)
+		if err != nil {
+			return nil, fmt.Errorf("invalid AUTH_ANONYMOUS_ROLE: %w", err)
+		}
+		a.anonymousRole = role
+	}
+
+	verifier, err := newJWTVerifier(cfg)
+	if err != nil {
+		return nil, err
+	}
+	a.jwt = verifier
+
+	return a, nil
+}
+
+// Anonymous devuelve el cliente usado para las solicitudes sin credenciales, o nil si se rechazan
+func (a *Authenticator) Anonymous() *Principal {
+	if a.anonymousRole == "" {
+		return nil
+	}
+	return &Principal{Subject: MethodAnonymous, Method: MethodAnonymous, Scopes: roleScopes[a.anonymousRole]}
+}
+
+// Authenticate valida una credencial, que puede ser un token JWT o una API key
+func (a *Authenticator) Authenticate(ctx context.Context, credential string) (*Principal, error) {
+	if credential == "" {
+		return nil, ErrUnauthenticated
+	}
+
+	if isJWT(credential) {
+		if a.jwt == nil {
+			return nil, fmt.Errorf("%w: JWT authentication is not configured", ErrUnauthenticated)
+		}
+		return a.jwt.verify(credential)
+	}
+
+	if principal := a.authenticateStaticKey(credential); principal != nil {
+		return principal, nil
+	}
+	if strings.HasPrefix(credential, apiKeyPrefix) {
+		return a.authenticateStoredKey(ctx, credential)
+	}
+	return nil, fmt.Errorf("%w: invalid API key", ErrUnauthenticated)
+}
+
+// authenticateStoredKey busca una API key emitida por la aplicación y registra su uso
+func (a *Authenticator) authenticateStoredKey(ctx context.Context, credential string) (*Principal, error) {
+	key, err := a.keys.GetByHash(ctx, HashAPIKey(credential))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	if key == nil || !key.Active(now) {
+		return nil, fmt.Errorf("%w: invalid, revoked or expired API key", ErrUnauthenticated)
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= lastUsedResolution {
+		// Un error al registrar el uso no debe rechazar la solicitud
+		if err := a.keys.TouchLastUsed(ctx, key.ID, now); err != nil {
+			a.logger.Warn("Error recording API key use", zap.String("id", key.ID), zap.Error(err))
+		}
+	}
+
+	scopes := make([]Scope, 0, len(key.Scopes))
+	for _, scope := range key.Scopes {
+		scopes = append(scopes, Scope(scope))
+	}
+
+	return &Principal{Subject: "key:" + key.ID, Method: MethodAPIKey, Scopes: scopes}, nil
+}
+
+// authenticateStaticKey busca la API key entre las configuradas, comparando en tiempo constante.
+// Devuelve nil si no es una de ellas.
+func (a *Authenticator) authenticateStaticKey(key string) *Principal {
+	hash := sha256.Sum256([]byte(key))
+	for _, static := range a.staticKeys {
+		if subtle.ConstantTimeCompare(hash[:], static.hash[:]) == 1 {
+			return &Principal{
+				Subject: fmt.Sprintf("static:%x", hash[:4]),
+				Method:  MethodAPIKey,
+				Scopes:  roleScopes[static.role],
+			}
+		}
+	}
+
+	return nil
+}
+
+// isJWT indica si una credencial tiene la forma de un token JWT (tres segmentos separados por puntos)
+func isJWT(credential string) bool {
+	return strings.Count(credential, ".") == 2
+}
@@ -0,0 +1,128 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/liferip/stock-analyzer/backend/config"
+)
+
+// jwtVerifier valida tokens JWT firmados con HS256 o RS256 usando claves configuradas localmente
+type jwtVerifier struct {
+	hmacSecret []byte
+	publicKey  *rsa.PublicKey
+	roleClaim  string
+	parser     *jwt.Parser
+}
+
+// newJWTVerifier crea el verificador de tokens, o nil si no hay claves configuradas
+func newJWTVerifier(cfg *config.Config) (*jwtVerifier, error) {
+	v := &jwtVerifier{roleClaim: cfg.JWTRoleClaim}
+	var methods []string
+
+	if cfg.JWTHS256Secret != "" {
+		v.hmacSecret = []byte(cfg.JWTHS256Secret)
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+
+	if cfg.JWTRS256PublicKeyFile != "" {
+		data, err := os.ReadFile(cfg.JWTRS256PublicKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading JWT_RS256_PUBLIC_KEY_FILE: %w", err)
+		}
+		key, err := jwt.ParseRSAPublicKeyFromPEM(data)
+		if err != nil {
+			return nil, fmt.Errorf("parsing JWT_RS256_PUBLIC_KEY_FILE: %w", err)
+		}
+		v.publicKey = key
+		methods = append(methods, jwt.SigningMethodRS256.Alg())
+	}
+
+	if len(methods) == 0 {
+		return nil, nil
+	}
+
+	options := []jwt.ParserOption{
+		// Solo aceptar los algoritmos configurados, para evitar la confusión entre HS256 y RS256
+		jwt.WithValidMethods(methods),
+		jwt.WithExpirationRequired(),
+	}
+	if cfg.JWTIssuer != "" {
+		options = append(options, jwt.WithIssuer(cfg.JWTIssuer))
+	}
+	if cfg.JWTAudience != "" {
+		options = append(options, jwt.WithAudience(cfg.JWTAudience))
+	}
+	v.parser = jwt.NewParser(options...)
+
+	return v, nil
+}
+
+// verify valida la firma y los claims de un token y obtiene el cliente que representa
+func (v *jwtVerifier) verify(tokenString string) (*Principal, error) {
+	claims := jwt.MapClaims{}
+	_, err := v.parser.ParseWithClaims(tokenString, claims, v.key)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid token: %v", ErrUnauthenticated, err)
+	}
+
+	subject, _ := claims.GetSubject()
+	if subject == "" {
+		return nil, fmt.Errorf("%w: token has no subject", ErrUnauthenticated)
+	}
+
+	role, err := v.role(claims)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnauthenticated, err)
+	}
+
+	return &Principal{Subject: subject, Method: MethodJWT, Scopes: roleScopes[role]}, nil
+}
+
+// key devuelve la clave de verificación según el algoritmo del token
+func (v *jwtVerifier) key(token *jwt.Token) (interface{}, error) {
+	switch token.Method.Alg() {
+	case jwt.SigningMethodHS256.Alg():
+		return v.hmacSecret, nil
+	case jwt.SigningMethodRS256.Alg():
+		return v.publicKey, nil
+	default:
+		return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+	}
+}
+
+// role obtiene el rol de mayor acceso del claim configurado, que puede ser un texto o una lista
+func (v *jwtVerifier) role(claims jwt.MapClaims) (Role, error) {
+	var values []string
+	switch claim := claims[v.roleClaim].(type) {
+	case string:
+		values = strings.Fields(strings.ReplaceAll(claim, ",", " "))
+	case []interface{}:
+		for _, value := range claim {
+			if s, ok := value.(string); ok {
+				values = append(values, s)
+			}
+		}
+	}
+
+	var best Role
+	for _, value := range values {
+		role, err := ParseRole(value)
+		if err != nil {
+			// Ignorar roles de otras aplicaciones
+			continue
+		}
+		if role.Includes(best) {
+			best = role
+		}
+	}
+
+	if best == "" {
+		return "", fmt.Errorf("token has no known role in claim %q", v.roleClaim)
+	}
+	return best, nil
+}
@@ -0,0 +1,122 @@
+// Package cache guarda resultados calculados a partir de la base de datos hasta que los datos cambian.
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"github.com/liferip/stock-analyzer/backend/config"
+)
+
+// Module proporciona las dependencias de la caché
+var Module = fx.Provide(NewStore, New)
+
+// Backends de caché disponibles
+const (
+	BackendMemory = "memory"
+	BackendNone   = "none"
+)
+
+// Store es el almacenamiento de la caché. Los valores se guardan serializados para que
+// puedan vivir fuera del proceso, por ejemplo en Redis.
+type Store interface {
+	// Get obtiene un valor, indicando si existe y no venció
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set guarda un valor durante ttl
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Clear elimina todos los valores
+	Clear(ctx context.Context) error
+}
+
+// NewStore crea el almacenamiento configurado en CACHE_BACKEND
+func NewStore(cfg *config.Config) (Store, error) {
+	switch cfg.CacheBackend {
+	case BackendMemory, "":
+		return NewMemoryStore(cfg.CacheMaxEntries), nil
+	case BackendNone:
+		return noopStore{}, nil
+	default:
+		return nil, fmt.Errorf("unknown CACHE_BACKEND %q, expected 'memory' or 'none'", cfg.CacheBackend)
+	}
+}
+
+// Cache guarda resultados en un Store y los invalida cuando cambian los datos de origen
+type Cache struct {
+	store  Store
+	ttl    time.Duration
+	logger *zap.Logger
+
+	// generation forma parte de cada clave. Al invalidar se incrementa, así un resultado
+	// calculado con los datos anteriores nunca se lee aunque se guarde después.
+	generation atomic.Uint64
+}
+
+// New crea una nueva instancia de Cache
+func New(store Store, cfg *config.Config, logger *zap.Logger) *Cache {
+	return &Cache{
+		store:  store,
+		ttl:    cfg.CacheTTL,
+		logger: logger.Named("cache"),
+	}
+}
+
+// Invalidate descarta todos los resultados guardados
+func (c *Cache) Invalidate(ctx context.Context) {
+	if c == nil {
+		return
+	}
+
+	c.generation.Add(1)
+	if err := c.store.Clear(ctx); err != nil {
+		c.logger.Warn("Error clearing cache", zap.Error(err))
+	}
+}
+
+// Load obtiene un resultado de la caché o lo calcula con load y lo guarda. Un error de la
+// caché no impide responder: se registra y se calcula el resultado.
+func Load[T any](ctx context.Context, c *Cache, key string, load func() (T, error)) (T, error) {
+	if c == nil {
+		return load()
+	}
+
+	key = fmt.Sprintf("%d:%s", c.generation.Load(), key)
+
+	if data, ok, err := c.store.Get(ctx, key); err != nil {
+		c.logger.Warn("Error reading cache", zap.String("key", key), zap.Error(err))
+	} else if ok {
+		var value T
+		if err := json.Unmarshal(data, &value); err == nil {
+			return value, nil
+		}
+		c.logger.Warn("Error decoding cached value", zap.String("key", key), zap.Error(err))
+	}
+
+	value, err := load()
+	if err != nil {
+		return value, err
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		c.logger.Warn("Error encoding cached value", zap.String("key", key), zap.Error(err))
+		return value, nil
+	}
+	if err := c.store.Set(ctx, key, data, c.ttl); err != nil {
+		c.logger.Warn("Error writing cache", zap.String("key", key), zap.Error(err))
+	}
+
+	return value, nil
+}
+
+// noopStore es un Store que no guarda nada, para desactivar la caché
+type noopStore struct{}
+
+func (noopStore) Get(context.Context, string) ([]byte, bool, error)        { return nil, false, nil }
+func (noopStore) Set(context.Context, string, []byte, time.Duration) error { return nil }
+func (noopStore) Clear(context.Context) error                              { return nil }
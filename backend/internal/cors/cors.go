@@ -0,0 +1,259 @@
+// Package cors decide qué orígenes, métodos y headers acepta la API en solicitudes de otros sitios.
+package cors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/fx"
+
+	"github.com/liferip/stock-analyzer/backend/config"
+)
+
+// Module proporciona las dependencias de la política CORS
+var Module = fx.Provide(NewPolicy)
+
+func init() {
+	config.RegisterValidator(validateConfig)
+}
+
+// validateConfig comprueba los orígenes de CORS_ALLOWED_ORIGINS y su combinación con
+// CORS_ALLOW_CREDENTIALS al cargar la configuración
+func validateConfig(cfg *config.Config, report func(setting string, err error)) {
+	for _, value := range cfg.CORSAllowedOrigins {
+		if value == Any {
+			if cfg.CORSAllowCredentials {
+				report("CORS_ALLOWED_ORIGINS", errAnyOriginWithCredentials)
+			}
+			continue
+		}
+		if _, err := ParseOrigin(value); err != nil {
+			report("CORS_ALLOWED_ORIGINS", err)
+		}
+	}
+	for _, header := range cfg.CORSAllowedHeaders {
+		if header == Any && cfg.CORSAllowCredentials {
+			report("CORS_ALLOWED_HEADERS", errAnyHeaderWithCredentials)
+		}
+	}
+}
+
+// Any permite cualquier origen o header
+const Any = "*"
+
+// Errores de las combinaciones de * con credenciales, que NewPolicy y validateConfig rechazan
+var (
+	errAnyOriginWithCredentials = errors.New("cannot include '*' when CORS_ALLOW_CREDENTIALS is true; list the origins instead")
+	errAnyHeaderWithCredentials = errors.New("cannot include '*' when CORS_ALLOW_CREDENTIALS is true; list the headers instead")
+)
+
+// Origin es un origen permitido: exacto (https://app.example.com) o con un comodín de
+// subdominio (https://*.example.com), que acepta cualquier subdominio pero no el dominio
+type Origin struct {
+	Scheme string
+	// Host incluye el puerto, si se indica
+	Host string
+	// Wildcard indica que Host es un sufijo de dominio
+	Wildcard bool
+}
+
+// ParseOrigin interpreta un origen con el formato scheme://host[:puerto] o scheme://*.dominio[:puerto]
+func ParseOrigin(value string) (Origin, error) {
+	u, err := url.Parse(strings.TrimSuffix(strings.TrimSpace(value), "/"))
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return Origin{}, fmt.Errorf("invalid origin %q, expected 'scheme://host[:port]' or 'scheme://*.domain'", value)
+	}
+	if u.Path != "" || u.RawQuery != "" || u.Fragment != "" || u.User != nil {
+		return Origin{}, fmt.Errorf("invalid origin %q: an origin has no path, query or credentials", value)
+	}
+
+	origin := Origin{Scheme: u.Scheme, Host: strings.ToLower(u.Host)}
+	if strings.HasPrefix(origin.Host, "*.") {
+		origin.Host = strings.TrimPrefix(origin.Host, "*")
+		origin.Wildcard = true
+	}
+	if strings.Contains(origin.Host, "*") || origin.Host == "." {
+		return Origin{}, fmt.Errorf("invalid origin %q: '*' is only allowed as the first label, as in https://*.example.com", value)
+	}
+
+	return origin, nil
+}
+
+// matches indica si un origen de una solicitud, ya interpretado, coincide con el permitido
+func (o Origin) matches(scheme, host string) bool {
+	if scheme != o.Scheme {
+		return false
+	}
+	if !o.Wildcard {
+		return host == o.Host
+	}
+	// El subdominio no puede estar vacío: https://*.example.com no acepta https://example.com
+	return len(host) > len(o.Host) && strings.HasSuffix(host, o.Host)
+}
+
+// Policy es la política CORS de la API
+type Policy struct {
+	anyOrigin   bool
+	origins     []Origin
+	methods     []string
+	anyHeader   bool
+	headers     map[string]bool
+	allowed     string
+	exposed     string
+	credentials bool
+	maxAge      time.Duration
+}
+
+// NewPolicy crea la política CORS configurada
+func NewPolicy(cfg *config.Config) (*Policy, error) {
+	p := &Policy{
+		headers:     make(map[string]bool),
+		credentials: cfg.CORSAllowCredentials,
+		maxAge:      cfg.CORSMaxAge,
+	}
+
+	for _, value := range cfg.CORSAllowedOrigins {
+		if value == Any {
+			p.anyOrigin = true
+			continue
+		}
+		origin, err := ParseOrigin(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CORS_ALLOWED_ORIGINS entry: %w", err)
+		}
+		p.origins = append(p.origins, origin)
+	}
+	// Los navegadores rechazan las respuestas con credenciales y Access-Control-Allow-Origin: *
+	if p.anyOrigin && p.credentials {
+		return nil, fmt.Errorf("CORS_ALLOWED_ORIGINS %w", errAnyOriginWithCredentials)
+	}
+
+	for _, method := range cfg.CORSAllowedMethods {
+		p.methods = append(p.methods, strings.ToUpper(method))
+	}
+
+	headers := make([]string, 0, len(cfg.CORSAllowedHeaders))
+	for _, header := range cfg.CORSAllowedHeaders {
+		if header == Any {
+			if p.credentials {
+				return nil, fmt.Errorf("CORS_ALLOWED_HEADERS %w", errAnyHeaderWithCredentials)
+			}
+			p.anyHeader = true
+			continue
+		}
+		p.headers[http.CanonicalHeaderKey(header)] = true
+		headers = append(headers, header)
+	}
+	p.allowed = strings.Join(headers, ", ")
+
+	p.exposed = strings.Join(cfg.CORSExposedHeaders, ", ")
+
+	return p, nil
+}
+
+// Enabled indica si se acepta algún origen
+func (p *Policy) Enabled() bool {
+	return p.anyOrigin || len(p.origins) > 0
+}
+
+// AllowOrigin indica si se acepta un origen, como el del header Origin de una solicitud
+func (p *Policy) AllowOrigin(origin string) bool {
+	if origin == "" {
+		return false
+	}
+	if p.anyOrigin {
+		return true
+	}
+
+	u, err := url.Parse(origin)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	scheme, host := strings.ToLower(u.Scheme), strings.ToLower(u.Host)
+	for _, allowed := range p.origins {
+		if allowed.matches(scheme, host) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowMethod indica si se acepta un método en solicitudes de otros orígenes
+func (p *Policy) AllowMethod(method string) bool {
+	for _, allowed := range p.methods {
+		if allowed == method {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowHeaders indica si se aceptan los headers de Access-Control-Request-Headers
+func (p *Policy) AllowHeaders(requested string) bool {
+	if p.anyHeader {
+		return true
+	}
+	for _, header := range strings.Split(requested, ",") {
+		header = strings.TrimSpace(header)
+		if header != "" && !p.headers[http.CanonicalHeaderKey(header)] {
+			return false
+		}
+	}
+	return true
+}
+
+// varyOrigin indica si la respuesta depende del origen de la solicitud; no depende si se
+// acepta cualquier origen sin credenciales, porque siempre se responde *
+func (p *Policy) varyOrigin() bool {
+	return !p.anyOrigin || p.credentials
+}
+
+// SetHeaders agrega los headers CORS de una respuesta a una solicitud de un origen aceptado
+func (p *Policy) SetHeaders(h http.Header, origin string) {
+	if p.varyOrigin() {
+		h.Add("Vary", "Origin")
+	}
+	if !p.AllowOrigin(origin) {
+		return
+	}
+
+	p.setOrigin(h, origin)
+	if p.exposed != "" {
+		h.Set("Access-Control-Expose-Headers", p.exposed)
+	}
+}
+
+// SetPreflightHeaders agrega los headers de la respuesta a una solicitud preflight aceptada
+func (p *Policy) SetPreflightHeaders(h http.Header, origin, requestedHeaders string) {
+	p.setOrigin(h, origin)
+	h.Set("Access-Control-Allow-Methods", strings.Join(p.methods, ", "))
+
+	switch {
+	case p.anyHeader && requestedHeaders != "":
+		// Se devuelven los headers pedidos, ya que * no se acepta en todos los navegadores
+		h.Set("Access-Control-Allow-Headers", requestedHeaders)
+	case p.allowed != "":
+		h.Set("Access-Control-Allow-Headers", p.allowed)
+	}
+
+	if p.maxAge > 0 {
+		h.Set("Access-Control-Max-Age", strconv.Itoa(int(p.maxAge.Seconds())))
+	}
+}
+
+// setOrigin agrega el origen aceptado y, si corresponde, el permiso para enviar credenciales
+func (p *Policy) setOrigin(h http.Header, origin string) {
+	if p.anyOrigin && !p.credentials {
+		h.Set("Access-Control-Allow-Origin", Any)
+	} else {
+		h.Set("Access-Control-Allow-Origin", origin)
+	}
+	if p.credentials {
+		h.Set("Access-Control-Allow-Credentials", "true")
+	}
+}
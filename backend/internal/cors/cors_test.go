@@ -0,0 +1,136 @@
+package cors
+
+import (
+	"testing"
+
+	"github.com/liferip/stock-analyzer/backend/config"
+)
+
+func TestParseOrigin(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    Origin
+		wantErr bool
+	}{
+		{value: "https://app.example.com", want: Origin{Scheme: "https", Host: "app.example.com"}},
+		{value: " https://App.Example.com/ ", want: Origin{Scheme: "https", Host: "app.example.com"}},
+		{value: "http://localhost:8082", want: Origin{Scheme: "http", Host: "localhost:8082"}},
+		{value: "https://*.example.com", want: Origin{Scheme: "https", Host: ".example.com", Wildcard: true}},
+		{value: "https://*.example.com:8443", want: Origin{Scheme: "https", Host: ".example.com:8443", Wildcard: true}},
+		{value: "app.example.com", wantErr: true},
+		{value: "ftp://example.com", wantErr: true},
+		{value: "https://example.com/app", wantErr: true},
+		{value: "https://example.com?a=1", wantErr: true},
+		{value: "https://user@example.com", wantErr: true},
+		{value: "https://*", wantErr: true},
+		{value: "https://*.", wantErr: true},
+		{value: "https://app.*.example.com", wantErr: true},
+		{value: "https://*.*.example.com", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseOrigin(tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseOrigin(%q) = %+v, want an error", tt.value, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseOrigin(%q) returned error: %v", tt.value, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseOrigin(%q) = %+v, want %+v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOriginMatches(t *testing.T) {
+	tests := []struct {
+		allowed string
+		origin  string
+		want    bool
+	}{
+		{"https://app.example.com", "https://app.example.com", true},
+		{"https://app.example.com", "https://APP.example.com", true},
+		{"https://app.example.com", "http://app.example.com", false},
+		{"https://app.example.com", "https://app.example.com:8443", false},
+		{"https://app.example.com", "https://other.example.com", false},
+		{"http://localhost:8082", "http://localhost:8082", true},
+		{"http://localhost:8082", "http://localhost:8083", false},
+		{"http://localhost:8082", "http://localhost", false},
+		{"https://*.example.com", "https://app.example.com", true},
+		{"https://*.example.com", "https://a.b.example.com", true},
+		{"https://*.example.com", "https://example.com", false},
+		{"https://*.example.com", "https://evilexample.com", false},
+		{"https://*.example.com", "https://app.example.com.evil.com", false},
+		{"https://*.example.com", "http://app.example.com", false},
+		{"https://*.example.com", "https://app.example.com:8443", false},
+		{"https://*.example.com:8443", "https://app.example.com:8443", true},
+		{"https://*.example.com:8443", "https://app.example.com", false},
+		{"https://*.example.com:8443", "https://app.example.com:9443", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.allowed+" "+tt.origin, func(t *testing.T) {
+			policy, err := NewPolicy(&config.Config{CORSAllowedOrigins: []string{tt.allowed}})
+			if err != nil {
+				t.Fatalf("NewPolicy returned error: %v", err)
+			}
+			if got := policy.AllowOrigin(tt.origin); got != tt.want {
+				t.Errorf("AllowOrigin(%q) with %q = %v, want %v", tt.origin, tt.allowed, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     config.Config
+		wantErr bool
+	}{
+		{
+			name: "any origin without credentials",
+			cfg:  config.Config{CORSAllowedOrigins: []string{Any}, CORSAllowedHeaders: []string{Any}},
+		},
+		{
+			name: "listed origins with credentials",
+			cfg: config.Config{
+				CORSAllowedOrigins:   []string{"https://*.example.com"},
+				CORSAllowedHeaders:   []string{"Content-Type"},
+				CORSAllowCredentials: true,
+			},
+		},
+		{
+			name:    "any origin with credentials",
+			cfg:     config.Config{CORSAllowedOrigins: []string{"https://app.example.com", Any}, CORSAllowCredentials: true},
+			wantErr: true,
+		},
+		{
+			name: "any header with credentials",
+			cfg: config.Config{
+				CORSAllowedOrigins:   []string{"https://app.example.com"},
+				CORSAllowedHeaders:   []string{Any},
+				CORSAllowCredentials: true,
+			},
+			wantErr: true,
+		},
+		{
+			name:    "invalid origin",
+			cfg:     config.Config{CORSAllowedOrigins: []string{"https://app.*.example.com"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewPolicy(&tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewPolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
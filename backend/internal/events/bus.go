@@ -0,0 +1,205 @@
+package events
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/fx"
+
+	"github.com/liferip/stock-analyzer/backend/internal/models"
+)
+
+// Module proporciona las dependencias del bus de eventos
+var Module = fx.Provide(NewBus)
+
+// Tipos de eventos publicados por la aplicación
+const (
+	// SyncCompleted se publica al terminar una sincronización exitosa
+	SyncCompleted = "sync.completed"
+	// StockSaved se publica cuando la sincronización crea o actualiza un stock
+	StockSaved = "stock.saved"
+	// AlertTriggered se publica cuando un stock cumple una regla de alerta
+	AlertTriggered = "alert.triggered"
+	// RecommendationsChanged se publica cuando una sincronización cambia la lista de recomendaciones
+	RecommendationsChanged = "recommendations.changed"
+)
+
+// Types contiene todos los tipos de eventos publicados por la aplicación
+var Types = []string{SyncCompleted, StockSaved, AlertTriggered, RecommendationsChanged}
+
+// Event representa un evento publicado en el bus
+type Event struct {
+	Type    string      `json:"type"`
+	Time    time.Time   `json:"time"`
+	Payload interface{} `json:"payload"`
+}
+
+// SyncCompletedPayload contiene el resultado de una sincronización
+type SyncCompletedPayload struct {
+	Count     int           `json:"count"`
+	StartedAt time.Time     `json:"started_at"`
+	Duration  time.Duration `json:"duration"`
+}
+
+// StockSavedPayload contiene el stock creado o actualizado por la sincronización
+type StockSavedPayload struct {
+	Stock   models.Stock `json:"stock"`
+	Created bool         `json:"created"`
+}
+
+// AlertTriggeredPayload contiene la alerta generada por una regla
+type AlertTriggeredPayload struct {
+	Alert models.AlertEvent `json:"alert"`
+}
+
+// RecommendationsChangedPayload contiene la nueva lista de recomendaciones y los tickers que entraron o salieron
+type RecommendationsChangedPayload struct {
+	Recommendations []models.StockRecommendation `json:"recommendations"`
+	Entered         []string                     `json:"entered"`
+	Left            []string                     `json:"left"`
+}
+
+// Handler procesa un evento publicado en el bus
+type Handler func(ctx context.Context, event Event)
+
+// asyncQueueSize es la cantidad de eventos que puede acumular un suscriptor asíncrono; con la
+// cola llena, Publish espera a que procese alguno, salvo que publique otro suscriptor asíncrono
+const asyncQueueSize = 256
+
+// Bus distribuye eventos a los suscriptores, de forma síncrona o en segundo plano con SubscribeAsync
+type Bus struct {
+	mu       sync.RWMutex
+	handlers []Handler
+
+	// done se cierra al detener la aplicación, para que los suscriptores asíncronos terminen
+	done     chan struct{}
+	stopOnce sync.Once
+	workers  sync.WaitGroup
+}
+
+// asyncWorkerKey marca el contexto de los eventos procesados por un suscriptor asíncrono
+type asyncWorkerKey struct{}
+
+// queuedEvent es un evento pendiente de un suscriptor asíncrono, con el contexto del publicador
+type queuedEvent struct {
+	ctx   context.Context
+	event Event
+}
+
+// NewBus crea una nueva instancia de Bus, que al detenerse espera a que los suscriptores
+// asíncronos procesen los eventos pendientes
+func NewBus(lc fx.Lifecycle) *Bus {
+	b := &Bus{done: make(chan struct{})}
+
+	lc.Append(fx.Hook{
+		OnStop: b.stop,
+	})
+
+	return b
+}
+
+// Subscribe registra un handler que recibirá todos los eventos publicados
+func (b *Bus) Subscribe(handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.handlers = append(b.handlers, handler)
+}
+
+// SubscribeAsync registra un handler que procesa los eventos en su propia goroutine, en el
+// orden en que se publicaron, para que Publish no espere consultas lentas. Cada evento se
+// procesa con los valores del contexto del publicador pero sin su cancelación, para que no
+// se pierda si termina la solicitud que lo publicó, y con un tiempo máximo de timeout.
+func (b *Bus) SubscribeAsync(timeout time.Duration, handler Handler) {
+	queue := make(chan queuedEvent, asyncQueueSize)
+
+	b.workers.Add(1)
+	go func() {
+		defer b.workers.Done()
+		for {
+			select {
+			case item := <-queue:
+				handleQueued(item, timeout, handler)
+			case <-b.done:
+				// Procesar los eventos que quedaron en la cola antes de terminar
+				for {
+					select {
+					case item := <-queue:
+						handleQueued(item, timeout, handler)
+					default:
+						return
+					}
+				}
+			}
+		}
+	}()
+
+	b.Subscribe(func(ctx context.Context, event Event) {
+		item := queuedEvent{ctx: context.WithoutCancel(ctx), event: event}
+		select {
+		case queue <- item:
+			return
+		default:
+		}
+
+		// Un suscriptor asíncrono que publica no puede esperar a que haya lugar: la cola llena
+		// puede ser la suya, que solo él vacía. El evento se encola en otra goroutine.
+		if ctx.Value(asyncWorkerKey{}) != nil {
+			go b.enqueue(queue, item)
+			return
+		}
+		b.enqueue(queue, item)
+	})
+}
+
+// enqueue espera a que haya lugar en la cola de un suscriptor asíncrono, o a que se detenga el bus
+func (b *Bus) enqueue(queue chan<- queuedEvent, item queuedEvent) {
+	select {
+	case queue <- item:
+	case <-b.done:
+	}
+}
+
+// handleQueued procesa un evento de la cola de un suscriptor asíncrono
+func handleQueued(item queuedEvent, timeout time.Duration, handler Handler) {
+	ctx, cancel := context.WithTimeout(context.WithValue(item.ctx, asyncWorkerKey{}, true), timeout)
+	defer cancel()
+
+	handler(ctx, item.event)
+}
+
+// Publish envía un evento a todos los suscriptores, en el orden en que se registraron
+func (b *Bus) Publish(ctx context.Context, eventType string, payload interface{}) {
+	b.mu.RLock()
+	handlers := make([]Handler, len(b.handlers))
+	copy(handlers, b.handlers)
+	b.mu.RUnlock()
+
+	event := Event{
+		Type:    eventType,
+		Time:    time.Now(),
+		Payload: payload,
+	}
+	for _, handler := range handlers {
+		handler(ctx, event)
+	}
+}
+
+// stop detiene los suscriptores asíncronos y espera a que vacíen sus colas
+func (b *Bus) stop(ctx context.Context) error {
+	b.stopOnce.Do(func() { close(b.done) })
+
+	finished := make(chan struct{})
+	go func() {
+		b.workers.Wait()
+		close(finished)
+	}()
+
+	select {
+	case <-finished:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
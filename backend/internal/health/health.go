@@ -0,0 +1,224 @@
+// Package health comprueba si la aplicación puede atender solicitudes: la conexión a la base de
+// datos, la versión del esquema, la antigüedad de los datos y el estado de la API externa.
+package health
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+
+	"github.com/liferip/stock-analyzer/backend/config"
+	"github.com/liferip/stock-analyzer/backend/db"
+	"github.com/liferip/stock-analyzer/backend/internal/models"
+	"github.com/liferip/stock-analyzer/backend/internal/repository"
+	"github.com/liferip/stock-analyzer/backend/pkg/httpclient"
+)
+
+// Module proporciona las dependencias de los chequeos de salud
+var Module = fx.Provide(NewChecker)
+
+// Status es el resultado de un chequeo o del informe completo
+type Status string
+
+const (
+	// StatusOK indica que todo funciona
+	StatusOK Status = "ok"
+	// StatusDegraded indica un problema que no impide atender solicitudes, como datos
+	// desactualizados o la API externa caída
+	StatusDegraded Status = "degraded"
+	// StatusUnavailable indica que la aplicación no puede atender solicitudes
+	StatusUnavailable Status = "unavailable"
+)
+
+// Report es el resultado de todos los chequeos
+type Report struct {
+	Status    Status    `json:"status"`
+	CheckedAt time.Time `json:"checked_at"`
+	Checks    Checks    `json:"checks"`
+}
+
+// Ready indica si la aplicación puede atender solicitudes. Solo depende de la base de datos:
+// los datos desactualizados o la API externa caída no se solucionan sacando la instancia de
+// servicio.
+func (r Report) Ready() bool {
+	return r.Checks.Database.Status == StatusOK && r.Checks.Migrations.Status == StatusOK
+}
+
+// Checks agrupa el resultado de cada chequeo
+type Checks struct {
+	Database   DatabaseCheck   `json:"database"`
+	Migrations MigrationsCheck `json:"migrations"`
+	Sync       SyncCheck       `json:"sync"`
+	Upstream   UpstreamCheck   `json:"upstream"`
+}
+
+// DatabaseCheck es el resultado de la conexión a la base de datos
+type DatabaseCheck struct {
+	Status    Status `json:"status"`
+	LatencyMS int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+}
+
+// MigrationsCheck compara la versión del esquema aplicada con la que espera la aplicación
+type MigrationsCheck struct {
+	Status   Status `json:"status"`
+	Version  int    `json:"version"`
+	Expected int    `json:"expected"`
+	Error    string `json:"error,omitempty"`
+}
+
+// SyncCheck indica la antigüedad de la última sincronización exitosa
+type SyncCheck struct {
+	Status            Status     `json:"status"`
+	LastSuccess       *time.Time `json:"last_success"`
+	AgeSeconds        *int64     `json:"age_seconds,omitempty"`
+	StaleAfterSeconds int64      `json:"stale_after_seconds"`
+	Stale             bool       `json:"stale"`
+	Error             string     `json:"error,omitempty"`
+}
+
+// UpstreamCheck indica el estado del circuito de la API externa
+type UpstreamCheck struct {
+	Status  Status                   `json:"status"`
+	Circuit httpclient.CircuitStatus `json:"circuit"`
+}
+
+// Checker ejecuta los chequeos de salud
+type Checker struct {
+	db          *gorm.DB
+	syncRuns    repository.SyncRunRepository
+	stockClient *httpclient.StockClient
+	dbTimeout   time.Duration
+	staleAfter  time.Duration
+	now         func() time.Time
+}
+
+// NewChecker crea una nueva instancia de Checker
+func NewChecker(
+	database *gorm.DB,
+	syncRuns repository.SyncRunRepository,
+	stockClient *httpclient.StockClient,
+	cfg *config.Config,
+) *Checker {
+	return &Checker{
+		db:          database,
+		syncRuns:    syncRuns,
+		stockClient: stockClient,
+		dbTimeout:   cfg.ReadyDBTimeout,
+		staleAfter:  cfg.SyncStaleAfter,
+		now:         time.Now,
+	}
+}
+
+// Check ejecuta todos los chequeos. Las consultas a la base de datos comparten el tiempo máximo
+// configurado en READY_DB_TIMEOUT_SECONDS.
+func (c *Checker) Check(ctx context.Context) Report {
+	ctx, cancel := context.WithTimeout(ctx, c.dbTimeout)
+	defer cancel()
+
+	report := Report{CheckedAt: c.now().UTC()}
+	report.Checks.Database = c.checkDatabase(ctx)
+	if report.Checks.Database.Status == StatusOK {
+		report.Checks.Migrations = c.checkMigrations(ctx)
+		report.Checks.Sync = c.checkSync(ctx)
+	} else {
+		report.Checks.Migrations = MigrationsCheck{Status: StatusUnavailable, Expected: db.SchemaVersion, Error: "database unavailable"}
+		report.Checks.Sync = SyncCheck{Status: StatusUnavailable, StaleAfterSeconds: int64(c.staleAfter.Seconds()), Error: "database unavailable"}
+	}
+	report.Checks.Upstream = c.checkUpstream()
+
+	switch {
+	case !report.Ready():
+		report.Status = StatusUnavailable
+	case report.Checks.Sync.Status != StatusOK || report.Checks.Upstream.Status != StatusOK:
+		report.Status = StatusDegraded
+	default:
+		report.Status = StatusOK
+	}
+
+	return report
+}
+
+// checkDatabase comprueba la conexión a la base de datos
+func (c *Checker) checkDatabase(ctx context.Context) DatabaseCheck {
+	start := c.now()
+
+	sqlDB, err := c.db.DB()
+	if err == nil {
+		err = sqlDB.PingContext(ctx)
+	}
+
+	check := DatabaseCheck{Status: StatusOK, LatencyMS: c.now().Sub(start).Milliseconds()}
+	if err != nil {
+		check.Status = StatusUnavailable
+		check.Error = err.Error()
+	}
+	return check
+}
+
+// checkMigrations obtiene la versión del esquema aplicada a la base de datos
+func (c *Checker) checkMigrations(ctx context.Context) MigrationsCheck {
+	check := MigrationsCheck{Expected: db.SchemaVersion}
+
+	var version *int
+	err := c.db.WithContext(ctx).Model(&models.SchemaMigration{}).Select("MAX(version)").Scan(&version).Error
+	if err != nil {
+		check.Status = StatusUnavailable
+		check.Error = err.Error()
+		return check
+	}
+
+	if version != nil {
+		check.Version = *version
+	}
+	check.Status = StatusOK
+	if check.Version < check.Expected {
+		check.Status = StatusUnavailable
+		check.Error = "database schema is older than the application expects"
+	}
+	return check
+}
+
+// checkSync calcula la antigüedad de la última sincronización exitosa
+func (c *Checker) checkSync(ctx context.Context) SyncCheck {
+	check := SyncCheck{StaleAfterSeconds: int64(c.staleAfter.Seconds())}
+
+	run, err := c.syncRuns.GetLastSuccessful(ctx)
+	if err != nil {
+		check.Status = StatusDegraded
+		check.Error = err.Error()
+		return check
+	}
+
+	if run == nil {
+		check.Status = StatusDegraded
+		check.Stale = true
+		check.Error = "no successful sync yet"
+		return check
+	}
+
+	lastSuccess := run.FinishedAt.UTC()
+	age := int64(c.now().Sub(lastSuccess).Seconds())
+	check.LastSuccess = &lastSuccess
+	check.AgeSeconds = &age
+	check.Stale = c.now().Sub(lastSuccess) > c.staleAfter
+
+	check.Status = StatusOK
+	if check.Stale {
+		check.Status = StatusDegraded
+	}
+	return check
+}
+
+// checkUpstream obtiene el estado del circuito de la API externa
+func (c *Checker) checkUpstream() UpstreamCheck {
+	circuit := c.stockClient.CircuitStatus()
+
+	check := UpstreamCheck{Status: StatusOK, Circuit: circuit}
+	if circuit.State != httpclient.CircuitClosed {
+		check.Status = StatusDegraded
+	}
+	return check
+}
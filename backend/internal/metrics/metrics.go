@@ -0,0 +1,184 @@
+// Package metrics expone métricas de Prometheus sobre las solicitudes HTTP, la sincronización,
+// la API externa, la base de datos y el cálculo de recomendaciones.
+package metrics
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+// Module proporciona las dependencias de las métricas
+var Module = fx.Options(
+	fx.Provide(New),
+	fx.Invoke(RegisterDBStats),
+)
+
+// namespace es el prefijo de todas las métricas de la aplicación
+const namespace = "stock_analyzer"
+
+// Resultados de una sincronización
+const (
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+)
+
+// Tipos de cálculo de recomendaciones
+const (
+	RecommendationsTop    = "top"
+	RecommendationsByTime = "by_time"
+)
+
+// Metrics agrupa las métricas de la aplicación en un registro propio. Un *Metrics nil no
+// registra nada, para poder usar los componentes sin métricas.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests        *prometheus.CounterVec
+	httpDuration        *prometheus.HistogramVec
+	syncRuns            *prometheus.CounterVec
+	syncDuration        prometheus.Histogram
+	syncItems           prometheus.Counter
+	upstreamDuration    *prometheus.HistogramVec
+	upstreamRetries     prometheus.Counter
+	recommendationsTime *prometheus.HistogramVec
+}
+
+// New crea el registro con las métricas de la aplicación, del runtime de Go y del proceso
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests handled, by method, route template and status code.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency, by method and route template.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+		syncRuns: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "sync_runs_total",
+			Help:      "Stock synchronizations from the external API, by result.",
+		}, []string{"result"}),
+		syncDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "sync_duration_seconds",
+			Help:      "Duration of stock synchronizations from the external API.",
+			Buckets:   []float64{1, 5, 10, 30, 60, 120, 300, 600},
+		}),
+		syncItems: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "sync_items_processed_total",
+			Help:      "Stock items saved by synchronizations, including failed ones.",
+		}),
+		upstreamDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "upstream_request_duration_seconds",
+			Help:      "Latency of each request to the external stock API, by status code or 'error'.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"status"}),
+		upstreamRetries: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "upstream_retries_total",
+			Help:      "Requests to the external stock API retried after an error response.",
+		}),
+		recommendationsTime: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "recommendation_computation_duration_seconds",
+			Help:      "Time spent computing recommendations on a cache miss, by kind.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"kind"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpDuration,
+		m.syncRuns,
+		m.syncDuration,
+		m.syncItems,
+		m.upstreamDuration,
+		m.upstreamRetries,
+		m.recommendationsTime,
+	)
+
+	return m
+}
+
+// RegisterDBStats agrega las estadísticas del pool de conexiones de la base de datos
+func RegisterDBStats(m *Metrics, database *gorm.DB) error {
+	sqlDB, err := database.DB()
+	if err != nil {
+		return fmt.Errorf("error getting SQL connection for metrics: %w", err)
+	}
+	return m.registry.Register(collectors.NewDBStatsCollector(sqlDB, namespace))
+}
+
+// Handler devuelve el handler que expone las métricas en el formato de Prometheus
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// ObserveHTTPRequest registra una solicitud HTTP terminada
+func (m *Metrics) ObserveHTTPRequest(method, route string, status int, duration time.Duration) {
+	if m == nil {
+		return
+	}
+	m.httpRequests.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+	m.httpDuration.WithLabelValues(method, route).Observe(duration.Seconds())
+}
+
+// ObserveSync registra una sincronización terminada y los stocks que guardó
+func (m *Metrics) ObserveSync(duration time.Duration, items int, err error) {
+	if m == nil {
+		return
+	}
+	result := ResultSuccess
+	if err != nil {
+		result = ResultFailure
+	}
+	m.syncRuns.WithLabelValues(result).Inc()
+	m.syncDuration.Observe(duration.Seconds())
+	m.syncItems.Add(float64(items))
+}
+
+// ObserveUpstreamRequest registra un intento de solicitud a la API externa. Un status cero
+// indica que no hubo respuesta.
+func (m *Metrics) ObserveUpstreamRequest(status int, duration time.Duration) {
+	if m == nil {
+		return
+	}
+	label := "error"
+	if status != 0 {
+		label = strconv.Itoa(status)
+	}
+	m.upstreamDuration.WithLabelValues(label).Observe(duration.Seconds())
+}
+
+// IncUpstreamRetries registra un reintento de solicitud a la API externa
+func (m *Metrics) IncUpstreamRetries() {
+	if m == nil {
+		return
+	}
+	m.upstreamRetries.Inc()
+}
+
+// ObserveRecommendations registra el tiempo de un cálculo de recomendaciones
+func (m *Metrics) ObserveRecommendations(kind string, duration time.Duration) {
+	if m == nil {
+		return
+	}
+	m.recommendationsTime.WithLabelValues(kind).Observe(duration.Seconds())
+}
@@ -0,0 +1,79 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AlertRule representa una regla de alerta sobre cambios de calificación y precio objetivo.
+// Todas las condiciones indicadas deben cumplirse; las listas vacías aceptan cualquier valor.
+type AlertRule struct {
+	ID                 string    `json:"id" gorm:"primaryKey;type:uuid"`
+	Name               string    `json:"name" gorm:"not null"`
+	Tickers            []string  `json:"tickers" gorm:"serializer:json;type:text"`
+	Brokerages         []string  `json:"brokerages" gorm:"serializer:json;type:text"`
+	Actions            []string  `json:"actions" gorm:"serializer:json;type:text"`
+	RatingTo           []string  `json:"rating_to" gorm:"serializer:json;type:text"`
+	MinTargetChangePct *float64  `json:"min_target_change_pct"`
+	MaxTargetChangePct *float64  `json:"max_target_change_pct"`
+	Enabled            bool      `json:"enabled" gorm:"not null"`
+	CreatedAt          time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt          time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// Hook BeforeCreate se ejecuta antes de crear un registro
+func (r *AlertRule) BeforeCreate(tx *gorm.DB) (err error) {
+	if r.ID == "" {
+		r.ID = uuid.New().String()
+	}
+	return
+}
+
+// AlertRuleInput contiene los datos para crear o actualizar una regla de alerta
+type AlertRuleInput struct {
+	Name               string   `json:"name"`
+	Tickers            []string `json:"tickers"`
+	Brokerages         []string `json:"brokerages"`
+	Actions            []string `json:"actions"`
+	RatingTo           []string `json:"rating_to"`
+	MinTargetChangePct *float64 `json:"min_target_change_pct"`
+	MaxTargetChangePct *float64 `json:"max_target_change_pct"`
+	Enabled            *bool    `json:"enabled"`
+}
+
+// AlertEvent representa una alerta generada cuando un stock cumple una regla
+type AlertEvent struct {
+	ID         string    `json:"id" gorm:"primaryKey;type:uuid"`
+	RuleID     string    `json:"rule_id" gorm:"type:uuid;index;not null"`
+	RuleName   string    `json:"rule_name" gorm:"not null"`
+	StockID    string    `json:"stock_id" gorm:"type:uuid;not null"`
+	Ticker     string    `json:"ticker" gorm:"index;not null"`
+	Company    string    `json:"company"`
+	Brokerage  string    `json:"brokerage"`
+	Action     string    `json:"action"`
+	RatingFrom string    `json:"rating_from"`
+	RatingTo   string    `json:"rating_to"`
+	TargetFrom string    `json:"target_from"`
+	TargetTo   string    `json:"target_to"`
+	Time       time.Time `json:"time"`
+	Matched    []string  `json:"matched" gorm:"serializer:json;type:text"`
+	CreatedAt  time.Time `json:"created_at" gorm:"autoCreateTime;index"`
+}
+
+// Hook BeforeCreate se ejecuta antes de crear un registro
+func (e *AlertEvent) BeforeCreate(tx *gorm.DB) (err error) {
+	if e.ID == "" {
+		e.ID = uuid.New().String()
+	}
+	return
+}
+
+// AlertEventFilter contiene los filtros para consultar alertas
+type AlertEventFilter struct {
+	RuleID string
+	Ticker string
+	Since  time.Time
+	Limit  int
+}
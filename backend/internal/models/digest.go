@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DigestRun representa un envío del resumen diario por correo
+type DigestRun struct {
+	ID     string    `json:"id" gorm:"primaryKey;type:uuid"`
+	SentAt time.Time `json:"sent_at" gorm:"index;not null"`
+	// Day es el día (UTC) del resumen diario automático, único para que se envíe una sola vez
+	// por día; es nulo en los envíos forzados
+	Day             *time.Time `json:"-" gorm:"uniqueIndex"`
+	Since           time.Time  `json:"since" gorm:"not null"`
+	Recipients      []string   `json:"recipients" gorm:"serializer:json;type:text"`
+	Recommendations int        `json:"recommendations"`
+	RatingChanges   int        `json:"rating_changes"`
+}
+
+// Hook BeforeCreate se ejecuta antes de crear un registro
+func (r *DigestRun) BeforeCreate(tx *gorm.DB) (err error) {
+	if r.ID == "" {
+		r.ID = uuid.New().String()
+	}
+	return
+}
+
+// DigestTargetChange representa un cambio de precio objetivo incluido en el resumen
+type DigestTargetChange struct {
+	Event     RatingEvent `json:"event"`
+	ChangePct float64     `json:"change_pct"`
+}
+
+// Digest contiene el resumen de recomendaciones y cambios de calificación desde el envío anterior
+type Digest struct {
+	GeneratedAt     time.Time             `json:"generated_at"`
+	Since           time.Time             `json:"since"`
+	Recommendations []StockRecommendation `json:"recommendations"`
+	Upgrades        []RatingEvent         `json:"upgrades"`
+	Downgrades      []RatingEvent         `json:"downgrades"`
+	TargetChanges   []DigestTargetChange  `json:"target_changes"`
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RecommendationSnapshot representa una recomendación guardada en la foto diaria
+type RecommendationSnapshot struct {
+	ID             string              `json:"id" gorm:"primaryKey;type:uuid"`
+	Date           time.Time           `json:"date" gorm:"uniqueIndex:idx_recommendation_snapshots_date_rank;type:date;not null"`
+	Rank           int                 `json:"rank" gorm:"uniqueIndex:idx_recommendation_snapshots_date_rank;not null"`
+	Ticker         string              `json:"ticker" gorm:"index;not null"`
+	Score          float64             `json:"score" gorm:"not null"`
+	Strategy       string              `json:"strategy" gorm:"not null"`
+	ScoringVersion string              `json:"scoring_version" gorm:"not null"`
+	Recommendation StockRecommendation `json:"recommendation" gorm:"serializer:json;type:text;not null"`
+	CreatedAt      time.Time           `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// Hook BeforeCreate se ejecuta antes de crear un registro
+func (s *RecommendationSnapshot) BeforeCreate(tx *gorm.DB) (err error) {
+	if s.ID == "" {
+		s.ID = uuid.New().String()
+	}
+	return
+}
+
+// SnapshotDiff representa las diferencias entre las fotos de dos fechas
+type SnapshotDiff struct {
+	From    string                   `json:"from"`
+	To      string                   `json:"to"`
+	Entered []RecommendationSnapshot `json:"entered"`
+	Left    []RecommendationSnapshot `json:"left"`
+}
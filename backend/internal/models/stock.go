@@ -31,6 +31,47 @@ func (s *Stock) BeforeCreate(tx *gorm.DB) (err error) {
 	return
 }
 
+// RatingEvent representa un cambio de calificación recibido de la API externa.
+// A diferencia de Stock, que guarda solo el último registro por ticker, se
+// conserva cada evento para poder reproducir el historial.
+type RatingEvent struct {
+	ID         string    `json:"id" gorm:"primaryKey;type:uuid"`
+	Ticker     string    `json:"ticker" gorm:"uniqueIndex:idx_rating_events_key;not null"`
+	Company    string    `json:"company" gorm:"not null"`
+	Brokerage  string    `json:"brokerage" gorm:"uniqueIndex:idx_rating_events_key;not null"`
+	Action     string    `json:"action" gorm:"uniqueIndex:idx_rating_events_key;not null"`
+	RatingFrom string    `json:"rating_from" gorm:"not null"`
+	RatingTo   string    `json:"rating_to" gorm:"not null"`
+	TargetFrom string    `json:"target_from"`
+	TargetTo   string    `json:"target_to"`
+	Time       time.Time `json:"time" gorm:"uniqueIndex:idx_rating_events_key;index;not null"`
+	CreatedAt  time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// Hook BeforeCreate se ejecuta antes de crear un registro
+func (e *RatingEvent) BeforeCreate(tx *gorm.DB) (err error) {
+	if e.ID == "" {
+		e.ID = uuid.New().String()
+	}
+	return
+}
+
+// ToStock convierte el evento en un Stock con los mismos datos. El ID queda vacío: el del
+// evento no identifica ningún registro de stocks y podría coincidir con el de uno.
+func (e *RatingEvent) ToStock() Stock {
+	return Stock{
+		Ticker:     e.Ticker,
+		Company:    e.Company,
+		Brokerage:  e.Brokerage,
+		Action:     e.Action,
+		RatingFrom: e.RatingFrom,
+		RatingTo:   e.RatingTo,
+		TargetFrom: e.TargetFrom,
+		TargetTo:   e.TargetTo,
+		Time:       e.Time,
+	}
+}
+
 // StockResponse representa la respuesta de la API externa
 type StockResponse struct {
 	Items    []StockItem `json:"items"`
@@ -50,10 +91,115 @@ type StockItem struct {
 	Time       string `json:"time"`
 }
 
+// StockPrice representa el precio de cierre de una acción en un día
+type StockPrice struct {
+	ID        string    `json:"id" gorm:"primaryKey;type:uuid"`
+	Ticker    string    `json:"ticker" gorm:"uniqueIndex:idx_stock_prices_ticker_date;not null"`
+	Date      time.Time `json:"date" gorm:"uniqueIndex:idx_stock_prices_ticker_date;type:date;not null"`
+	Close     float64   `json:"close" gorm:"not null"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// Hook BeforeCreate se ejecuta antes de crear un registro
+func (p *StockPrice) BeforeCreate(tx *gorm.DB) (err error) {
+	if p.ID == "" {
+		p.ID = uuid.New().String()
+	}
+	return
+}
+
+// StockPriceItem representa un precio de cierre recibido por la API
+type StockPriceItem struct {
+	Ticker string  `json:"ticker"`
+	Date   string  `json:"date"`
+	Close  float64 `json:"close"`
+}
+
+// ScoreFactor representa la contribución de un factor a la puntuación de una recomendación
+type ScoreFactor struct {
+	Factor       string  `json:"factor"`
+	Input        string  `json:"input"`
+	Value        float64 `json:"value"`
+	Weight       float64 `json:"weight"`
+	Contribution float64 `json:"contribution"`
+}
+
 // StockRecommendation representa una recomendación de inversión
 type StockRecommendation struct {
-	Stock       Stock    `json:"stock"`
-	Score       float64  `json:"score"`
-	Reason      []string `json:"reasons"`
-	PotentialUp float64  `json:"potential_up,omitempty"`
+	Stock        Stock         `json:"stock"`
+	Score        float64       `json:"score"`
+	Reason       []string      `json:"reasons"`
+	Breakdown    []ScoreFactor `json:"breakdown"`
+	CurrentPrice *float64      `json:"current_price,omitempty"`
+	PotentialUp  *float64      `json:"potential_up,omitempty"`
+	AboveTarget  bool          `json:"above_target"`
+	TargetChange float64       `json:"target_change,omitempty"`
+}
+
+// BacktestParams contiene los parámetros de un backtest de recomendaciones
+type BacktestParams struct {
+	From time.Time
+	To   time.Time
+	// Top y Horizon usan el valor por defecto si son nil
+	Top       *int
+	Horizon   *int
+	Strategy  string
+	Benchmark string
+}
+
+// BacktestDay representa la lista de recomendaciones de un día y su rendimiento
+type BacktestDay struct {
+	Date            string   `json:"date"`
+	Tickers         []string `json:"tickers"`
+	Return          *float64 `json:"return,omitempty"`
+	BenchmarkReturn *float64 `json:"benchmark_return,omitempty"`
+	Turnover        float64  `json:"turnover"`
+}
+
+// BacktestResult resume el rendimiento de una estrategia en un periodo
+type BacktestResult struct {
+	Strategy        string        `json:"strategy"`
+	ScoringVersion  string        `json:"scoring_version"`
+	From            string        `json:"from"`
+	To              string        `json:"to"`
+	Top             int           `json:"top"`
+	Horizon         int           `json:"horizon_days"`
+	Benchmark       string        `json:"benchmark"`
+	Days            int           `json:"days"`
+	Picks           int           `json:"picks"`
+	PricedPicks     int           `json:"priced_picks"`
+	HitRate         float64       `json:"hit_rate"`
+	AverageReturn   float64       `json:"average_return"`
+	BenchmarkReturn float64       `json:"benchmark_return"`
+	ExcessReturn    float64       `json:"excess_return"`
+	MaxDrawdown     float64       `json:"max_drawdown"`
+	Turnover        float64       `json:"turnover"`
+	Daily           []BacktestDay `json:"daily"`
+}
+
+// StockFilter contiene los filtros y la paginación para consultar stocks
+type StockFilter struct {
+	Ticker    string
+	Brokerage string
+	Limit     int
+	Offset    int
+}
+
+// RatingEventFilter contiene los filtros y la paginación para consultar el historial de calificaciones
+type RatingEventFilter struct {
+	Ticker    string
+	Brokerage string
+	From      time.Time
+	To        time.Time
+	Limit     int
+	Offset    int
+}
+
+// BrokerageSummary resume la actividad de un broker en el historial de calificaciones
+type BrokerageSummary struct {
+	Name        string    `json:"name"`
+	EventCount  int64     `json:"event_count"`
+	TickerCount int64     `json:"ticker_count"`
+	LastEventAt time.Time `json:"last_event_at"`
 }
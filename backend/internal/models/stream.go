@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// StockStreamEvent representa un stock creado o actualizado por la sincronización,
+// numerado para poder retomar el stream desde el último evento recibido
+type StockStreamEvent struct {
+	ID      uint64    `json:"id"`
+	Time    time.Time `json:"time"`
+	Created bool      `json:"created"`
+	Stock   Stock     `json:"stock"`
+}
+
+// StockStreamFilter contiene los filtros de un suscriptor del stream; las listas vacías aceptan cualquier valor
+type StockStreamFilter struct {
+	Tickers    []string
+	Brokerages []string
+}
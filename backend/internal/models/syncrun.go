@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SyncRun representa una sincronización de stocks desde la API externa
+type SyncRun struct {
+	ID         string    `json:"id" gorm:"primaryKey;type:uuid"`
+	StartedAt  time.Time `json:"started_at" gorm:"not null"`
+	FinishedAt time.Time `json:"finished_at" gorm:"index;not null"`
+	Count      int       `json:"count"`
+	Succeeded  bool      `json:"succeeded" gorm:"index;not null"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// Hook BeforeCreate se ejecuta antes de crear un registro
+func (r *SyncRun) BeforeCreate(tx *gorm.DB) (err error) {
+	if r.ID == "" {
+		r.ID = uuid.New().String()
+	}
+	return
+}
+
+// SchemaMigration registra cada versión del esquema aplicada a la base de datos
+type SchemaMigration struct {
+	Version   int       `json:"version" gorm:"primaryKey;autoIncrement:false"`
+	AppliedAt time.Time `json:"applied_at" gorm:"not null"`
+}
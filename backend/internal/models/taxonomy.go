@@ -0,0 +1,71 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Tipos de etiquetas de la taxonomía
+const (
+	TaxonomyKindRating = "rating"
+	TaxonomyKindAction = "action"
+)
+
+// TaxonomyLabel representa una etiqueta canónica de calificación o acción con su puntuación
+type TaxonomyLabel struct {
+	ID        string          `json:"id" gorm:"primaryKey;type:uuid"`
+	Kind      string          `json:"kind" gorm:"uniqueIndex:idx_taxonomy_labels_kind_name;not null"`
+	Name      string          `json:"name" gorm:"uniqueIndex:idx_taxonomy_labels_kind_name;not null"`
+	Score     float64         `json:"score" gorm:"not null"`
+	Aliases   []TaxonomyAlias `json:"aliases" gorm:"foreignKey:LabelID;constraint:OnDelete:CASCADE"`
+	CreatedAt time.Time       `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time       `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// Hook BeforeCreate se ejecuta antes de crear un registro
+func (l *TaxonomyLabel) BeforeCreate(tx *gorm.DB) (err error) {
+	if l.ID == "" {
+		l.ID = uuid.New().String()
+	}
+	return
+}
+
+// TaxonomyAlias asocia una etiqueta recibida de la API externa con una etiqueta canónica
+type TaxonomyAlias struct {
+	ID        string    `json:"id" gorm:"primaryKey;type:uuid"`
+	LabelID   string    `json:"label_id" gorm:"type:uuid;index;not null"`
+	Kind      string    `json:"kind" gorm:"uniqueIndex:idx_taxonomy_aliases_kind_alias;not null"`
+	Alias     string    `json:"alias" gorm:"uniqueIndex:idx_taxonomy_aliases_kind_alias;not null"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// Hook BeforeCreate se ejecuta antes de crear un registro
+func (a *TaxonomyAlias) BeforeCreate(tx *gorm.DB) (err error) {
+	if a.ID == "" {
+		a.ID = uuid.New().String()
+	}
+	return
+}
+
+// UnmappedLabel registra una etiqueta recibida durante la sincronización sin puntuación asignada
+type UnmappedLabel struct {
+	Kind        string    `json:"kind" gorm:"primaryKey"`
+	Label       string    `json:"label" gorm:"primaryKey"`
+	Occurrences int64     `json:"occurrences" gorm:"not null;default:0"`
+	FirstSeen   time.Time `json:"first_seen" gorm:"not null"`
+	LastSeen    time.Time `json:"last_seen" gorm:"not null"`
+}
+
+// TaxonomyLabelInput contiene los datos para crear o actualizar una etiqueta canónica
+type TaxonomyLabelInput struct {
+	Name  string   `json:"name"`
+	Score *float64 `json:"score"`
+}
+
+// TaxonomyAliasInput contiene los datos para crear un alias de una etiqueta canónica
+type TaxonomyAliasInput struct {
+	Alias string `json:"alias"`
+	Label string `json:"label"`
+}
@@ -0,0 +1,74 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Estados de una entrega de webhook
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliverySucceeded = "succeeded"
+	WebhookDeliveryFailed    = "failed"
+)
+
+// Webhook representa un endpoint externo que recibe notificaciones de eventos.
+// Una lista de eventos vacía recibe todos los eventos.
+type Webhook struct {
+	ID        string    `json:"id" gorm:"primaryKey;type:uuid"`
+	URL       string    `json:"url" gorm:"not null"`
+	Secret    string    `json:"-" gorm:"not null"`
+	Events    []string  `json:"events" gorm:"serializer:json;type:text"`
+	Enabled   bool      `json:"enabled" gorm:"not null"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// Hook BeforeCreate se ejecuta antes de crear un registro
+func (w *Webhook) BeforeCreate(tx *gorm.DB) (err error) {
+	if w.ID == "" {
+		w.ID = uuid.New().String()
+	}
+	return
+}
+
+// WebhookInput contiene los datos para crear o actualizar un webhook
+type WebhookInput struct {
+	URL     string   `json:"url"`
+	Secret  string   `json:"secret"`
+	Events  []string `json:"events"`
+	Enabled *bool    `json:"enabled"`
+}
+
+// WebhookDelivery representa el envío de un evento a un webhook y sus reintentos
+type WebhookDelivery struct {
+	ID             string     `json:"id" gorm:"primaryKey;type:uuid"`
+	WebhookID      string     `json:"webhook_id" gorm:"type:uuid;index;not null"`
+	EventType      string     `json:"event_type" gorm:"not null"`
+	Payload        string     `json:"payload" gorm:"type:text;not null"`
+	Status         string     `json:"status" gorm:"index:idx_webhook_deliveries_due,priority:1;not null"`
+	Attempts       int        `json:"attempts" gorm:"not null"`
+	NextAttemptAt  time.Time  `json:"next_attempt_at" gorm:"index:idx_webhook_deliveries_due,priority:2"`
+	LastStatusCode int        `json:"last_status_code"`
+	LastError      string     `json:"last_error"`
+	DeliveredAt    *time.Time `json:"delivered_at"`
+	CreatedAt      time.Time  `json:"created_at" gorm:"autoCreateTime;index"`
+	UpdatedAt      time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// Hook BeforeCreate se ejecuta antes de crear un registro
+func (d *WebhookDelivery) BeforeCreate(tx *gorm.DB) (err error) {
+	if d.ID == "" {
+		d.ID = uuid.New().String()
+	}
+	return
+}
+
+// WebhookDeliveryFilter contiene los filtros para consultar el registro de entregas
+type WebhookDeliveryFilter struct {
+	WebhookID string
+	Status    string
+	Limit     int
+}
@@ -0,0 +1,274 @@
+// Package ratelimit limita la cantidad de solicitudes de cada cliente con token buckets.
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/fx"
+
+	"github.com/liferip/stock-analyzer/backend/config"
+)
+
+// Module proporciona las dependencias del limitador de solicitudes
+var Module = fx.Provide(NewLimiter)
+
+func init() {
+	config.RegisterValidator(validateConfig)
+}
+
+// validateConfig comprueba RATE_LIMITS y RATE_LIMIT_AUTH_FAILURES al cargar la configuración
+func validateConfig(cfg *config.Config, report func(setting string, err error)) {
+	for _, value := range cfg.RateLimits {
+		if _, err := ParseRule(value); err != nil {
+			report("RATE_LIMITS", err)
+		}
+	}
+	if value := strings.TrimSpace(cfg.RateLimitAuthFailures); value != "" {
+		if _, err := parseLimit(value, value); err != nil {
+			report("RATE_LIMIT_AUTH_FAILURES", err)
+		}
+	}
+}
+
+// sweepInterval es cada cuánto se eliminan los buckets llenos, que equivalen a uno nuevo
+const sweepInterval = 5 * time.Minute
+
+// Rule es el límite de un grupo de rutas: Requests solicitudes por Period, con ráfagas de
+// hasta Requests solicitudes
+type Rule struct {
+	// Prefix es el prefijo de las rutas del grupo, relativo a /api
+	Prefix   string
+	Requests int
+	Period   time.Duration
+}
+
+// ParseRule interpreta una regla con el formato prefijo=solicitudes/periodo, por ejemplo /stock/sync=5/1m
+func ParseRule(value string) (Rule, error) {
+	prefix, limit, ok := strings.Cut(value, "=")
+	if !ok {
+		return Rule{}, fmt.Errorf("invalid rate limit %q, expected 'prefix=requests/period'", value)
+	}
+
+	rule, err := parseLimit(value, limit)
+	if err != nil {
+		return Rule{}, err
+	}
+	rule.Prefix = "/" + strings.Trim(strings.TrimSpace(prefix), "/")
+
+	return rule, nil
+}
+
+// parseLimit interpreta un límite con el formato solicitudes/periodo, por ejemplo 10/1m; value
+// es el valor completo, para los mensajes de error
+func parseLimit(value, limit string) (Rule, error) {
+	requests, period, ok := strings.Cut(limit, "/")
+	if !ok {
+		return Rule{}, fmt.Errorf("invalid rate limit %q, expected 'requests/period'", value)
+	}
+
+	var rule Rule
+	var err error
+	if rule.Requests, err = strconv.Atoi(strings.TrimSpace(requests)); err != nil || rule.Requests <= 0 {
+		return Rule{}, fmt.Errorf("invalid rate limit %q: requests must be a positive integer", value)
+	}
+	if rule.Period, err = time.ParseDuration(strings.TrimSpace(period)); err != nil || rule.Period <= 0 {
+		return Rule{}, fmt.Errorf("invalid rate limit %q: period must be a positive duration such as 1s or 1m", value)
+	}
+
+	return rule, nil
+}
+
+// matches indica si una ruta pertenece al grupo de la regla
+func (r Rule) matches(path string) bool {
+	if r.Prefix == "/" {
+		return true
+	}
+	return path == r.Prefix || strings.HasPrefix(path, r.Prefix+"/")
+}
+
+// Result es el resultado de consumir una solicitud del bucket de un cliente
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset es el tiempo que falta para que el bucket vuelva a estar lleno
+	Reset time.Duration
+	// RetryAfter es el tiempo que falta para que haya lugar para otra solicitud; cero si se permitió
+	RetryAfter time.Duration
+	Rule       Rule
+}
+
+// bucket guarda los tokens disponibles de un cliente en un grupo de rutas
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	// period es el periodo de la regla, en el que un bucket vacío se vuelve a llenar
+	period time.Duration
+}
+
+// failurePrefix identifica los buckets de fallos de autenticación, separados de los de las rutas
+const failurePrefix = "auth-failures"
+
+// Limiter aplica las reglas con un token bucket por cliente y grupo de rutas, y limita los
+// fallos de autenticación de cada IP
+type Limiter struct {
+	// rules está ordenado del prefijo más largo al más corto
+	rules []Rule
+	// failures es el límite de credenciales inválidas por IP; nil si no se limita
+	failures *Rule
+	now      func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+// NewLimiter crea un limitador con las reglas configuradas en RATE_LIMITS; sin reglas no limita
+func NewLimiter(cfg *config.Config) (*Limiter, error) {
+	rules := make([]Rule, 0, len(cfg.RateLimits))
+	for _, value := range cfg.RateLimits {
+		rule, err := ParseRule(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RATE_LIMITS entry: %w", err)
+		}
+		rules = append(rules, rule)
+	}
+
+	limiter := New(rules)
+
+	if value := strings.TrimSpace(cfg.RateLimitAuthFailures); value != "" {
+		rule, err := parseLimit(value, value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RATE_LIMIT_AUTH_FAILURES: %w", err)
+		}
+		rule.Prefix = failurePrefix
+		limiter.failures = &rule
+	}
+
+	return limiter, nil
+}
+
+// New crea un limitador con las reglas indicadas
+func New(rules []Rule) *Limiter {
+	sorted := append([]Rule(nil), rules...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return len(sorted[i].Prefix) > len(sorted[j].Prefix)
+	})
+
+	return &Limiter{
+		rules:     sorted,
+		now:       time.Now,
+		buckets:   make(map[string]*bucket),
+		lastSweep: time.Now(),
+	}
+}
+
+// Enabled indica si hay alguna regla configurada
+func (l *Limiter) Enabled() bool {
+	return len(l.rules) > 0
+}
+
+// FailuresEnabled indica si se limitan los fallos de autenticación
+func (l *Limiter) FailuresEnabled() bool {
+	return l.failures != nil
+}
+
+// Allow consume una solicitud del cliente en el grupo de la ruta. Devuelve false si la ruta
+// no pertenece a ningún grupo.
+func (l *Limiter) Allow(client, path string) (Result, bool) {
+	rule, ok := l.match(path)
+	if !ok {
+		return Result{}, false
+	}
+
+	return l.take(rule, client, true), true
+}
+
+// CheckFailures indica si un cliente todavía puede intentar autenticarse, sin consumir un
+// intento. Devuelve false si no se limitan los fallos.
+func (l *Limiter) CheckFailures(client string) (Result, bool) {
+	if l.failures == nil {
+		return Result{}, false
+	}
+	return l.take(*l.failures, client, false), true
+}
+
+// RecordFailure consume un intento de autenticación del cliente después de un fallo
+func (l *Limiter) RecordFailure(client string) {
+	if l.failures != nil {
+		l.take(*l.failures, client, true)
+	}
+}
+
+// take recarga el bucket del cliente en el grupo de la regla y, si consume es true, gasta
+// una solicitud. Sin consumir, Allowed indica si queda lugar para otra.
+func (l *Limiter) take(rule Rule, client string, consume bool) Result {
+	capacity := float64(rule.Requests)
+	// rate es la cantidad de tokens que se recuperan por segundo
+	rate := capacity / rule.Period.Seconds()
+	now := l.now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+
+	key := rule.Prefix + " " + client
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: capacity, updated: now, period: rule.Period}
+		l.buckets[key] = b
+	}
+
+	b.tokens = math.Min(capacity, b.tokens+now.Sub(b.updated).Seconds()*rate)
+	b.updated = now
+
+	result := Result{Limit: rule.Requests, Rule: rule}
+	if b.tokens >= 1 {
+		if consume {
+			b.tokens--
+		}
+		result.Allowed = true
+	} else {
+		result.RetryAfter = secondsToDuration((1 - b.tokens) / rate)
+	}
+	result.Remaining = int(b.tokens)
+	result.Reset = secondsToDuration((capacity - b.tokens) / rate)
+
+	return result
+}
+
+// match obtiene la regla del grupo con el prefijo más largo que contiene la ruta
+func (l *Limiter) match(path string) (Rule, bool) {
+	for _, rule := range l.rules {
+		if rule.matches(path) {
+			return rule, true
+		}
+	}
+	return Rule{}, false
+}
+
+// sweep elimina los buckets que ya se llenaron, para no acumular un bucket por cada cliente visto
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+
+	for key, b := range l.buckets {
+		if now.Sub(b.updated) >= b.period {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// secondsToDuration convierte segundos a una duración
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/liferip/stock-analyzer/backend/internal/models"
+	"github.com/liferip/stock-analyzer/backend/pkg/logger"
+)
+
+// AlertRepository interfaz que define las operaciones sobre reglas y eventos de alerta
+type AlertRepository interface {
+	GetRules(ctx context.Context) ([]models.AlertRule, error)
+	GetRuleByID(ctx context.Context, id string) (*models.AlertRule, error)
+	CreateRule(ctx context.Context, rule *models.AlertRule) error
+	UpdateRule(ctx context.Context, rule *models.AlertRule) error
+	DeleteRule(ctx context.Context, id string) error
+	CreateEvent(ctx context.Context, event *models.AlertEvent) error
+	GetEvents(ctx context.Context, filter models.AlertEventFilter) ([]models.AlertEvent, error)
+}
+
+// alertRepository implementación de AlertRepository con GORM
+type alertRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewAlertRepository crea una nueva instancia de AlertRepository
+func NewAlertRepository(db *gorm.DB, logger *zap.Logger) AlertRepository {
+	return &alertRepository{
+		db:     db,
+		logger: logger.Named("alert_repository"),
+	}
+}
+
+// GetRules obtiene todas las reglas de alerta
+func (r *alertRepository) GetRules(ctx context.Context) ([]models.AlertRule, error) {
+	var rules []models.AlertRule
+
+	result := r.db.WithContext(ctx).
+		Order("created_at ASC").
+		Find(&rules)
+
+	if result.Error != nil {
+		logger.Ctx(ctx, r.logger).Error("Error getting alert rules", zap.Error(result.Error))
+		return nil, result.Error
+	}
+
+	return rules, nil
+}
+
+// GetRuleByID obtiene una regla de alerta por su ID
+func (r *alertRepository) GetRuleByID(ctx context.Context, id string) (*models.AlertRule, error) {
+	var rule models.AlertRule
+
+	result := r.db.WithContext(ctx).
+		Where("id = ?", id).
+		First(&rule)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		logger.Ctx(ctx, r.logger).Error("Error getting alert rule",
+			zap.String("id", id),
+			zap.Error(result.Error))
+		return nil, result.Error
+	}
+
+	return &rule, nil
+}
+
+// CreateRule crea una regla de alerta
+func (r *alertRepository) CreateRule(ctx context.Context, rule *models.AlertRule) error {
+	result := r.db.WithContext(ctx).Create(rule)
+
+	if result.Error != nil {
+		logger.Ctx(ctx, r.logger).Error("Error creating alert rule",
+			zap.String("name", rule.Name),
+			zap.Error(result.Error))
+		return result.Error
+	}
+
+	return nil
+}
+
+// UpdateRule actualiza una regla de alerta existente
+func (r *alertRepository) UpdateRule(ctx context.Context, rule *models.AlertRule) error {
+	result := r.db.WithContext(ctx).Save(rule)
+
+	if result.Error != nil {
+		logger.Ctx(ctx, r.logger).Error("Error updating alert rule",
+			zap.String("id", rule.ID),
+			zap.Error(result.Error))
+		return result.Error
+	}
+
+	return nil
+}
+
+// DeleteRule elimina una regla de alerta por su ID
+func (r *alertRepository) DeleteRule(ctx context.Context, id string) error {
+	result := r.db.WithContext(ctx).
+		Delete(&models.AlertRule{}, "id = ?", id)
+
+	if result.Error != nil {
+		logger.Ctx(ctx, r.logger).Error("Error deleting alert rule",
+			zap.String("id", id),
+			zap.Error(result.Error))
+		return result.Error
+	}
+
+	return nil
+}
+
+// CreateEvent guarda una alerta generada por una regla
+func (r *alertRepository) CreateEvent(ctx context.Context, event *models.AlertEvent) error {
+	result := r.db.WithContext(ctx).Create(event)
+
+	if result.Error != nil {
+		logger.Ctx(ctx, r.logger).Error("Error creating alert event",
+			zap.String("rule_id", event.RuleID),
+			zap.String("ticker", event.Ticker),
+			zap.Error(result.Error))
+		return result.Error
+	}
+
+	return nil
+}
+
+// GetEvents obtiene las alertas más recientes que cumplen los filtros
+func (r *alertRepository) GetEvents(ctx context.Context, filter models.AlertEventFilter) ([]models.AlertEvent, error) {
+	var events []models.AlertEvent
+
+	query := r.db.WithContext(ctx).
+		Order("created_at DESC").
+		Limit(filter.Limit)
+	if filter.RuleID != "" {
+		query = query.Where("rule_id = ?", filter.RuleID)
+	}
+	if filter.Ticker != "" {
+		query = query.Where("ticker = ?", filter.Ticker)
+	}
+	if !filter.Since.IsZero() {
+		query = query.Where("created_at >= ?", filter.Since)
+	}
+
+	result := query.Find(&events)
+	if result.Error != nil {
+		logger.Ctx(ctx, r.logger).Error("Error getting alert events", zap.Error(result.Error))
+		return nil, result.Error
+	}
+
+	return events, nil
+}
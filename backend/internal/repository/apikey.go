@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/liferip/stock-analyzer/backend/internal/models"
+	"github.com/liferip/stock-analyzer/backend/pkg/logger"
+)
+
+// APIKeyRepository interfaz que define las operaciones sobre API keys
+type APIKeyRepository interface {
+	GetAll(ctx context.Context) ([]models.APIKey, error)
+	GetByID(ctx context.Context, id string) (*models.APIKey, error)
+	GetByHash(ctx context.Context, hash string) (*models.APIKey, error)
+	Create(ctx context.Context, key *models.APIKey) error
+	Update(ctx context.Context, key *models.APIKey) error
+	TouchLastUsed(ctx context.Context, id string, usedAt time.Time) error
+}
+
+// apiKeyRepository implementación de APIKeyRepository con GORM
+type apiKeyRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewAPIKeyRepository crea una nueva instancia de APIKeyRepository
+func NewAPIKeyRepository(db *gorm.DB, logger *zap.Logger) APIKeyRepository {
+	return &apiKeyRepository{
+		db:     db,
+		logger: logger.Named("api_key_repository"),
+	}
+}
+
+// GetAll obtiene todas las API keys, incluidas las revocadas
+func (r *apiKeyRepository) GetAll(ctx context.Context) ([]models.APIKey, error) {
+	var keys []models.APIKey
+
+	result := r.db.WithContext(ctx).
+		Order("created_at ASC").
+		Find(&keys)
+
+	if result.Error != nil {
+		logger.Ctx(ctx, r.logger).Error("Error getting API keys", zap.Error(result.Error))
+		return nil, result.Error
+	}
+
+	return keys, nil
+}
+
+// GetByID obtiene una API key por su ID
+func (r *apiKeyRepository) GetByID(ctx context.Context, id string) (*models.APIKey, error) {
+	return r.getBy(ctx, "id = ?", id)
+}
+
+// GetByHash obtiene una API key por el hash de su valor
+func (r *apiKeyRepository) GetByHash(ctx context.Context, hash string) (*models.APIKey, error) {
+	return r.getBy(ctx, "hash = ?", hash)
+}
+
+// getBy obtiene la primera API key que cumple la condición, o nil si no existe
+func (r *apiKeyRepository) getBy(ctx context.Context, query string, value string) (*models.APIKey, error) {
+	var key models.APIKey
+
+	result := r.db.WithContext(ctx).
+		Where(query, value).
+		First(&key)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		logger.Ctx(ctx, r.logger).Error("Error getting API key", zap.Error(result.Error))
+		return nil, result.Error
+	}
+
+	return &key, nil
+}
+
+// Create registra una API key
+func (r *apiKeyRepository) Create(ctx context.Context, key *models.APIKey) error {
+	result := r.db.WithContext(ctx).Create(key)
+
+	if result.Error != nil {
+		logger.Ctx(ctx, r.logger).Error("Error creating API key", zap.Error(result.Error))
+		return result.Error
+	}
+
+	return nil
+}
+
+// Update guarda todos los campos de una API key
+func (r *apiKeyRepository) Update(ctx context.Context, key *models.APIKey) error {
+	result := r.db.WithContext(ctx).Save(key)
+
+	if result.Error != nil {
+		logger.Ctx(ctx, r.logger).Error("Error updating API key",
+			zap.String("id", key.ID),
+			zap.Error(result.Error))
+		return result.Error
+	}
+
+	return nil
+}
+
+// TouchLastUsed actualiza solo la fecha de último uso de una API key
+func (r *apiKeyRepository) TouchLastUsed(ctx context.Context, id string, usedAt time.Time) error {
+	result := r.db.WithContext(ctx).
+		Model(&models.APIKey{}).
+		Where("id = ?", id).
+		UpdateColumn("last_used_at", usedAt)
+
+	if result.Error != nil {
+		logger.Ctx(ctx, r.logger).Error("Error updating API key last use",
+			zap.String("id", id),
+			zap.Error(result.Error))
+		return result.Error
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/liferip/stock-analyzer/backend/internal/models"
+	"github.com/liferip/stock-analyzer/backend/pkg/logger"
+)
+
+// DigestRepository interfaz que define las operaciones sobre los envíos del resumen diario
+type DigestRepository interface {
+	GetLast(ctx context.Context) (*models.DigestRun, error)
+	Create(ctx context.Context, run *models.DigestRun) error
+	Claim(ctx context.Context, run *models.DigestRun) (bool, error)
+	Delete(ctx context.Context, id string) error
+}
+
+// digestRepository implementación de DigestRepository con GORM
+type digestRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewDigestRepository crea una nueva instancia de DigestRepository
+func NewDigestRepository(db *gorm.DB, logger *zap.Logger) DigestRepository {
+	return &digestRepository{
+		db:     db,
+		logger: logger.Named("digest_repository"),
+	}
+}
+
+// GetLast obtiene el último envío del resumen, o nil si nunca se envió
+func (r *digestRepository) GetLast(ctx context.Context) (*models.DigestRun, error) {
+	var run models.DigestRun
+
+	result := r.db.WithContext(ctx).
+		Order("sent_at DESC").
+		First(&run)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		logger.Ctx(ctx, r.logger).Error("Error getting last digest run", zap.Error(result.Error))
+		return nil, result.Error
+	}
+
+	return &run, nil
+}
+
+// Create registra un envío del resumen
+func (r *digestRepository) Create(ctx context.Context, run *models.DigestRun) error {
+	result := r.db.WithContext(ctx).Create(run)
+
+	if result.Error != nil {
+		logger.Ctx(ctx, r.logger).Error("Error creating digest run", zap.Error(result.Error))
+		return result.Error
+	}
+
+	return nil
+}
+
+// Claim registra el envío del resumen de run.Day, o devuelve false si otro ya lo registró
+func (r *digestRepository) Claim(ctx context.Context, run *models.DigestRun) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(run)
+
+	if result.Error != nil {
+		logger.Ctx(ctx, r.logger).Error("Error claiming digest run", zap.Error(result.Error))
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// Delete elimina un envío del resumen; no es un error si no existe
+func (r *digestRepository) Delete(ctx context.Context, id string) error {
+	result := r.db.WithContext(ctx).Delete(&models.DigestRun{}, "id = ?", id)
+
+	if result.Error != nil {
+		logger.Ctx(ctx, r.logger).Error("Error deleting digest run", zap.Error(result.Error))
+		return result.Error
+	}
+
+	return nil
+}
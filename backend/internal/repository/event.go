@@ -0,0 +1,213 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/liferip/stock-analyzer/backend/internal/models"
+	"github.com/liferip/stock-analyzer/backend/pkg/logger"
+)
+
+// EventRepository interfaz que define las operaciones sobre eventos de calificación
+type EventRepository interface {
+	Create(ctx context.Context, event *models.RatingEvent) error
+	GetUntil(ctx context.Context, until time.Time) ([]models.RatingEvent, error)
+	GetRecordedSince(ctx context.Context, since time.Time) ([]models.RatingEvent, error)
+	Find(ctx context.Context, filter models.RatingEventFilter) ([]models.RatingEvent, int64, error)
+	GetBrokerages(ctx context.Context) ([]models.BrokerageSummary, error)
+}
+
+// eventRepository implementación de EventRepository con GORM
+type eventRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewEventRepository crea una nueva instancia de EventRepository
+func NewEventRepository(db *gorm.DB, logger *zap.Logger) EventRepository {
+	return &eventRepository{
+		db:     db,
+		logger: logger.Named("event_repository"),
+	}
+}
+
+// Create guarda un evento, ignorándolo si ya fue registrado
+func (r *eventRepository) Create(ctx context.Context, event *models.RatingEvent) error {
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(event)
+
+	if result.Error != nil {
+		logger.Ctx(ctx, r.logger).Error("Error creating rating event",
+			zap.String("ticker", event.Ticker),
+			zap.Error(result.Error))
+		return result.Error
+	}
+
+	return nil
+}
+
+// GetUntil obtiene los eventos ocurridos hasta una fecha, del más antiguo al más reciente
+func (r *eventRepository) GetUntil(ctx context.Context, until time.Time) ([]models.RatingEvent, error) {
+	var events []models.RatingEvent
+
+	result := r.db.WithContext(ctx).
+		Where("time <= ?", until).
+		Order("time ASC").
+		Find(&events)
+
+	if result.Error != nil {
+		logger.Ctx(ctx, r.logger).Error("Error getting rating events",
+			zap.Time("until", until),
+			zap.Error(result.Error))
+		return nil, result.Error
+	}
+
+	return events, nil
+}
+
+// GetRecordedSince obtiene los eventos registrados después de una fecha, del más reciente al más antiguo
+func (r *eventRepository) GetRecordedSince(ctx context.Context, since time.Time) ([]models.RatingEvent, error) {
+	var events []models.RatingEvent
+
+	result := r.db.WithContext(ctx).
+		Where("created_at > ?", since).
+		Order("time DESC").
+		Find(&events)
+
+	if result.Error != nil {
+		logger.Ctx(ctx, r.logger).Error("Error getting recorded rating events",
+			zap.Time("since", since),
+			zap.Error(result.Error))
+		return nil, result.Error
+	}
+
+	return events, nil
+}
+
+// Find obtiene una página de eventos que cumplen los filtros, del más reciente al más antiguo,
+// junto con el total sin paginar
+func (r *eventRepository) Find(ctx context.Context, filter models.RatingEventFilter) ([]models.RatingEvent, int64, error) {
+	var events []models.RatingEvent
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&models.RatingEvent{})
+	if filter.Ticker != "" {
+		query = query.Where("ticker = ?", filter.Ticker)
+	}
+	if filter.Brokerage != "" {
+		query = query.Where("brokerage = ?", filter.Brokerage)
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("time >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("time <= ?", filter.To)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		logger.Ctx(ctx, r.logger).Error("Error counting rating events", zap.Error(err))
+		return nil, 0, err
+	}
+
+	result := query.
+		Order("time DESC").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(&events)
+
+	if result.Error != nil {
+		logger.Ctx(ctx, r.logger).Error("Error finding rating events", zap.Error(result.Error))
+		return nil, 0, result.Error
+	}
+
+	return events, total, nil
+}
+
+// GetBrokerages obtiene los brokers del historial con su cantidad de eventos, del más activo al menos activo
+func (r *eventRepository) GetBrokerages(ctx context.Context) ([]models.BrokerageSummary, error) {
+	var rows []struct {
+		Name        string
+		EventCount  int64
+		TickerCount int64
+		LastEventAt aggregateTime
+	}
+
+	result := r.db.WithContext(ctx).
+		Model(&models.RatingEvent{}).
+		Select("brokerage AS name, COUNT(*) AS event_count, COUNT(DISTINCT ticker) AS ticker_count, MAX(time) AS last_event_at").
+		Group("brokerage").
+		Order("event_count DESC, name ASC").
+		Scan(&rows)
+
+	if result.Error != nil {
+		logger.Ctx(ctx, r.logger).Error("Error getting brokerages", zap.Error(result.Error))
+		return nil, result.Error
+	}
+
+	brokerages := make([]models.BrokerageSummary, len(rows))
+	for i, row := range rows {
+		brokerages[i] = models.BrokerageSummary{
+			Name:        row.Name,
+			EventCount:  row.EventCount,
+			TickerCount: row.TickerCount,
+			LastEventAt: row.LastEventAt.Time,
+		}
+	}
+
+	return brokerages, nil
+}
+
+// aggregateTime es una fecha calculada con una función de agregación como MAX. SQLite la
+// devuelve como texto, porque el resultado no tiene el tipo de la columna.
+type aggregateTime struct {
+	time.Time
+}
+
+// aggregateTimeLayouts son los formatos en que SQLite guarda las fechas
+var aggregateTimeLayouts = []string{
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02T15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999",
+	time.RFC3339Nano,
+}
+
+// Scan implementa sql.Scanner
+func (t *aggregateTime) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		t.Time = time.Time{}
+		return nil
+	case time.Time:
+		t.Time = v
+		return nil
+	case []byte:
+		return t.parse(string(v))
+	case string:
+		return t.parse(v)
+	default:
+		return fmt.Errorf("cannot scan %T into a time", value)
+	}
+}
+
+// Value implementa driver.Valuer, que GORM exige junto con Scan
+func (t aggregateTime) Value() (driver.Value, error) {
+	return t.Time, nil
+}
+
+// parse interpreta una fecha guardada como texto
+func (t *aggregateTime) parse(value string) error {
+	for _, layout := range aggregateTimeLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			t.Time = parsed
+			return nil
+		}
+	}
+	return fmt.Errorf("cannot parse %q as a time", value)
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/liferip/stock-analyzer/backend/internal/models"
+	"github.com/liferip/stock-analyzer/backend/pkg/logger"
+)
+
+// PriceRepository interfaz que define las operaciones sobre precios de cierre
+type PriceRepository interface {
+	GetLatest(ctx context.Context) (map[string]models.StockPrice, error)
+	GetLatestByTicker(ctx context.Context, ticker string) (*models.StockPrice, error)
+	GetRange(ctx context.Context, from, to time.Time) ([]models.StockPrice, error)
+	Upsert(ctx context.Context, prices []models.StockPrice) error
+}
+
+// priceRepository implementación de PriceRepository con GORM
+type priceRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewPriceRepository crea una nueva instancia de PriceRepository
+func NewPriceRepository(db *gorm.DB, logger *zap.Logger) PriceRepository {
+	return &priceRepository{
+		db:     db,
+		logger: logger.Named("price_repository"),
+	}
+}
+
+// GetLatest obtiene el último precio de cierre de cada ticker
+func (r *priceRepository) GetLatest(ctx context.Context) (map[string]models.StockPrice, error) {
+	var prices []models.StockPrice
+
+	latest := r.db.Model(&models.StockPrice{}).
+		Select("ticker, MAX(date)").
+		Group("ticker")
+
+	result := r.db.WithContext(ctx).
+		Where("(ticker, date) IN (?)", latest).
+		Find(&prices)
+
+	if result.Error != nil {
+		logger.Ctx(ctx, r.logger).Error("Error getting latest prices", zap.Error(result.Error))
+		return nil, result.Error
+	}
+
+	byTicker := make(map[string]models.StockPrice, len(prices))
+	for _, price := range prices {
+		byTicker[price.Ticker] = price
+	}
+
+	return byTicker, nil
+}
+
+// GetLatestByTicker obtiene el último precio de cierre de un ticker, o nil si no tiene precios
+func (r *priceRepository) GetLatestByTicker(ctx context.Context, ticker string) (*models.StockPrice, error) {
+	var prices []models.StockPrice
+
+	result := r.db.WithContext(ctx).
+		Where("ticker = ?", ticker).
+		Order("date DESC").
+		Limit(1).
+		Find(&prices)
+
+	if result.Error != nil {
+		logger.Ctx(ctx, r.logger).Error("Error getting latest price",
+			zap.String("ticker", ticker),
+			zap.Error(result.Error))
+		return nil, result.Error
+	}
+
+	if len(prices) == 0 {
+		return nil, nil
+	}
+	return &prices[0], nil
+}
+
+// GetRange obtiene los precios entre dos fechas, ordenados por ticker y fecha
+func (r *priceRepository) GetRange(ctx context.Context, from, to time.Time) ([]models.StockPrice, error) {
+	var prices []models.StockPrice
+
+	result := r.db.WithContext(ctx).
+		Where("date BETWEEN ? AND ?", from, to).
+		Order("ticker ASC, date ASC").
+		Find(&prices)
+
+	if result.Error != nil {
+		logger.Ctx(ctx, r.logger).Error("Error getting prices by range",
+			zap.Time("from", from),
+			zap.Time("to", to),
+			zap.Error(result.Error))
+		return nil, result.Error
+	}
+
+	return prices, nil
+}
+
+// Upsert crea o actualiza precios de cierre por ticker y fecha
+func (r *priceRepository) Upsert(ctx context.Context, prices []models.StockPrice) error {
+	if len(prices) == 0 {
+		return nil
+	}
+
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "ticker"}, {Name: "date"}},
+			DoUpdates: clause.AssignmentColumns([]string{"close", "updated_at"}),
+		}).
+		Create(&prices)
+
+	if result.Error != nil {
+		logger.Ctx(ctx, r.logger).Error("Error upserting prices",
+			zap.Int("count", len(prices)),
+			zap.Error(result.Error))
+		return result.Error
+	}
+
+	return nil
+}
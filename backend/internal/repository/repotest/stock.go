@@ -0,0 +1,329 @@
+// Package repotest verifica que las implementaciones de los repositorios se comporten igual,
+// al estilo de testing/fstest: cada función prueba una implementación y devuelve un error con
+// todos los casos que fallaron.
+package repotest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/liferip/stock-analyzer/backend/internal/models"
+	"github.com/liferip/stock-analyzer/backend/internal/repository"
+)
+
+// NewStockRepository crea un StockRepository vacío para un caso de prueba
+type NewStockRepository func() (repository.StockRepository, error)
+
+// Error reúne los casos que fallaron
+type Error struct {
+	Failures []string
+}
+
+// Error devuelve un caso por línea
+func (e *Error) Error() string {
+	return "repository does not conform:\n  - " + strings.Join(e.Failures, "\n  - ")
+}
+
+// stockCase es un caso de prueba de StockRepository
+type stockCase struct {
+	name string
+	run  func(ctx context.Context, repo repository.StockRepository) error
+}
+
+// day es la fecha de referencia de los casos, en UTC y sin fracciones de segundo, para que
+// todas las implementaciones la guarden igual
+var day = time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+
+// StockRepository verifica que una implementación de StockRepository cumpla el comportamiento
+// esperado: orden del más reciente al más antiguo, nil sin error si no hay resultados,
+// paginación como LIMIT y OFFSET y errores al repetir un ID. newRepo debe devolver un
+// repositorio vacío en cada llamada.
+func StockRepository(ctx context.Context, newRepo NewStockRepository) error {
+	var failures []string
+	for _, c := range stockCases {
+		repo, err := newRepo()
+		if err != nil {
+			return fmt.Errorf("error creating repository for %q: %w", c.name, err)
+		}
+
+		if err := c.run(ctx, repo); err != nil {
+			failures = append(failures, c.name+": "+err.Error())
+		}
+	}
+
+	if len(failures) > 0 {
+		return &Error{Failures: failures}
+	}
+	return nil
+}
+
+// stockCases son los casos de StockRepository
+var stockCases = []stockCase{
+	{"empty repository", func(ctx context.Context, repo repository.StockRepository) error {
+		stocks, err := repo.GetAll(ctx)
+		if err != nil || len(stocks) != 0 {
+			return fmt.Errorf("GetAll = %d stocks, %v; want 0, nil", len(stocks), err)
+		}
+		if stock, err := repo.GetByTicker(ctx, "NONE"); stock != nil || err != nil {
+			return fmt.Errorf("GetByTicker of a missing ticker = %v, %v; want nil, nil", stock, err)
+		}
+		if stock, err := repo.GetByTickerSimple(ctx, "NONE"); stock != nil || err != nil {
+			return fmt.Errorf("GetByTickerSimple of a missing ticker = %v, %v; want nil, nil", stock, err)
+		}
+		stocks, total, err := repo.Find(ctx, models.StockFilter{Limit: 10})
+		if err != nil || len(stocks) != 0 || total != 0 {
+			return fmt.Errorf("Find = %d stocks, total %d, %v; want 0, 0, nil", len(stocks), total, err)
+		}
+		return nil
+	}},
+
+	{"create assigns ID and timestamps", func(ctx context.Context, repo repository.StockRepository) error {
+		stock := newStock("AAPL", "Broker A", day)
+		if err := repo.Create(ctx, &stock); err != nil {
+			return fmt.Errorf("Create: %w", err)
+		}
+		if stock.ID == "" || stock.CreatedAt.IsZero() || stock.UpdatedAt.IsZero() {
+			return fmt.Errorf("Create left ID %q, created_at %v, updated_at %v; want them set", stock.ID, stock.CreatedAt, stock.UpdatedAt)
+		}
+
+		got, err := repo.GetByTicker(ctx, "AAPL")
+		if err != nil || got == nil {
+			return fmt.Errorf("GetByTicker after Create = %v, %v", got, err)
+		}
+		return sameStock(*got, stock)
+	}},
+
+	{"create rejects a duplicate ID", func(ctx context.Context, repo repository.StockRepository) error {
+		stock := newStock("AAPL", "Broker A", day)
+		if err := repo.Create(ctx, &stock); err != nil {
+			return fmt.Errorf("Create: %w", err)
+		}
+		duplicate := newStock("MSFT", "Broker B", day)
+		duplicate.ID = stock.ID
+		if err := repo.Create(ctx, &duplicate); err == nil {
+			return errors.New("Create with an existing ID succeeded; want an error")
+		}
+		return nil
+	}},
+
+	{"get all orders by time descending", func(ctx context.Context, repo repository.StockRepository) error {
+		if err := createStocks(ctx, repo,
+			newStock("AAPL", "Broker A", day.Add(time.Hour)),
+			newStock("MSFT", "Broker A", day.Add(3*time.Hour)),
+			newStock("NVDA", "Broker B", day.Add(2*time.Hour)),
+		); err != nil {
+			return err
+		}
+
+		stocks, err := repo.GetAll(ctx)
+		if err != nil {
+			return fmt.Errorf("GetAll: %w", err)
+		}
+		return tickers(stocks, "MSFT", "NVDA", "AAPL")
+	}},
+
+	{"get by ticker returns the latest", func(ctx context.Context, repo repository.StockRepository) error {
+		older := newStock("AAPL", "Broker A", day)
+		latest := newStock("AAPL", "Broker B", day.Add(time.Hour))
+		if err := createStocks(ctx, repo, older, latest, newStock("MSFT", "Broker A", day.Add(2*time.Hour))); err != nil {
+			return err
+		}
+
+		got, err := repo.GetByTicker(ctx, "AAPL")
+		if err != nil || got == nil {
+			return fmt.Errorf("GetByTicker = %v, %v", got, err)
+		}
+		if got.Brokerage != "Broker B" {
+			return fmt.Errorf("GetByTicker returned the stock from %s; want the latest, from Broker B", got.Brokerage)
+		}
+
+		simple, err := repo.GetByTickerSimple(ctx, "AAPL")
+		if err != nil || simple == nil {
+			return fmt.Errorf("GetByTickerSimple = %v, %v", simple, err)
+		}
+		if simple.ID != got.ID || !simple.Time.Equal(got.Time) {
+			return fmt.Errorf("GetByTickerSimple = %s at %v; want %s at %v", simple.ID, simple.Time, got.ID, got.Time)
+		}
+		if simple.Ticker != "" || simple.Company != "" {
+			return fmt.Errorf("GetByTickerSimple loaded ticker %q and company %q; want only ID and time", simple.Ticker, simple.Company)
+		}
+		return nil
+	}},
+
+	{"get all by time covers the whole day", func(ctx context.Context, repo repository.StockRepository) error {
+		if err := createStocks(ctx, repo,
+			newStock("BEFORE", "Broker A", day.Add(-time.Second)),
+			newStock("START", "Broker A", day),
+			newStock("NOON", "Broker A", day.Add(12*time.Hour)),
+			newStock("END", "Broker A", day.Add(24*time.Hour-time.Second)),
+			newStock("AFTER", "Broker A", day.Add(24*time.Hour)),
+		); err != nil {
+			return err
+		}
+
+		stocks, err := repo.GetAllByTime(ctx, day.Format(time.DateOnly))
+		if err != nil {
+			return fmt.Errorf("GetAllByTime: %w", err)
+		}
+		if err := tickers(stocks, "END", "NOON", "START"); err != nil {
+			return err
+		}
+
+		stocks, err = repo.GetAllByTime(ctx, day.AddDate(0, 0, 7).Format(time.DateOnly))
+		if err != nil || len(stocks) != 0 {
+			return fmt.Errorf("GetAllByTime of a day without stocks = %d stocks, %v; want 0, nil", len(stocks), err)
+		}
+		return nil
+	}},
+
+	{"update saves every field", func(ctx context.Context, repo repository.StockRepository) error {
+		stock := newStock("AAPL", "Broker A", day)
+		if err := repo.Create(ctx, &stock); err != nil {
+			return fmt.Errorf("Create: %w", err)
+		}
+
+		stock.RatingTo = "Sell"
+		stock.TargetTo = "$100.00"
+		stock.Time = day.Add(time.Hour)
+		if err := repo.Update(ctx, &stock); err != nil {
+			return fmt.Errorf("Update: %w", err)
+		}
+
+		got, err := repo.GetByTicker(ctx, "AAPL")
+		if err != nil || got == nil {
+			return fmt.Errorf("GetByTicker after Update = %v, %v", got, err)
+		}
+		if err := sameStock(*got, stock); err != nil {
+			return err
+		}
+
+		stocks, err := repo.GetAll(ctx)
+		if err != nil || len(stocks) != 1 {
+			return fmt.Errorf("GetAll after Update = %d stocks, %v; want 1, nil", len(stocks), err)
+		}
+		return nil
+	}},
+
+	{"delete removes the stock", func(ctx context.Context, repo repository.StockRepository) error {
+		kept := newStock("AAPL", "Broker A", day)
+		deleted := newStock("MSFT", "Broker A", day.Add(time.Hour))
+		if err := createStocks(ctx, repo, kept, deleted); err != nil {
+			return err
+		}
+
+		stocks, err := repo.GetAll(ctx)
+		if err != nil {
+			return fmt.Errorf("GetAll: %w", err)
+		}
+		if err := repo.Delete(ctx, stocks[0].ID); err != nil {
+			return fmt.Errorf("Delete: %w", err)
+		}
+		if stock, err := repo.GetByTicker(ctx, "MSFT"); stock != nil || err != nil {
+			return fmt.Errorf("GetByTicker after Delete = %v, %v; want nil, nil", stock, err)
+		}
+		if err := repo.Delete(ctx, stocks[0].ID); err != nil {
+			return fmt.Errorf("Delete of a missing ID = %v; want nil", err)
+		}
+
+		stocks, err = repo.GetAll(ctx)
+		if err != nil {
+			return fmt.Errorf("GetAll: %w", err)
+		}
+		return tickers(stocks, "AAPL")
+	}},
+
+	{"find filters and paginates", func(ctx context.Context, repo repository.StockRepository) error {
+		if err := createStocks(ctx, repo,
+			newStock("AAPL", "Broker A", day.Add(1*time.Hour)),
+			newStock("AAPL", "Broker B", day.Add(2*time.Hour)),
+			newStock("MSFT", "Broker A", day.Add(3*time.Hour)),
+			newStock("NVDA", "Broker A", day.Add(4*time.Hour)),
+			newStock("TSLA", "Broker B", day.Add(5*time.Hour)),
+		); err != nil {
+			return err
+		}
+
+		checks := []struct {
+			filter models.StockFilter
+			total  int64
+			want   []string
+		}{
+			{models.StockFilter{Limit: 2}, 5, []string{"TSLA", "NVDA"}},
+			{models.StockFilter{Limit: 2, Offset: 2}, 5, []string{"MSFT", "AAPL"}},
+			{models.StockFilter{Limit: 2, Offset: 4}, 5, []string{"AAPL"}},
+			{models.StockFilter{Limit: 2, Offset: 10}, 5, nil},
+			{models.StockFilter{Limit: 0}, 5, nil},
+			{models.StockFilter{Limit: -1, Offset: 3}, 5, []string{"AAPL", "AAPL"}},
+			{models.StockFilter{Ticker: "AAPL", Limit: 10}, 2, []string{"AAPL", "AAPL"}},
+			{models.StockFilter{Brokerage: "Broker B", Limit: 10}, 2, []string{"TSLA", "AAPL"}},
+			{models.StockFilter{Ticker: "AAPL", Brokerage: "Broker A", Limit: 10}, 1, []string{"AAPL"}},
+			{models.StockFilter{Ticker: "NONE", Limit: 10}, 0, nil},
+		}
+
+		for _, check := range checks {
+			stocks, total, err := repo.Find(ctx, check.filter)
+			if err != nil {
+				return fmt.Errorf("Find(%+v): %w", check.filter, err)
+			}
+			if total != check.total {
+				return fmt.Errorf("Find(%+v) total = %d; want %d", check.filter, total, check.total)
+			}
+			if err := tickers(stocks, check.want...); err != nil {
+				return fmt.Errorf("Find(%+v): %w", check.filter, err)
+			}
+		}
+		return nil
+	}},
+}
+
+// newStock crea un stock de prueba
+func newStock(ticker, brokerage string, at time.Time) models.Stock {
+	return models.Stock{
+		Ticker:     ticker,
+		Company:    ticker + " Inc.",
+		Brokerage:  brokerage,
+		Action:     "upgraded by",
+		RatingFrom: "Neutral",
+		RatingTo:   "Buy",
+		TargetFrom: "$150.00",
+		TargetTo:   "$180.00",
+		Time:       at,
+	}
+}
+
+// createStocks guarda los stocks en orden
+func createStocks(ctx context.Context, repo repository.StockRepository, stocks ...models.Stock) error {
+	for i := range stocks {
+		if err := repo.Create(ctx, &stocks[i]); err != nil {
+			return fmt.Errorf("Create %s: %w", stocks[i].Ticker, err)
+		}
+	}
+	return nil
+}
+
+// tickers comprueba los tickers de los stocks, en orden
+func tickers(stocks []models.Stock, want ...string) error {
+	got := make([]string, len(stocks))
+	for i, stock := range stocks {
+		got[i] = stock.Ticker
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		return fmt.Errorf("got tickers [%s]; want [%s]", strings.Join(got, " "), strings.Join(want, " "))
+	}
+	return nil
+}
+
+// sameStock comprueba que un stock leído tenga los datos del guardado
+func sameStock(got, want models.Stock) error {
+	if got.ID != want.ID || got.Ticker != want.Ticker || got.Company != want.Company ||
+		got.Brokerage != want.Brokerage || got.Action != want.Action ||
+		got.RatingFrom != want.RatingFrom || got.RatingTo != want.RatingTo ||
+		got.TargetFrom != want.TargetFrom || got.TargetTo != want.TargetTo ||
+		!got.Time.Equal(want.Time) {
+		return fmt.Errorf("got %+v; want %+v", got, want)
+	}
+	return nil
+}
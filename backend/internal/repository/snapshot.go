@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/liferip/stock-analyzer/backend/internal/models"
+	"github.com/liferip/stock-analyzer/backend/pkg/logger"
+)
+
+// SnapshotRepository interfaz que define las operaciones sobre las fotos de recomendaciones
+type SnapshotRepository interface {
+	ReplaceForDate(ctx context.Context, date time.Time, snapshots []models.RecommendationSnapshot) error
+	GetByDate(ctx context.Context, date time.Time) ([]models.RecommendationSnapshot, error)
+	GetDates(ctx context.Context) ([]time.Time, error)
+}
+
+// snapshotRepository implementación de SnapshotRepository con GORM
+type snapshotRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewSnapshotRepository crea una nueva instancia de SnapshotRepository
+func NewSnapshotRepository(db *gorm.DB, logger *zap.Logger) SnapshotRepository {
+	return &snapshotRepository{
+		db:     db,
+		logger: logger.Named("snapshot_repository"),
+	}
+}
+
+// ReplaceForDate reemplaza la foto de una fecha por una nueva
+func (r *snapshotRepository) ReplaceForDate(ctx context.Context, date time.Time, snapshots []models.RecommendationSnapshot) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.RecommendationSnapshot{}, "date = ?", date).Error; err != nil {
+			return err
+		}
+		if len(snapshots) == 0 {
+			return nil
+		}
+		return tx.Create(&snapshots).Error
+	})
+
+	if err != nil {
+		logger.Ctx(ctx, r.logger).Error("Error saving recommendation snapshot",
+			zap.Time("date", date),
+			zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// GetByDate obtiene la foto de una fecha ordenada por posición
+func (r *snapshotRepository) GetByDate(ctx context.Context, date time.Time) ([]models.RecommendationSnapshot, error) {
+	var snapshots []models.RecommendationSnapshot
+
+	result := r.db.WithContext(ctx).
+		Where("date = ?", date).
+		Order("rank ASC").
+		Find(&snapshots)
+
+	if result.Error != nil {
+		logger.Ctx(ctx, r.logger).Error("Error getting recommendation snapshot",
+			zap.Time("date", date),
+			zap.Error(result.Error))
+		return nil, result.Error
+	}
+
+	return snapshots, nil
+}
+
+// GetDates obtiene las fechas con foto guardada, de la más reciente a la más antigua
+func (r *snapshotRepository) GetDates(ctx context.Context) ([]time.Time, error) {
+	var dates []time.Time
+
+	result := r.db.WithContext(ctx).
+		Model(&models.RecommendationSnapshot{}).
+		Distinct("date").
+		Order("date DESC").
+		Pluck("date", &dates)
+
+	if result.Error != nil {
+		logger.Ctx(ctx, r.logger).Error("Error getting snapshot dates", zap.Error(result.Error))
+		return nil, result.Error
+	}
+
+	return dates, nil
+}
@@ -3,21 +3,38 @@ package repository
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 
+	"github.com/liferip/stock-analyzer/backend/config"
+	"github.com/liferip/stock-analyzer/backend/db"
 	"github.com/liferip/stock-analyzer/backend/internal/models"
+	"github.com/liferip/stock-analyzer/backend/pkg/logger"
 )
 
 // Module proporciona las dependencias del repositorio
-var Module = fx.Provide(NewStockRepository)
+var Module = fx.Provide(
+	SelectStockRepository,
+	NewPriceRepository,
+	NewEventRepository,
+	NewTaxonomyRepository,
+	NewSnapshotRepository,
+	NewAlertRepository,
+	NewWebhookRepository,
+	NewDigestRepository,
+	NewAPIKeyRepository,
+	NewSyncRunRepository,
+)
 
 // StockRepository interfaz que define las operaciones del repositorio
 type StockRepository interface {
 	GetAll(ctx context.Context) ([]models.Stock, error)
 	GetAllByTime(ctx context.Context, time string) ([]models.Stock, error)
+	Find(ctx context.Context, filter models.StockFilter) ([]models.Stock, int64, error)
 	GetByTicker(ctx context.Context, ticker string) (*models.Stock, error)
 	GetByTickerSimple(ctx context.Context, ticker string) (*models.Stock, error)
 	Create(ctx context.Context, stock *models.Stock) error
@@ -39,6 +56,15 @@ func NewStockRepository(db *gorm.DB, logger *zap.Logger) StockRepository {
 	}
 }
 
+// SelectStockRepository crea el StockRepository de DATABASE_DRIVER: en memoria con memory, o
+// con GORM sobre la base de datos configurada
+func SelectStockRepository(cfg *config.Config, database *gorm.DB, logger *zap.Logger) StockRepository {
+	if cfg.DatabaseDriver == db.DriverMemory {
+		return NewMemoryStockRepository(logger)
+	}
+	return NewStockRepository(database, logger)
+}
+
 // GetAll obtiene todos los stocks de la base de datos
 func (r *stockRepository) GetAll(ctx context.Context) ([]models.Stock, error) {
 	var stocks []models.Stock
@@ -48,7 +74,7 @@ func (r *stockRepository) GetAll(ctx context.Context) ([]models.Stock, error) {
 		Find(&stocks)
 
 	if result.Error != nil {
-		r.logger.Error("Error getting stocks", zap.Error(result.Error))
+		logger.Ctx(ctx, r.logger).Error("Error getting stocks", zap.Error(result.Error))
 		return nil, result.Error
 	}
 
@@ -68,7 +94,7 @@ func (r *stockRepository) GetByTicker(ctx context.Context, ticker string) (*mode
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return nil, nil
 		}
-		r.logger.Error("Error getting stock by ticker",
+		logger.Ctx(ctx, r.logger).Error("Error getting stock by ticker",
 			zap.String("ticker", ticker),
 			zap.Error(result.Error))
 		return nil, result.Error
@@ -91,7 +117,7 @@ func (r *stockRepository) GetByTickerSimple(ctx context.Context, ticker string)
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return nil, nil
 		}
-		r.logger.Error("Error getting stock by ticker",
+		logger.Ctx(ctx, r.logger).Error("Error getting stock by ticker",
 			zap.String("ticker", ticker),
 			zap.Error(result.Error))
 		return nil, result.Error
@@ -104,13 +130,22 @@ func (r *stockRepository) GetByTickerSimple(ctx context.Context, ticker string)
 func (r *stockRepository) GetAllByTime(ctx context.Context, time string) ([]models.Stock, error) {
 	var stocks []models.Stock
 
+	start, end, err := dayRange(time)
+	if err != nil {
+		logger.Ctx(ctx, r.logger).Error("Error getting stocks by date",
+			zap.String("time", time),
+			zap.Error(err))
+		return nil, err
+	}
+
+	// Los límites se pasan como fechas y no como texto, porque SQLite compara las fechas como texto
 	result := r.db.WithContext(ctx).
-		Where("time BETWEEN ? AND ?", time+" 00:00:00", time+" 23:59:59").
+		Where("time BETWEEN ? AND ?", start, end).
 		Order("time DESC").
 		Find(&stocks)
 
 	if result.Error != nil {
-		r.logger.Error("Error getting stocks by date",
+		logger.Ctx(ctx, r.logger).Error("Error getting stocks by date",
 			zap.String("time", time),
 			zap.Error(result.Error))
 		return nil, result.Error
@@ -119,12 +154,21 @@ func (r *stockRepository) GetAllByTime(ctx context.Context, time string) ([]mode
 	return stocks, nil
 }
 
+// dayRange obtiene el primer y el último segundo de una fecha con el formato 2006-01-02, en UTC
+func dayRange(date string) (time.Time, time.Time, error) {
+	start, err := time.Parse(time.DateOnly, date)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid date %q: %w", date, err)
+	}
+	return start, start.Add(24*time.Hour - time.Second), nil
+}
+
 // Create crea un nuevo stock en la base de datos
 func (r *stockRepository) Create(ctx context.Context, stock *models.Stock) error {
 	result := r.db.WithContext(ctx).Create(stock)
 
 	if result.Error != nil {
-		r.logger.Error("Error creating stock",
+		logger.Ctx(ctx, r.logger).Error("Error creating stock",
 			zap.String("ticker", stock.Ticker),
 			zap.Error(result.Error))
 		return result.Error
@@ -138,7 +182,7 @@ func (r *stockRepository) Update(ctx context.Context, stock *models.Stock) error
 	result := r.db.WithContext(ctx).Save(stock)
 
 	if result.Error != nil {
-		r.logger.Error("Error updating stock",
+		logger.Ctx(ctx, r.logger).Error("Error updating stock",
 			zap.String("id", stock.ID),
 			zap.Error(result.Error))
 		return result.Error
@@ -153,7 +197,7 @@ func (r *stockRepository) Delete(ctx context.Context, id string) error {
 		Delete(&models.Stock{}, "id = ?", id)
 
 	if result.Error != nil {
-		r.logger.Error("Error deleting stock",
+		logger.Ctx(ctx, r.logger).Error("Error deleting stock",
 			zap.String("id", id),
 			zap.Error(result.Error))
 		return result.Error
@@ -161,3 +205,35 @@ func (r *stockRepository) Delete(ctx context.Context, id string) error {
 
 	return nil
 }
+
+// Find obtiene una página de stocks que cumplen los filtros, junto con el total sin paginar
+func (r *stockRepository) Find(ctx context.Context, filter models.StockFilter) ([]models.Stock, int64, error) {
+	var stocks []models.Stock
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&models.Stock{})
+	if filter.Ticker != "" {
+		query = query.Where("ticker = ?", filter.Ticker)
+	}
+	if filter.Brokerage != "" {
+		query = query.Where("brokerage = ?", filter.Brokerage)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		logger.Ctx(ctx, r.logger).Error("Error counting stocks", zap.Error(err))
+		return nil, 0, err
+	}
+
+	result := query.
+		Order("time DESC").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(&stocks)
+
+	if result.Error != nil {
+		logger.Ctx(ctx, r.logger).Error("Error finding stocks", zap.Error(result.Error))
+		return nil, 0, result.Error
+	}
+
+	return stocks, total, nil
+}
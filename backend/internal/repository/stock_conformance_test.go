@@ -0,0 +1,97 @@
+package repository_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.uber.org/zap"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+
+	"github.com/liferip/stock-analyzer/backend/db"
+	"github.com/liferip/stock-analyzer/backend/internal/models"
+	"github.com/liferip/stock-analyzer/backend/internal/repository"
+	"github.com/liferip/stock-analyzer/backend/internal/repository/repotest"
+)
+
+// postgresDSNEnv es la variable con la URL de una base de datos Postgres o CockroachDB de
+// prueba; sin ella se omite el caso. Los stocks de esa base de datos se borran.
+const postgresDSNEnv = "TEST_DATABASE_URL"
+
+// quietGorm no registra las consultas de GORM en los tests
+var quietGorm = &gorm.Config{Logger: gormlogger.Discard}
+
+func TestStockRepositoryConformance(t *testing.T) {
+	tests := []struct {
+		name    string
+		newRepo func(t *testing.T) repotest.NewStockRepository
+	}{
+		{"memory", func(t *testing.T) repotest.NewStockRepository {
+			return func() (repository.StockRepository, error) {
+				return repository.NewMemoryStockRepository(zap.NewNop()), nil
+			}
+		}},
+		{"sqlite", newSQLiteStockRepository},
+		{"postgres", newPostgresStockRepository},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := repotest.StockRepository(context.Background(), tt.newRepo(t)); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+// newSQLiteStockRepository crea cada repositorio en un archivo SQLite nuevo
+func newSQLiteStockRepository(t *testing.T) repotest.NewStockRepository {
+	dir := t.TempDir()
+	count := 0
+
+	return func() (repository.StockRepository, error) {
+		count++
+		database, err := db.NewSQLite(filepath.Join(dir, fmt.Sprintf("stocks-%d.db", count)), quietGorm, zap.NewNop())
+		if err != nil {
+			return nil, err
+		}
+		t.Cleanup(func() {
+			if sqlDB, err := database.DB(); err == nil {
+				sqlDB.Close()
+			}
+		})
+		return repository.NewStockRepository(database, zap.NewNop()), nil
+	}
+}
+
+// newPostgresStockRepository vacía la tabla de stocks de TEST_DATABASE_URL antes de cada repositorio
+func newPostgresStockRepository(t *testing.T) repotest.NewStockRepository {
+	dsn := os.Getenv(postgresDSNEnv)
+	if dsn == "" {
+		t.Skip(postgresDSNEnv + " is not set")
+	}
+
+	database, err := gorm.Open(postgres.Open(dsn), quietGorm)
+	if err != nil {
+		t.Fatalf("error connecting to %s: %v", postgresDSNEnv, err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := database.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	if err := database.AutoMigrate(&models.Stock{}); err != nil {
+		t.Fatalf("error migrating stocks: %v", err)
+	}
+
+	return func() (repository.StockRepository, error) {
+		if err := database.Session(&gorm.Session{AllowGlobalUpdate: true}).Delete(&models.Stock{}).Error; err != nil {
+			return nil, err
+		}
+		return repository.NewStockRepository(database, zap.NewNop()), nil
+	}
+}
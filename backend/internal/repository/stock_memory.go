@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/liferip/stock-analyzer/backend/internal/models"
+	"github.com/liferip/stock-analyzer/backend/pkg/logger"
+)
+
+// memoryStockRepository implementación de StockRepository en memoria, para desarrollo local.
+// Se comporta como stockRepository: mismo orden, nil sin error si no hay resultados y las
+// mismas reglas de paginación.
+type memoryStockRepository struct {
+	logger *zap.Logger
+	now    func() time.Time
+
+	mu sync.RWMutex
+	// stocks se guardan en orden de creación, para desempatar igual en cada consulta
+	stocks []models.Stock
+}
+
+// NewMemoryStockRepository crea una nueva instancia de StockRepository en memoria
+func NewMemoryStockRepository(logger *zap.Logger) StockRepository {
+	return &memoryStockRepository{
+		logger: logger.Named("stock_repository"),
+		now:    time.Now,
+	}
+}
+
+// GetAll obtiene todos los stocks
+func (r *memoryStockRepository) GetAll(ctx context.Context) ([]models.Stock, error) {
+	return r.filter(func(models.Stock) bool { return true }), nil
+}
+
+// GetByTicker obtiene el último stock de un ticker
+func (r *memoryStockRepository) GetByTicker(ctx context.Context, ticker string) (*models.Stock, error) {
+	stocks := r.filter(func(s models.Stock) bool { return s.Ticker == ticker })
+	if len(stocks) == 0 {
+		return nil, nil
+	}
+	return &stocks[0], nil
+}
+
+// GetByTickerSimple obtiene el time e ID del último stock de un ticker
+func (r *memoryStockRepository) GetByTickerSimple(ctx context.Context, ticker string) (*models.Stock, error) {
+	stock, err := r.GetByTicker(ctx, ticker)
+	if stock == nil || err != nil {
+		return stock, err
+	}
+	return &models.Stock{ID: stock.ID, Time: stock.Time}, nil
+}
+
+// GetAllByTime obtiene los stocks de una fecha con el formato 2006-01-02, en UTC
+func (r *memoryStockRepository) GetAllByTime(ctx context.Context, date string) ([]models.Stock, error) {
+	start, end, err := dayRange(date)
+	if err != nil {
+		logger.Ctx(ctx, r.logger).Error("Error getting stocks by date",
+			zap.String("time", date),
+			zap.Error(err))
+		return nil, err
+	}
+
+	return r.filter(func(s models.Stock) bool {
+		return !s.Time.Before(start) && !s.Time.After(end)
+	}), nil
+}
+
+// Create guarda un nuevo stock, con un ID nuevo si no tiene
+func (r *memoryStockRepository) Create(ctx context.Context, stock *models.Stock) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if stock.ID == "" {
+		stock.ID = uuid.New().String()
+	}
+	if r.indexOf(stock.ID) >= 0 {
+		logger.Ctx(ctx, r.logger).Error("Error creating stock",
+			zap.String("ticker", stock.Ticker),
+			zap.Error(gorm.ErrDuplicatedKey))
+		return gorm.ErrDuplicatedKey
+	}
+
+	now := r.now()
+	if stock.CreatedAt.IsZero() {
+		stock.CreatedAt = now
+	}
+	if stock.UpdatedAt.IsZero() {
+		stock.UpdatedAt = now
+	}
+
+	r.stocks = append(r.stocks, *stock)
+	return nil
+}
+
+// Update guarda todos los campos de un stock; si no existe, lo crea, igual que Save de GORM
+func (r *memoryStockRepository) Update(ctx context.Context, stock *models.Stock) error {
+	if stock.ID == "" {
+		return r.Create(ctx, stock)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stock.UpdatedAt = r.now()
+	if i := r.indexOf(stock.ID); i >= 0 {
+		r.stocks[i] = *stock
+		return nil
+	}
+
+	if stock.CreatedAt.IsZero() {
+		stock.CreatedAt = stock.UpdatedAt
+	}
+	r.stocks = append(r.stocks, *stock)
+	return nil
+}
+
+// Delete elimina un stock por su ID; no es un error si no existe
+func (r *memoryStockRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if i := r.indexOf(id); i >= 0 {
+		r.stocks = append(r.stocks[:i], r.stocks[i+1:]...)
+	}
+	return nil
+}
+
+// Find obtiene una página de stocks que cumplen los filtros, junto con el total sin paginar.
+// Como LIMIT, un límite negativo no limita y cero no devuelve resultados.
+func (r *memoryStockRepository) Find(ctx context.Context, filter models.StockFilter) ([]models.Stock, int64, error) {
+	stocks := r.filter(func(s models.Stock) bool {
+		return (filter.Ticker == "" || s.Ticker == filter.Ticker) &&
+			(filter.Brokerage == "" || s.Brokerage == filter.Brokerage)
+	})
+	total := int64(len(stocks))
+
+	if filter.Offset > 0 {
+		if filter.Offset >= len(stocks) {
+			return []models.Stock{}, total, nil
+		}
+		stocks = stocks[filter.Offset:]
+	}
+	if filter.Limit >= 0 && filter.Limit < len(stocks) {
+		stocks = stocks[:filter.Limit]
+	}
+
+	return stocks, total, nil
+}
+
+// filter obtiene una copia de los stocks que cumplen la condición, del más reciente al más antiguo
+func (r *memoryStockRepository) filter(match func(models.Stock) bool) []models.Stock {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stocks := make([]models.Stock, 0, len(r.stocks))
+	for _, stock := range r.stocks {
+		if match(stock) {
+			stocks = append(stocks, stock)
+		}
+	}
+
+	sort.SliceStable(stocks, func(i, j int) bool {
+		return stocks[i].Time.After(stocks[j].Time)
+	})
+	return stocks
+}
+
+// indexOf obtiene la posición de un stock por su ID, o -1 si no existe. Requiere el lock.
+func (r *memoryStockRepository) indexOf(id string) int {
+	for i, stock := range r.stocks {
+		if stock.ID == id {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/liferip/stock-analyzer/backend/internal/models"
+	"github.com/liferip/stock-analyzer/backend/pkg/logger"
+)
+
+// SyncRunRepository interfaz que define las operaciones sobre el historial de sincronizaciones
+type SyncRunRepository interface {
+	GetLastSuccessful(ctx context.Context) (*models.SyncRun, error)
+	Create(ctx context.Context, run *models.SyncRun) error
+}
+
+// syncRunRepository implementación de SyncRunRepository con GORM
+type syncRunRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewSyncRunRepository crea una nueva instancia de SyncRunRepository
+func NewSyncRunRepository(db *gorm.DB, logger *zap.Logger) SyncRunRepository {
+	return &syncRunRepository{
+		db:     db,
+		logger: logger.Named("sync_run_repository"),
+	}
+}
+
+// GetLastSuccessful obtiene la última sincronización exitosa, o nil si nunca hubo una
+func (r *syncRunRepository) GetLastSuccessful(ctx context.Context) (*models.SyncRun, error) {
+	var run models.SyncRun
+
+	result := r.db.WithContext(ctx).
+		Where("succeeded = ?", true).
+		Order("finished_at DESC").
+		First(&run)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		logger.Ctx(ctx, r.logger).Error("Error getting last successful sync run", zap.Error(result.Error))
+		return nil, result.Error
+	}
+
+	return &run, nil
+}
+
+// Create registra una sincronización
+func (r *syncRunRepository) Create(ctx context.Context, run *models.SyncRun) error {
+	result := r.db.WithContext(ctx).Create(run)
+
+	if result.Error != nil {
+		logger.Ctx(ctx, r.logger).Error("Error creating sync run", zap.Error(result.Error))
+		return result.Error
+	}
+
+	return nil
+}
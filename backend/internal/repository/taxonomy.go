@@ -0,0 +1,259 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/liferip/stock-analyzer/backend/internal/models"
+	"github.com/liferip/stock-analyzer/backend/pkg/logger"
+)
+
+// ErrDuplicate indica que el registro viola una restricción única, por ejemplo porque otra
+// solicitud creó la misma etiqueta o alias al mismo tiempo
+var ErrDuplicate = gorm.ErrDuplicatedKey
+
+// TaxonomyRepository interfaz que define las operaciones sobre la taxonomía de calificaciones y acciones
+type TaxonomyRepository interface {
+	CountLabels(ctx context.Context) (int64, error)
+	GetLabels(ctx context.Context, kind string) ([]models.TaxonomyLabel, error)
+	GetLabelByID(ctx context.Context, id string) (*models.TaxonomyLabel, error)
+	CreateLabels(ctx context.Context, labels []models.TaxonomyLabel) error
+	UpdateLabel(ctx context.Context, label *models.TaxonomyLabel) error
+	DeleteLabel(ctx context.Context, id string) error
+	GetAliasByID(ctx context.Context, id string) (*models.TaxonomyAlias, error)
+	CreateAlias(ctx context.Context, alias *models.TaxonomyAlias) error
+	DeleteAlias(ctx context.Context, id string) error
+	RecordUnmapped(ctx context.Context, kind, label string, seenAt time.Time) error
+	GetUnmapped(ctx context.Context, kind string) ([]models.UnmappedLabel, error)
+}
+
+// taxonomyRepository implementación de TaxonomyRepository con GORM
+type taxonomyRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewTaxonomyRepository crea una nueva instancia de TaxonomyRepository
+func NewTaxonomyRepository(db *gorm.DB, logger *zap.Logger) TaxonomyRepository {
+	return &taxonomyRepository{
+		db:     db,
+		logger: logger.Named("taxonomy_repository"),
+	}
+}
+
+// CountLabels cuenta las etiquetas canónicas registradas
+func (r *taxonomyRepository) CountLabels(ctx context.Context) (int64, error) {
+	var count int64
+
+	result := r.db.WithContext(ctx).
+		Model(&models.TaxonomyLabel{}).
+		Count(&count)
+
+	if result.Error != nil {
+		logger.Ctx(ctx, r.logger).Error("Error counting taxonomy labels", zap.Error(result.Error))
+		return 0, result.Error
+	}
+
+	return count, nil
+}
+
+// GetLabels obtiene las etiquetas canónicas con sus alias, filtradas por tipo si se indica
+func (r *taxonomyRepository) GetLabels(ctx context.Context, kind string) ([]models.TaxonomyLabel, error) {
+	var labels []models.TaxonomyLabel
+
+	query := r.db.WithContext(ctx).
+		Preload("Aliases", func(db *gorm.DB) *gorm.DB {
+			return db.Order("alias ASC")
+		}).
+		Order("kind ASC, score DESC, name ASC")
+	if kind != "" {
+		query = query.Where("kind = ?", kind)
+	}
+
+	result := query.Find(&labels)
+	if result.Error != nil {
+		logger.Ctx(ctx, r.logger).Error("Error getting taxonomy labels",
+			zap.String("kind", kind),
+			zap.Error(result.Error))
+		return nil, result.Error
+	}
+
+	return labels, nil
+}
+
+// GetLabelByID obtiene una etiqueta canónica por su ID
+func (r *taxonomyRepository) GetLabelByID(ctx context.Context, id string) (*models.TaxonomyLabel, error) {
+	var label models.TaxonomyLabel
+
+	result := r.db.WithContext(ctx).
+		Preload("Aliases").
+		Where("id = ?", id).
+		First(&label)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		logger.Ctx(ctx, r.logger).Error("Error getting taxonomy label",
+			zap.String("id", id),
+			zap.Error(result.Error))
+		return nil, result.Error
+	}
+
+	return &label, nil
+}
+
+// CreateLabels crea etiquetas canónicas junto con sus alias
+func (r *taxonomyRepository) CreateLabels(ctx context.Context, labels []models.TaxonomyLabel) error {
+	result := r.db.WithContext(ctx).Create(&labels)
+
+	if result.Error != nil {
+		logger.Ctx(ctx, r.logger).Error("Error creating taxonomy labels",
+			zap.Int("count", len(labels)),
+			zap.Error(result.Error))
+		return result.Error
+	}
+
+	return nil
+}
+
+// UpdateLabel actualiza el nombre y la puntuación de una etiqueta canónica
+func (r *taxonomyRepository) UpdateLabel(ctx context.Context, label *models.TaxonomyLabel) error {
+	result := r.db.WithContext(ctx).
+		Model(label).
+		Select("name", "score", "updated_at").
+		Updates(label)
+
+	if result.Error != nil {
+		logger.Ctx(ctx, r.logger).Error("Error updating taxonomy label",
+			zap.String("id", label.ID),
+			zap.Error(result.Error))
+		return result.Error
+	}
+
+	return nil
+}
+
+// DeleteLabel elimina una etiqueta canónica y sus alias
+func (r *taxonomyRepository) DeleteLabel(ctx context.Context, id string) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.TaxonomyAlias{}, "label_id = ?", id).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.TaxonomyLabel{}, "id = ?", id).Error
+	})
+
+	if err != nil {
+		logger.Ctx(ctx, r.logger).Error("Error deleting taxonomy label",
+			zap.String("id", id),
+			zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// GetAliasByID obtiene un alias por su ID
+func (r *taxonomyRepository) GetAliasByID(ctx context.Context, id string) (*models.TaxonomyAlias, error) {
+	var alias models.TaxonomyAlias
+
+	result := r.db.WithContext(ctx).
+		Where("id = ?", id).
+		First(&alias)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		logger.Ctx(ctx, r.logger).Error("Error getting taxonomy alias",
+			zap.String("id", id),
+			zap.Error(result.Error))
+		return nil, result.Error
+	}
+
+	return &alias, nil
+}
+
+// CreateAlias crea un alias de una etiqueta canónica
+func (r *taxonomyRepository) CreateAlias(ctx context.Context, alias *models.TaxonomyAlias) error {
+	result := r.db.WithContext(ctx).Create(alias)
+
+	if result.Error != nil {
+		logger.Ctx(ctx, r.logger).Error("Error creating taxonomy alias",
+			zap.String("alias", alias.Alias),
+			zap.Error(result.Error))
+		return result.Error
+	}
+
+	return nil
+}
+
+// DeleteAlias elimina un alias por su ID
+func (r *taxonomyRepository) DeleteAlias(ctx context.Context, id string) error {
+	result := r.db.WithContext(ctx).
+		Delete(&models.TaxonomyAlias{}, "id = ?", id)
+
+	if result.Error != nil {
+		logger.Ctx(ctx, r.logger).Error("Error deleting taxonomy alias",
+			zap.String("id", id),
+			zap.Error(result.Error))
+		return result.Error
+	}
+
+	return nil
+}
+
+// RecordUnmapped registra una aparición de una etiqueta sin puntuación asignada
+func (r *taxonomyRepository) RecordUnmapped(ctx context.Context, kind, label string, seenAt time.Time) error {
+	unmapped := models.UnmappedLabel{
+		Kind:        kind,
+		Label:       label,
+		Occurrences: 1,
+		FirstSeen:   seenAt,
+		LastSeen:    seenAt,
+	}
+
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "kind"}, {Name: "label"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"occurrences": gorm.Expr("unmapped_labels.occurrences + 1"),
+				"last_seen":   seenAt,
+			}),
+		}).
+		Create(&unmapped)
+
+	if result.Error != nil {
+		logger.Ctx(ctx, r.logger).Error("Error recording unmapped label",
+			zap.String("kind", kind),
+			zap.String("label", label),
+			zap.Error(result.Error))
+		return result.Error
+	}
+
+	return nil
+}
+
+// GetUnmapped obtiene las etiquetas sin puntuación, de la más frecuente a la menos frecuente
+func (r *taxonomyRepository) GetUnmapped(ctx context.Context, kind string) ([]models.UnmappedLabel, error) {
+	var labels []models.UnmappedLabel
+
+	result := r.db.WithContext(ctx).
+		Where("kind = ?", kind).
+		Order("occurrences DESC, label ASC").
+		Find(&labels)
+
+	if result.Error != nil {
+		logger.Ctx(ctx, r.logger).Error("Error getting unmapped labels",
+			zap.String("kind", kind),
+			zap.Error(result.Error))
+		return nil, result.Error
+	}
+
+	return labels, nil
+}
@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/liferip/stock-analyzer/backend/internal/models"
+	"github.com/liferip/stock-analyzer/backend/pkg/logger"
+)
+
+// WebhookRepository interfaz que define las operaciones sobre webhooks y sus entregas
+type WebhookRepository interface {
+	GetWebhooks(ctx context.Context) ([]models.Webhook, error)
+	GetWebhookByID(ctx context.Context, id string) (*models.Webhook, error)
+	CreateWebhook(ctx context.Context, webhook *models.Webhook) error
+	UpdateWebhook(ctx context.Context, webhook *models.Webhook) error
+	DeleteWebhook(ctx context.Context, id string) error
+	CreateDeliveries(ctx context.Context, deliveries []models.WebhookDelivery) error
+	UpdateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error
+	GetDueDeliveries(ctx context.Context, now time.Time, limit int) ([]models.WebhookDelivery, error)
+	GetDeliveries(ctx context.Context, filter models.WebhookDeliveryFilter) ([]models.WebhookDelivery, error)
+}
+
+// webhookRepository implementación de WebhookRepository con GORM
+type webhookRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewWebhookRepository crea una nueva instancia de WebhookRepository
+func NewWebhookRepository(db *gorm.DB, logger *zap.Logger) WebhookRepository {
+	return &webhookRepository{
+		db:     db,
+		logger: logger.Named("webhook_repository"),
+	}
+}
+
+// GetWebhooks obtiene todos los webhooks registrados
+func (r *webhookRepository) GetWebhooks(ctx context.Context) ([]models.Webhook, error) {
+	var webhooks []models.Webhook
+
+	result := r.db.WithContext(ctx).
+		Order("created_at ASC").
+		Find(&webhooks)
+
+	if result.Error != nil {
+		logger.Ctx(ctx, r.logger).Error("Error getting webhooks", zap.Error(result.Error))
+		return nil, result.Error
+	}
+
+	return webhooks, nil
+}
+
+// GetWebhookByID obtiene un webhook por su ID
+func (r *webhookRepository) GetWebhookByID(ctx context.Context, id string) (*models.Webhook, error) {
+	var webhook models.Webhook
+
+	result := r.db.WithContext(ctx).
+		Where("id = ?", id).
+		First(&webhook)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		logger.Ctx(ctx, r.logger).Error("Error getting webhook",
+			zap.String("id", id),
+			zap.Error(result.Error))
+		return nil, result.Error
+	}
+
+	return &webhook, nil
+}
+
+// CreateWebhook registra un webhook
+func (r *webhookRepository) CreateWebhook(ctx context.Context, webhook *models.Webhook) error {
+	result := r.db.WithContext(ctx).Create(webhook)
+
+	if result.Error != nil {
+		logger.Ctx(ctx, r.logger).Error("Error creating webhook",
+			zap.String("url", webhook.URL),
+			zap.Error(result.Error))
+		return result.Error
+	}
+
+	return nil
+}
+
+// UpdateWebhook actualiza un webhook existente
+func (r *webhookRepository) UpdateWebhook(ctx context.Context, webhook *models.Webhook) error {
+	result := r.db.WithContext(ctx).Save(webhook)
+
+	if result.Error != nil {
+		logger.Ctx(ctx, r.logger).Error("Error updating webhook",
+			zap.String("id", webhook.ID),
+			zap.Error(result.Error))
+		return result.Error
+	}
+
+	return nil
+}
+
+// DeleteWebhook elimina un webhook y descarta sus entregas pendientes
+func (r *webhookRepository) DeleteWebhook(ctx context.Context, id string) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("webhook_id = ? AND status = ?", id, models.WebhookDeliveryPending).
+			Delete(&models.WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Webhook{}, "id = ?", id).Error
+	})
+
+	if err != nil {
+		logger.Ctx(ctx, r.logger).Error("Error deleting webhook",
+			zap.String("id", id),
+			zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// CreateDeliveries encola entregas de un evento
+func (r *webhookRepository) CreateDeliveries(ctx context.Context, deliveries []models.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	result := r.db.WithContext(ctx).Create(&deliveries)
+
+	if result.Error != nil {
+		logger.Ctx(ctx, r.logger).Error("Error creating webhook deliveries",
+			zap.Int("count", len(deliveries)),
+			zap.Error(result.Error))
+		return result.Error
+	}
+
+	return nil
+}
+
+// UpdateDelivery guarda el resultado de un intento de entrega
+func (r *webhookRepository) UpdateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	result := r.db.WithContext(ctx).Save(delivery)
+
+	if result.Error != nil {
+		logger.Ctx(ctx, r.logger).Error("Error updating webhook delivery",
+			zap.String("id", delivery.ID),
+			zap.Error(result.Error))
+		return result.Error
+	}
+
+	return nil
+}
+
+// GetDueDeliveries obtiene las entregas pendientes cuyo próximo intento ya venció, de la más antigua a la más nueva
+func (r *webhookRepository) GetDueDeliveries(ctx context.Context, now time.Time, limit int) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+
+	result := r.db.WithContext(ctx).
+		Where("status = ? AND next_attempt_at <= ?", models.WebhookDeliveryPending, now).
+		Order("next_attempt_at ASC").
+		Limit(limit).
+		Find(&deliveries)
+
+	if result.Error != nil {
+		logger.Ctx(ctx, r.logger).Error("Error getting due webhook deliveries", zap.Error(result.Error))
+		return nil, result.Error
+	}
+
+	return deliveries, nil
+}
+
+// GetDeliveries obtiene el registro de entregas, de la más reciente a la más antigua
+func (r *webhookRepository) GetDeliveries(ctx context.Context, filter models.WebhookDeliveryFilter) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+
+	query := r.db.WithContext(ctx).
+		Order("created_at DESC").
+		Limit(filter.Limit)
+	if filter.WebhookID != "" {
+		query = query.Where("webhook_id = ?", filter.WebhookID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	result := query.Find(&deliveries)
+	if result.Error != nil {
+		logger.Ctx(ctx, r.logger).Error("Error getting webhook deliveries", zap.Error(result.Error))
+		return nil, result.Error
+	}
+
+	return deliveries, nil
+}
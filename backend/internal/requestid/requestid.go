@@ -0,0 +1,46 @@
+// Package requestid identifica cada solicitud para correlacionar respuestas, errores y logs.
+package requestid
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Header es el header HTTP con el ID de la solicitud
+const Header = "X-Request-ID"
+
+// maxLength es la longitud máxima de un ID recibido del cliente
+const maxLength = 128
+
+type requestIDKey struct{}
+
+// New genera un ID de solicitud
+func New() string {
+	return uuid.NewString()
+}
+
+// Valid indica si un ID recibido del cliente se puede reutilizar: no vacío, de longitud
+// acotada y solo con caracteres visibles, para que no se pueda inyectar en headers o logs
+func Valid(id string) bool {
+	if id == "" || len(id) > maxLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < '!' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// WithRequestID devuelve un contexto con el ID de la solicitud
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// FromContext obtiene el ID de la solicitud del contexto, o una cadena vacía si no hay
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
@@ -0,0 +1,319 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/liferip/stock-analyzer/backend/internal/events"
+	"github.com/liferip/stock-analyzer/backend/internal/models"
+	"github.com/liferip/stock-analyzer/backend/internal/repository"
+	"github.com/liferip/stock-analyzer/backend/pkg/logger"
+)
+
+const (
+	// defaultAlertEventLimit es la cantidad de alertas devueltas por defecto
+	defaultAlertEventLimit = 100
+	// maxAlertEventLimit es la cantidad máxima de alertas devueltas en una consulta
+	maxAlertEventLimit = 1000
+	// alertEngineTimeout es el tiempo máximo para evaluar las reglas sobre un stock guardado
+	alertEngineTimeout = 30 * time.Second
+)
+
+// AlertService interfaz que define las operaciones sobre reglas y eventos de alerta
+type AlertService interface {
+	ListRules(ctx context.Context) ([]models.AlertRule, error)
+	GetRule(ctx context.Context, id string) (*models.AlertRule, error)
+	CreateRule(ctx context.Context, input models.AlertRuleInput) (*models.AlertRule, error)
+	UpdateRule(ctx context.Context, id string, input models.AlertRuleInput) (*models.AlertRule, error)
+	DeleteRule(ctx context.Context, id string) error
+	ListEvents(ctx context.Context, filter models.AlertEventFilter) ([]models.AlertEvent, error)
+	Evaluate(ctx context.Context, stock *models.Stock) ([]models.AlertEvent, error)
+}
+
+// alertService implementación de AlertService con las reglas activas en memoria
+type alertService struct {
+	repo   repository.AlertRepository
+	bus    *events.Bus
+	logger *zap.Logger
+
+	mu    sync.RWMutex
+	rules []models.AlertRule
+}
+
+// NewAlertService crea una nueva instancia de AlertService
+func NewAlertService(repo repository.AlertRepository, bus *events.Bus, logger *zap.Logger) (AlertService, error) {
+	s := &alertService{
+		repo:   repo,
+		bus:    bus,
+		logger: logger.Named("alert_service"),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := s.reload(ctx); err != nil {
+		return nil, fmt.Errorf("error loading alert rules: %w", err)
+	}
+
+	return s, nil
+}
+
+// RegisterAlertEngine evalúa las reglas de alerta sobre cada stock creado o actualizado por la
+// sincronización, en segundo plano para no demorarla ni perder alertas si se cancela la solicitud
+func RegisterAlertEngine(bus *events.Bus, alertService AlertService, logger *zap.Logger) {
+	logger = logger.Named("alert_engine")
+
+	bus.SubscribeAsync(alertEngineTimeout, func(ctx context.Context, event events.Event) {
+		if event.Type != events.StockSaved {
+			return
+		}
+
+		payload := event.Payload.(events.StockSavedPayload)
+		if _, err := alertService.Evaluate(ctx, &payload.Stock); err != nil {
+			logger.Error("Error evaluating alert rules",
+				zap.String("ticker", payload.Stock.Ticker),
+				zap.Error(err))
+		}
+	})
+}
+
+// reload vuelve a cargar las reglas activas en memoria
+func (s *alertService) reload(ctx context.Context) error {
+	rules, err := s.repo.GetRules(ctx)
+	if err != nil {
+		return err
+	}
+
+	enabled := make([]models.AlertRule, 0, len(rules))
+	for _, rule := range rules {
+		if rule.Enabled {
+			enabled = append(enabled, rule)
+		}
+	}
+
+	s.mu.Lock()
+	s.rules = enabled
+	s.mu.Unlock()
+
+	return nil
+}
+
+// ListRules obtiene todas las reglas de alerta
+func (s *alertService) ListRules(ctx context.Context) ([]models.AlertRule, error) {
+	return s.repo.GetRules(ctx)
+}
+
+// GetRule obtiene una regla de alerta, o ErrNotFound si no existe
+func (s *alertService) GetRule(ctx context.Context, id string) (*models.AlertRule, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, fmt.Errorf("%w: alert rule %s", ErrNotFound, id)
+	}
+
+	rule, err := s.repo.GetRuleByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if rule == nil {
+		return nil, fmt.Errorf("%w: alert rule %s", ErrNotFound, id)
+	}
+
+	return rule, nil
+}
+
+// CreateRule crea una regla de alerta
+func (s *alertService) CreateRule(ctx context.Context, input models.AlertRuleInput) (*models.AlertRule, error) {
+	rule := &models.AlertRule{Enabled: true}
+	if err := applyAlertRuleInput(rule, input); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreateRule(ctx, rule); err != nil {
+		return nil, fmt.Errorf("error creating alert rule: %w", err)
+	}
+
+	return rule, s.reload(ctx)
+}
+
+// UpdateRule reemplaza las condiciones de una regla de alerta
+func (s *alertService) UpdateRule(ctx context.Context, id string, input models.AlertRuleInput) (*models.AlertRule, error) {
+	rule, err := s.GetRule(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyAlertRuleInput(rule, input); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateRule(ctx, rule); err != nil {
+		return nil, fmt.Errorf("error updating alert rule: %w", err)
+	}
+
+	return rule, s.reload(ctx)
+}
+
+// DeleteRule elimina una regla de alerta; las alertas ya generadas se conservan
+func (s *alertService) DeleteRule(ctx context.Context, id string) error {
+	if _, err := s.GetRule(ctx, id); err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteRule(ctx, id); err != nil {
+		return fmt.Errorf("error deleting alert rule: %w", err)
+	}
+
+	return s.reload(ctx)
+}
+
+// ListEvents obtiene las alertas generadas, de la más reciente a la más antigua
+func (s *alertService) ListEvents(ctx context.Context, filter models.AlertEventFilter) ([]models.AlertEvent, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = defaultAlertEventLimit
+	}
+	if filter.Limit > maxAlertEventLimit {
+		filter.Limit = maxAlertEventLimit
+	}
+
+	return s.repo.GetEvents(ctx, filter)
+}
+
+// Evaluate evalúa las reglas activas sobre un stock y guarda una alerta por cada regla cumplida
+func (s *alertService) Evaluate(ctx context.Context, stock *models.Stock) ([]models.AlertEvent, error) {
+	s.mu.RLock()
+	rules := s.rules
+	s.mu.RUnlock()
+
+	var triggered []models.AlertEvent
+	for _, rule := range rules {
+		matched, ok := matchAlertRule(&rule, stock)
+		if !ok {
+			continue
+		}
+
+		alert := models.AlertEvent{
+			RuleID:     rule.ID,
+			RuleName:   rule.Name,
+			StockID:    stock.ID,
+			Ticker:     stock.Ticker,
+			Company:    stock.Company,
+			Brokerage:  stock.Brokerage,
+			Action:     stock.Action,
+			RatingFrom: stock.RatingFrom,
+			RatingTo:   stock.RatingTo,
+			TargetFrom: stock.TargetFrom,
+			TargetTo:   stock.TargetTo,
+			Time:       stock.Time,
+			Matched:    matched,
+		}
+		if err := s.repo.CreateEvent(ctx, &alert); err != nil {
+			return triggered, fmt.Errorf("error saving alert: %w", err)
+		}
+
+		logger.Ctx(ctx, s.logger).Info("Alert triggered",
+			zap.String("rule", rule.Name),
+			zap.String("ticker", stock.Ticker))
+		s.bus.Publish(ctx, events.AlertTriggered, events.AlertTriggeredPayload{Alert: alert})
+		triggered = append(triggered, alert)
+	}
+
+	return triggered, nil
+}
+
+// matchAlertRule evalúa las condiciones de una regla sobre un stock, devolviendo
+// la descripción de cada condición cumplida
+func matchAlertRule(rule *models.AlertRule, stock *models.Stock) ([]string, bool) {
+	var matched []string
+
+	if len(rule.Tickers) > 0 {
+		if !containsFold(rule.Tickers, stock.Ticker) {
+			return nil, false
+		}
+		matched = append(matched, fmt.Sprintf("ticker is %s", stock.Ticker))
+	}
+
+	if len(rule.Brokerages) > 0 {
+		if !containsFold(rule.Brokerages, stock.Brokerage) {
+			return nil, false
+		}
+		matched = append(matched, fmt.Sprintf("brokerage is %s", stock.Brokerage))
+	}
+
+	if len(rule.Actions) > 0 {
+		if !containsFold(rule.Actions, stock.Action) {
+			return nil, false
+		}
+		matched = append(matched, fmt.Sprintf("action is %s", stock.Action))
+	}
+
+	// La calificación debe cambiar hacia uno de los valores indicados
+	if len(rule.RatingTo) > 0 {
+		if strings.EqualFold(stock.RatingFrom, stock.RatingTo) || !containsFold(rule.RatingTo, stock.RatingTo) {
+			return nil, false
+		}
+		matched = append(matched, fmt.Sprintf("rating moved from %s to %s", stock.RatingFrom, stock.RatingTo))
+	}
+
+	if rule.MinTargetChangePct != nil || rule.MaxTargetChangePct != nil {
+		change, ok := targetChangePercent(stock)
+		if !ok {
+			return nil, false
+		}
+		if rule.MinTargetChangePct != nil && change < *rule.MinTargetChangePct {
+			return nil, false
+		}
+		if rule.MaxTargetChangePct != nil && change > *rule.MaxTargetChangePct {
+			return nil, false
+		}
+		matched = append(matched, fmt.Sprintf("target changed by %.2f%%", change))
+	}
+
+	return matched, true
+}
+
+// applyAlertRuleInput valida y copia los datos de entrada en una regla
+func applyAlertRuleInput(rule *models.AlertRule, input models.AlertRuleInput) error {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidInput)
+	}
+
+	hasCondition := len(input.Tickers) > 0 || len(input.Brokerages) > 0 || len(input.Actions) > 0 ||
+		len(input.RatingTo) > 0 || input.MinTargetChangePct != nil || input.MaxTargetChangePct != nil
+	if !hasCondition {
+		return fmt.Errorf("%w: at least one condition is required", ErrInvalidInput)
+	}
+
+	if input.MinTargetChangePct != nil && input.MaxTargetChangePct != nil &&
+		*input.MinTargetChangePct > *input.MaxTargetChangePct {
+		return fmt.Errorf("%w: min_target_change_pct must not exceed max_target_change_pct", ErrInvalidInput)
+	}
+
+	rule.Name = name
+	rule.Tickers = input.Tickers
+	rule.Brokerages = input.Brokerages
+	rule.Actions = input.Actions
+	rule.RatingTo = input.RatingTo
+	rule.MinTargetChangePct = input.MinTargetChangePct
+	rule.MaxTargetChangePct = input.MaxTargetChangePct
+	if input.Enabled != nil {
+		rule.Enabled = *input.Enabled
+	}
+
+	return nil
+}
+
+// containsFold indica si un valor está en la lista, sin distinguir mayúsculas
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(strings.TrimSpace(v), value) {
+			return true
+		}
+	}
+	return false
+}
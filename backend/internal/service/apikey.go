@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/liferip/stock-analyzer/backend/internal/auth"
+	"github.com/liferip/stock-analyzer/backend/internal/models"
+	"github.com/liferip/stock-analyzer/backend/internal/repository"
+	"github.com/liferip/stock-analyzer/backend/pkg/logger"
+)
+
+// APIKeyService interfaz que define la administración de API keys
+type APIKeyService interface {
+	ListAPIKeys(ctx context.Context) ([]models.APIKey, error)
+	GetAPIKey(ctx context.Context, id string) (*models.APIKey, error)
+	CreateAPIKey(ctx context.Context, input models.APIKeyInput) (*models.APIKey, string, error)
+	RotateAPIKey(ctx context.Context, id string) (*models.APIKey, string, error)
+	RevokeAPIKey(ctx context.Context, id string) error
+}
+
+// apiKeyService implementación de APIKeyService
+type apiKeyService struct {
+	repo   repository.APIKeyRepository
+	logger *zap.Logger
+}
+
+// NewAPIKeyService crea una nueva instancia de APIKeyService
+func NewAPIKeyService(repo repository.APIKeyRepository, logger *zap.Logger) APIKeyService {
+	return &apiKeyService{
+		repo:   repo,
+		logger: logger.Named("api_key_service"),
+	}
+}
+
+// ListAPIKeys obtiene todas las API keys, incluidas las revocadas y vencidas
+func (s *apiKeyService) ListAPIKeys(ctx context.Context) ([]models.APIKey, error) {
+	return s.repo.GetAll(ctx)
+}
+
+// GetAPIKey obtiene una API key, o ErrNotFound si no existe
+func (s *apiKeyService) GetAPIKey(ctx context.Context, id string) (*models.APIKey, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, fmt.Errorf("%w: API key %s", ErrNotFound, id)
+	}
+
+	key, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if key == nil {
+		return nil, fmt.Errorf("%w: API key %s", ErrNotFound, id)
+	}
+
+	return key, nil
+}
+
+// CreateAPIKey emite una API key y devuelve su valor, que no vuelve a estar disponible
+func (s *apiKeyService) CreateAPIKey(ctx context.Context, input models.APIKeyInput) (*models.APIKey, string, error) {
+	key := &models.APIKey{}
+	if err := applyAPIKeyInput(key, input); err != nil {
+		return nil, "", err
+	}
+
+	secret, err := issueAPIKey(key)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if err := s.repo.Create(ctx, key); err != nil {
+		return nil, "", fmt.Errorf("error creating API key: %w", err)
+	}
+
+	logger.Ctx(ctx, s.logger).Info("API key created",
+		zap.String("id", key.ID),
+		zap.String("label", key.Label),
+		zap.Strings("scopes", key.Scopes))
+
+	return key, secret, nil
+}
+
+// RotateAPIKey reemplaza el valor de una API key conservando su etiqueta, scopes y vencimiento.
+// El valor anterior deja de funcionar inmediatamente.
+func (s *apiKeyService) RotateAPIKey(ctx context.Context, id string) (*models.APIKey, string, error) {
+	key, err := s.GetAPIKey(ctx, id)
+	if err != nil {
+		return nil, "", err
+	}
+	if key.RevokedAt != nil {
+		return nil, "", fmt.Errorf("%w: API key %s is revoked", ErrConflict, id)
+	}
+
+	secret, err := issueAPIKey(key)
+	if err != nil {
+		return nil, "", err
+	}
+	key.LastUsedAt = nil
+
+	if err := s.repo.Update(ctx, key); err != nil {
+		return nil, "", fmt.Errorf("error rotating API key: %w", err)
+	}
+
+	logger.Ctx(ctx, s.logger).Info("API key rotated", zap.String("id", key.ID))
+
+	return key, secret, nil
+}
+
+// RevokeAPIKey revoca una API key; el registro se conserva para auditoría
+func (s *apiKeyService) RevokeAPIKey(ctx context.Context, id string) error {
+	key, err := s.GetAPIKey(ctx, id)
+	if err != nil {
+		return err
+	}
+	if key.RevokedAt != nil {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	key.RevokedAt = &now
+
+	if err := s.repo.Update(ctx, key); err != nil {
+		return fmt.Errorf("error revoking API key: %w", err)
+	}
+
+	logger.Ctx(ctx, s.logger).Info("API key revoked", zap.String("id", key.ID))
+
+	return nil
+}
+
+// applyAPIKeyInput valida los datos de una API key y los copia al modelo
+func applyAPIKeyInput(key *models.APIKey, input models.APIKeyInput) error {
+	label := strings.TrimSpace(input.Label)
+	if label == "" {
+		return fmt.Errorf("%w: label is required", ErrInvalidInput)
+	}
+
+	if len(input.Scopes) == 0 {
+		return fmt.Errorf("%w: at least one scope is required", ErrInvalidInput)
+	}
+	scopes := make([]string, 0, len(input.Scopes))
+	for _, value := range input.Scopes {
+		scope, err := auth.ParseScope(strings.TrimSpace(value))
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidInput, err)
+		}
+		if !containsFold(scopes, string(scope)) {
+			scopes = append(scopes, string(scope))
+		}
+	}
+
+	if input.ExpiresAt != nil && !input.ExpiresAt.After(time.Now()) {
+		return fmt.Errorf("%w: expires_at must be in the future", ErrInvalidInput)
+	}
+
+	key.Label = label
+	key.Scopes = scopes
+	key.ExpiresAt = input.ExpiresAt
+	return nil
+}
+
+// issueAPIKey genera un nuevo valor para la API key y guarda su prefijo y hash
+func issueAPIKey(key *models.APIKey) (string, error) {
+	secret, prefix, hash, err := auth.GenerateAPIKey()
+	if err != nil {
+		return "", err
+	}
+
+	key.Prefix = prefix
+	key.Hash = hash
+	return secret, nil
+}
@@ -0,0 +1,279 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/liferip/stock-analyzer/backend/internal/models"
+	"github.com/liferip/stock-analyzer/backend/pkg/logger"
+)
+
+// ScoringVersion identifica la versión de las reglas de calculateRecommendationScore.
+// Debe actualizarse cada vez que cambien los pesos o factores de la puntuación.
+const ScoringVersion = "1"
+
+const (
+	// defaultBacktestHorizon es la cantidad de días usada para medir el rendimiento posterior
+	defaultBacktestHorizon = 5
+	// defaultBacktestDays es la duración del periodo cuando no se indica una fecha de inicio
+	defaultBacktestDays = 90
+	// maxBacktestYears limita la duración del periodo, que se recorre día a día
+	maxBacktestYears = 3
+	// maxBacktestHorizon es la cantidad máxima de días para medir el rendimiento posterior
+	maxBacktestHorizon = 365
+)
+
+// priceStaleness es la antigüedad máxima de un precio para considerarlo vigente en un día
+// (cubre fines de semana y feriados)
+const priceStaleness = 7 * 24 * time.Hour
+
+// priceSeries agrupa los precios de cierre por ticker, ordenados por fecha
+type priceSeries map[string][]models.StockPrice
+
+// newPriceSeries crea una serie a partir de precios ordenados por ticker y fecha
+func newPriceSeries(prices []models.StockPrice) priceSeries {
+	series := make(priceSeries)
+	for _, price := range prices {
+		series[price.Ticker] = append(series[price.Ticker], price)
+	}
+	return series
+}
+
+// closeOn obtiene el último precio de cierre de un ticker en o antes de un día
+func (p priceSeries) closeOn(ticker string, day time.Time) (models.StockPrice, bool) {
+	prices := p[ticker]
+	i := sort.Search(len(prices), func(i int) bool {
+		return prices[i].Date.After(day)
+	})
+	if i == 0 {
+		return models.StockPrice{}, false
+	}
+
+	price := prices[i-1]
+	if day.Sub(price.Date) > priceStaleness {
+		return models.StockPrice{}, false
+	}
+	return price, true
+}
+
+// forwardReturn calcula el rendimiento porcentual de un ticker entre un día y otro posterior.
+// Solo es válido si existe un precio de cierre nuevo después del día de entrada.
+func (p priceSeries) forwardReturn(ticker string, day, exitDay time.Time) (float64, bool) {
+	entry, ok := p.closeOn(ticker, day)
+	if !ok {
+		return 0, false
+	}
+
+	exit, ok := p.closeOn(ticker, exitDay)
+	if !ok || !exit.Date.After(day) {
+		return 0, false
+	}
+
+	return (exit.Close - entry.Close) / entry.Close * 100, true
+}
+
+// RunBacktest reproduce los eventos de calificación día a día, calcula la lista de
+// recomendaciones de cada día con la estrategia indicada y mide su rendimiento
+// posterior contra un índice de referencia.
+func (s *stockService) RunBacktest(ctx context.Context, params models.BacktestParams) (*models.BacktestResult, error) {
+	ctx, span := tracer.Start(ctx, "stockService.RunBacktest")
+	defer span.End()
+
+	strategy, err := ParseRecommendationSort(params.Strategy)
+	if err != nil {
+		return nil, err
+	}
+
+	// Aplicar valores por defecto
+	top, horizon := recommendationLimit, defaultBacktestHorizon
+	if params.Top != nil {
+		top = *params.Top
+	}
+	if params.Horizon != nil {
+		horizon = *params.Horizon
+	}
+	if params.Benchmark == "" {
+		params.Benchmark = s.benchmark
+	}
+	if params.To.IsZero() {
+		params.To = time.Now()
+	}
+	if params.From.IsZero() {
+		params.From = params.To.AddDate(0, 0, -defaultBacktestDays)
+	}
+
+	if top <= 0 {
+		return nil, fmt.Errorf("%w: top must be positive", ErrInvalidInput)
+	}
+	if horizon <= 0 || horizon > maxBacktestHorizon {
+		return nil, fmt.Errorf("%w: horizon must be between 1 and %d days", ErrInvalidInput, maxBacktestHorizon)
+	}
+
+	from := truncateDay(params.From)
+	to := truncateDay(params.To)
+	if to.Before(from) {
+		return nil, fmt.Errorf("%w: 'to' must not be before 'from'", ErrInvalidInput)
+	}
+	if from.AddDate(maxBacktestYears, 0, 0).Before(to) {
+		return nil, fmt.Errorf("%w: the period from 'from' to 'to' must not exceed %d years", ErrInvalidInput, maxBacktestYears)
+	}
+
+	// Cargar los eventos hasta el final del periodo
+	events, err := s.eventRepo.GetUntil(ctx, to.AddDate(0, 0, 1).Add(-time.Nanosecond))
+	if err != nil {
+		logger.Ctx(ctx, s.logger).Error("Error getting rating events for backtest", zap.Error(err))
+		return nil, err
+	}
+
+	// Cargar los precios necesarios para calcular rendimientos posteriores
+	prices, err := s.priceRepo.GetRange(ctx, from.Add(-priceStaleness), to.AddDate(0, 0, horizon))
+	if err != nil {
+		logger.Ctx(ctx, s.logger).Error("Error getting prices for backtest", zap.Error(err))
+		return nil, err
+	}
+	series := newPriceSeries(prices)
+
+	result := &models.BacktestResult{
+		Strategy:       string(strategy),
+		ScoringVersion: ScoringVersion,
+		From:           from.Format(time.DateOnly),
+		To:             to.Format(time.DateOnly),
+		Top:            top,
+		Horizon:        horizon,
+		Benchmark:      params.Benchmark,
+		Daily:          []models.BacktestDay{},
+	}
+
+	latest := make(map[string]models.Stock)
+	next := 0
+	var previous map[string]bool
+	var hits, turnoverDays int
+	var returnSum, benchmarkSum, turnoverSum float64
+	var benchmarkDays int
+	equity, peak := 1.0, 1.0
+
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		// Aplicar los eventos ocurridos hasta el final del día
+		dayEnd := day.AddDate(0, 0, 1)
+		for next < len(events) && events[next].Time.Before(dayEnd) {
+			latest[events[next].Ticker] = events[next].ToStock()
+			next++
+		}
+		if len(latest) == 0 {
+			continue
+		}
+
+		// Reconstruir el estado de la tabla de stocks en ese día
+		stocks := make([]models.Stock, 0, len(latest))
+		closes := make(map[string]float64, len(latest))
+		for ticker, stock := range latest {
+			stocks = append(stocks, stock)
+			if price, ok := series.closeOn(ticker, day); ok {
+				closes[ticker] = price.Close
+			}
+		}
+		sort.Slice(stocks, func(i, j int) bool {
+			return stocks[i].Time.After(stocks[j].Time)
+		})
+
+		recommendations := s.processRecommendations(stocks, closes, strategy, top)
+
+		exitDay := day.AddDate(0, 0, horizon)
+		benchmarkReturn, hasBenchmark := series.forwardReturn(params.Benchmark, day, exitDay)
+
+		backtestDay := models.BacktestDay{
+			Date:    day.Format(time.DateOnly),
+			Tickers: make([]string, 0, len(recommendations)),
+		}
+		current := make(map[string]bool, len(recommendations))
+
+		var dayReturnSum, dailyStepSum float64
+		var priced, stepped int
+		for _, recommendation := range recommendations {
+			ticker := recommendation.Stock.Ticker
+			backtestDay.Tickers = append(backtestDay.Tickers, ticker)
+			current[ticker] = true
+			result.Picks++
+
+			if r, ok := series.forwardReturn(ticker, day, exitDay); ok {
+				priced++
+				dayReturnSum += r
+				if (hasBenchmark && r > benchmarkReturn) || (!hasBenchmark && r > 0) {
+					hits++
+				}
+			}
+
+			// Rendimiento a un día para la curva de capital (rebalanceo diario)
+			if r, ok := series.forwardReturn(ticker, day, day.AddDate(0, 0, 1)); ok {
+				stepped++
+				dailyStepSum += r
+			}
+		}
+
+		if priced > 0 {
+			dayReturn := dayReturnSum / float64(priced)
+			backtestDay.Return = &dayReturn
+			result.PricedPicks += priced
+			returnSum += dayReturnSum
+		}
+		if hasBenchmark {
+			backtestDay.BenchmarkReturn = &benchmarkReturn
+			benchmarkSum += benchmarkReturn
+			benchmarkDays++
+		}
+
+		// Rotación: proporción de la lista que no estaba el día anterior
+		if previous != nil && len(current) > 0 {
+			var entered int
+			for ticker := range current {
+				if !previous[ticker] {
+					entered++
+				}
+			}
+			backtestDay.Turnover = float64(entered) / float64(len(current))
+			turnoverSum += backtestDay.Turnover
+			turnoverDays++
+		}
+		previous = current
+
+		// Actualizar la curva de capital y la caída máxima
+		if stepped > 0 {
+			equity *= 1 + dailyStepSum/float64(stepped)/100
+			peak = math.Max(peak, equity)
+			result.MaxDrawdown = math.Max(result.MaxDrawdown, (peak-equity)/peak*100)
+		}
+
+		result.Days++
+		result.Daily = append(result.Daily, backtestDay)
+	}
+
+	if result.PricedPicks > 0 {
+		result.HitRate = float64(hits) / float64(result.PricedPicks)
+		result.AverageReturn = returnSum / float64(result.PricedPicks)
+	}
+	if benchmarkDays > 0 {
+		result.BenchmarkReturn = benchmarkSum / float64(benchmarkDays)
+	}
+	result.ExcessReturn = result.AverageReturn - result.BenchmarkReturn
+	if turnoverDays > 0 {
+		result.Turnover = turnoverSum / float64(turnoverDays)
+	}
+
+	logger.Ctx(ctx, s.logger).Info("Backtest completed",
+		zap.String("strategy", result.Strategy),
+		zap.String("from", result.From),
+		zap.String("to", result.To),
+		zap.Int("days", result.Days),
+		zap.Int("priced_picks", result.PricedPicks))
+
+	return result, nil
+}
@@ -0,0 +1,246 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/liferip/stock-analyzer/backend/config"
+	"github.com/liferip/stock-analyzer/backend/internal/events"
+	"github.com/liferip/stock-analyzer/backend/internal/models"
+	"github.com/liferip/stock-analyzer/backend/internal/repository"
+	"github.com/liferip/stock-analyzer/backend/pkg/mailer"
+)
+
+const (
+	// digestListLimit es la cantidad máxima de mejoras y rebajas incluidas en el resumen
+	digestListLimit = 10
+	// digestTargetChangeLimit es la cantidad de mayores cambios de precio objetivo incluidos en el resumen
+	digestTargetChangeLimit = 5
+	// digestDefaultWindow es el período cubierto por el primer resumen
+	digestDefaultWindow = 24 * time.Hour
+	// digestJobTimeout es el tiempo máximo para armar y enviar el resumen después de una sincronización
+	digestJobTimeout = 2 * time.Minute
+)
+
+// DigestService interfaz que define las operaciones del resumen diario por correo
+type DigestService interface {
+	Enabled() bool
+	BuildDigest(ctx context.Context) (*models.Digest, error)
+	Render(digest *models.Digest) (html string, text string, err error)
+	SendDigest(ctx context.Context, force bool) (*models.DigestRun, error)
+}
+
+// digestService implementación de DigestService
+type digestService struct {
+	repo         repository.DigestRepository
+	eventRepo    repository.EventRepository
+	stockService StockService
+	taxonomy     TaxonomyService
+	mailer       *mailer.Mailer
+	recipients   []string
+	logger       *zap.Logger
+}
+
+// NewDigestService crea una nueva instancia de DigestService
+func NewDigestService(
+	repo repository.DigestRepository,
+	eventRepo repository.EventRepository,
+	stockService StockService,
+	taxonomy TaxonomyService,
+	mailer *mailer.Mailer,
+	cfg *config.Config,
+	logger *zap.Logger,
+) DigestService {
+	return &digestService{
+		repo:         repo,
+		eventRepo:    eventRepo,
+		stockService: stockService,
+		taxonomy:     taxonomy,
+		mailer:       mailer,
+		recipients:   cfg.DigestRecipients,
+		logger:       logger.Named("digest_service"),
+	}
+}
+
+// RegisterDigestJob envía el resumen diario después de la primera sincronización exitosa de
+// cada día, en segundo plano para que un servidor SMTP lento no demore la sincronización
+func RegisterDigestJob(bus *events.Bus, digestService DigestService, logger *zap.Logger) {
+	logger = logger.Named("digest_job")
+
+	bus.SubscribeAsync(digestJobTimeout, func(ctx context.Context, event events.Event) {
+		if event.Type != events.SyncCompleted || !digestService.Enabled() {
+			return
+		}
+
+		run, err := digestService.SendDigest(ctx, false)
+		if err != nil {
+			logger.Error("Error sending digest", zap.Error(err))
+			return
+		}
+		if run == nil {
+			logger.Debug("Digest already sent today")
+			return
+		}
+		logger.Info("Digest sent", zap.Int("recipients", len(run.Recipients)))
+	})
+}
+
+// Enabled indica si hay servidor SMTP y destinatarios configurados
+func (s *digestService) Enabled() bool {
+	return s.mailer.Enabled() && len(s.recipients) > 0
+}
+
+// BuildDigest arma el resumen con las mejores recomendaciones y los cambios registrados desde el envío anterior
+func (s *digestService) BuildDigest(ctx context.Context) (*models.Digest, error) {
+	now := time.Now().UTC()
+
+	since := now.Add(-digestDefaultWindow)
+	last, err := s.repo.GetLast(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting last digest: %w", err)
+	}
+	if last != nil {
+		since = last.SentAt
+	}
+
+	recommendations, err := s.stockService.GetRecommendations(ctx, SortByScore)
+	if err != nil {
+		return nil, fmt.Errorf("error getting recommendations: %w", err)
+	}
+
+	recorded, err := s.eventRepo.GetRecordedSince(ctx, since)
+	if err != nil {
+		return nil, fmt.Errorf("error getting rating events: %w", err)
+	}
+
+	digest := &models.Digest{
+		GeneratedAt:     now,
+		Since:           since,
+		Recommendations: recommendations,
+		Upgrades:        []models.RatingEvent{},
+		Downgrades:      []models.RatingEvent{},
+		TargetChanges:   []models.DigestTargetChange{},
+	}
+
+	for _, event := range recorded {
+		// Clasificar según la diferencia de puntuación entre la calificación nueva y la anterior
+		from, _ := s.taxonomy.Score(models.TaxonomyKindRating, event.RatingFrom)
+		to, _ := s.taxonomy.Score(models.TaxonomyKindRating, event.RatingTo)
+		switch {
+		case to > from && len(digest.Upgrades) < digestListLimit:
+			digest.Upgrades = append(digest.Upgrades, event)
+		case to < from && len(digest.Downgrades) < digestListLimit:
+			digest.Downgrades = append(digest.Downgrades, event)
+		}
+
+		stock := event.ToStock()
+		if change, ok := targetChangePercent(&stock); ok && change != 0 {
+			digest.TargetChanges = append(digest.TargetChanges, models.DigestTargetChange{
+				Event:     event,
+				ChangePct: change,
+			})
+		}
+	}
+
+	sort.SliceStable(digest.TargetChanges, func(i, j int) bool {
+		return math.Abs(digest.TargetChanges[i].ChangePct) > math.Abs(digest.TargetChanges[j].ChangePct)
+	})
+	if len(digest.TargetChanges) > digestTargetChangeLimit {
+		digest.TargetChanges = digest.TargetChanges[:digestTargetChangeLimit]
+	}
+
+	return digest, nil
+}
+
+// Render genera las versiones HTML y de texto plano del resumen
+func (s *digestService) Render(digest *models.Digest) (string, string, error) {
+	var html, text bytes.Buffer
+
+	if err := digestHTMLTemplate.Execute(&html, digest); err != nil {
+		return "", "", fmt.Errorf("error rendering HTML digest: %w", err)
+	}
+	if err := digestTextTemplate.Execute(&text, digest); err != nil {
+		return "", "", fmt.Errorf("error rendering text digest: %w", err)
+	}
+
+	return html.String(), text.String(), nil
+}
+
+// SendDigest envía el resumen a los destinatarios configurados. Sin force no envía
+// si ya se envió uno en el día (UTC) y devuelve nil; el envío del día se registra antes
+// de enviarlo, para que dos sincronizaciones simultáneas no lo envíen dos veces
+func (s *digestService) SendDigest(ctx context.Context, force bool) (*models.DigestRun, error) {
+	if !s.Enabled() {
+		return nil, fmt.Errorf("%w: digest is not configured, set SMTP_HOST and DIGEST_RECIPIENTS", ErrInvalidInput)
+	}
+
+	if !force {
+		last, err := s.repo.GetLast(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error getting last digest: %w", err)
+		}
+		if last != nil && truncateDay(last.SentAt).Equal(truncateDay(time.Now())) {
+			return nil, nil
+		}
+	}
+
+	digest, err := s.BuildDigest(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	html, text, err := s.Render(digest)
+	if err != nil {
+		return nil, err
+	}
+
+	run := &models.DigestRun{
+		SentAt:          digest.GeneratedAt,
+		Since:           digest.Since,
+		Recipients:      s.recipients,
+		Recommendations: len(digest.Recommendations),
+		RatingChanges:   len(digest.Upgrades) + len(digest.Downgrades),
+	}
+
+	if !force {
+		day := truncateDay(digest.GeneratedAt)
+		run.Day = &day
+		claimed, err := s.repo.Claim(ctx, run)
+		if err != nil {
+			return nil, fmt.Errorf("error saving digest run: %w", err)
+		}
+		if !claimed {
+			return nil, nil
+		}
+	}
+
+	err = s.mailer.Send(ctx, mailer.Message{
+		To:      s.recipients,
+		Subject: fmt.Sprintf("Stock Analyzer digest for %s", digest.GeneratedAt.Format(time.DateOnly)),
+		HTML:    html,
+		Text:    text,
+	})
+	if err != nil {
+		if !force {
+			// Liberar el día para que la próxima sincronización vuelva a intentarlo
+			if deleteErr := s.repo.Delete(context.WithoutCancel(ctx), run.ID); deleteErr != nil {
+				s.logger.Error("Error releasing digest run", zap.Error(deleteErr))
+			}
+		}
+		return nil, err
+	}
+
+	if force {
+		if err := s.repo.Create(ctx, run); err != nil {
+			return nil, fmt.Errorf("error saving digest run: %w", err)
+		}
+	}
+
+	return run, nil
+}
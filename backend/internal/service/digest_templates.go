@@ -0,0 +1,75 @@
+package service
+
+import (
+	"fmt"
+	htmltemplate "html/template"
+	texttemplate "text/template"
+	"time"
+)
+
+// digestFuncs son las funciones disponibles en las plantillas del resumen
+var digestFuncs = map[string]interface{}{
+	"inc": func(i int) int { return i + 1 },
+	"pct": func(v float64) string {
+		if v > 0 {
+			return "+" + formatFloat(v) + "%"
+		}
+		return formatFloat(v) + "%"
+	},
+	"score":    formatFloat,
+	"datetime": func(t time.Time) string { return t.UTC().Format("2006-01-02 15:04 UTC") },
+}
+
+// formatFloat formatea un número con dos decimales
+func formatFloat(v float64) string {
+	return fmt.Sprintf("%.2f", v)
+}
+
+const digestText = `Stock Analyzer daily digest
+Changes recorded since {{datetime .Since}}
+
+TOP RECOMMENDATIONS
+{{range $i, $r := .Recommendations}}{{inc $i}}. {{$r.Stock.Ticker}} ({{$r.Stock.Company}}) - score {{score $r.Score}}, {{$r.Stock.RatingTo}}, target {{$r.Stock.TargetTo}}
+{{else}}No recommendations yet.
+{{end}}
+UPGRADES
+{{range .Upgrades}}- {{.Ticker}}: {{.Brokerage}} {{.RatingFrom}} -> {{.RatingTo}}
+{{else}}No upgrades.
+{{end}}
+DOWNGRADES
+{{range .Downgrades}}- {{.Ticker}}: {{.Brokerage}} {{.RatingFrom}} -> {{.RatingTo}}
+{{else}}No downgrades.
+{{end}}
+BIGGEST TARGET CHANGES
+{{range .TargetChanges}}- {{.Event.Ticker}}: {{.Event.Brokerage}} {{.Event.TargetFrom}} -> {{.Event.TargetTo}} ({{pct .ChangePct}})
+{{else}}No target changes.
+{{end}}`
+
+const digestHTML = `<!DOCTYPE html>
+<html>
+<body style="font-family: Arial, sans-serif; color: #1f2937;">
+<h2>Stock Analyzer daily digest</h2>
+<p style="color: #6b7280;">Changes recorded since {{datetime .Since}}</p>
+
+<h3>Top recommendations</h3>
+{{if .Recommendations}}<table cellpadding="6" style="border-collapse: collapse;">
+<tr style="text-align: left; background: #f3f4f6;"><th>#</th><th>Ticker</th><th>Company</th><th>Score</th><th>Rating</th><th>Target</th></tr>
+{{range $i, $r := .Recommendations}}<tr><td>{{inc $i}}</td><td><b>{{$r.Stock.Ticker}}</b></td><td>{{$r.Stock.Company}}</td><td>{{score $r.Score}}</td><td>{{$r.Stock.RatingTo}}</td><td>{{$r.Stock.TargetTo}}</td></tr>
+{{end}}</table>{{else}}<p>No recommendations yet.</p>{{end}}
+
+<h3>Upgrades</h3>
+{{if .Upgrades}}<ul>{{range .Upgrades}}<li><b>{{.Ticker}}</b>: {{.Brokerage}} {{.RatingFrom}} &rarr; {{.RatingTo}}</li>{{end}}</ul>{{else}}<p>No upgrades.</p>{{end}}
+
+<h3>Downgrades</h3>
+{{if .Downgrades}}<ul>{{range .Downgrades}}<li><b>{{.Ticker}}</b>: {{.Brokerage}} {{.RatingFrom}} &rarr; {{.RatingTo}}</li>{{end}}</ul>{{else}}<p>No downgrades.</p>{{end}}
+
+<h3>Biggest target changes</h3>
+{{if .TargetChanges}}<ul>{{range .TargetChanges}}<li><b>{{.Event.Ticker}}</b>: {{.Event.Brokerage}} {{.Event.TargetFrom}} &rarr; {{.Event.TargetTo}} ({{pct .ChangePct}})</li>{{end}}</ul>{{else}}<p>No target changes.</p>{{end}}
+</body>
+</html>
+`
+
+var (
+	digestTextTemplate = texttemplate.Must(texttemplate.New("digest.txt").Funcs(digestFuncs).Parse(digestText))
+	digestHTMLTemplate = htmltemplate.Must(htmltemplate.New("digest.html").Funcs(digestFuncs).Parse(digestHTML))
+)
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/liferip/stock-analyzer/backend/config"
+	"github.com/liferip/stock-analyzer/backend/internal/events"
+	"github.com/liferip/stock-analyzer/backend/internal/models"
+	"github.com/liferip/stock-analyzer/backend/internal/repository"
+)
+
+// snapshotJobTimeout es el tiempo máximo para guardar la foto después de una sincronización
+const snapshotJobTimeout = 2 * time.Minute
+
+// SnapshotService interfaz que define las operaciones sobre las fotos diarias de recomendaciones
+type SnapshotService interface {
+	TakeSnapshot(ctx context.Context, date time.Time) ([]models.RecommendationSnapshot, error)
+	GetSnapshot(ctx context.Context, date time.Time) ([]models.RecommendationSnapshot, error)
+	GetSnapshotDates(ctx context.Context) ([]string, error)
+	DiffSnapshots(ctx context.Context, from, to time.Time) (*models.SnapshotDiff, error)
+}
+
+// snapshotService implementación de SnapshotService
+type snapshotService struct {
+	repo         repository.SnapshotRepository
+	stockService StockService
+	size         int
+	logger       *zap.Logger
+}
+
+// NewSnapshotService crea una nueva instancia de SnapshotService
+func NewSnapshotService(
+	repo repository.SnapshotRepository,
+	stockService StockService,
+	cfg *config.Config,
+	logger *zap.Logger,
+) SnapshotService {
+	return &snapshotService{
+		repo:         repo,
+		stockService: stockService,
+		size:         cfg.SnapshotSize,
+		logger:       logger.Named("snapshot_service"),
+	}
+}
+
+// RegisterSnapshotJob guarda una foto de las recomendaciones al terminar cada sincronización,
+// en segundo plano para que la foto no dependa de la solicitud que sincronizó
+func RegisterSnapshotJob(bus *events.Bus, snapshotService SnapshotService, logger *zap.Logger) {
+	logger = logger.Named("snapshot_job")
+
+	bus.SubscribeAsync(snapshotJobTimeout, func(ctx context.Context, event events.Event) {
+		if event.Type != events.SyncCompleted {
+			return
+		}
+
+		snapshots, err := snapshotService.TakeSnapshot(ctx, event.Time)
+		if err != nil {
+			logger.Error("Error taking recommendation snapshot", zap.Error(err))
+			return
+		}
+		logger.Info("Recommendation snapshot saved", zap.Int("count", len(snapshots)))
+	})
+}
+
+// TakeSnapshot guarda las mejores recomendaciones actuales como la foto de una fecha,
+// reemplazando la foto anterior de esa misma fecha
+func (s *snapshotService) TakeSnapshot(ctx context.Context, date time.Time) ([]models.RecommendationSnapshot, error) {
+	recommendations, err := s.stockService.GetTopRecommendations(ctx, SortByScore, s.size)
+	if err != nil {
+		return nil, fmt.Errorf("error getting recommendations: %w", err)
+	}
+
+	day := truncateDay(date)
+	snapshots := make([]models.RecommendationSnapshot, 0, len(recommendations))
+	for i, recommendation := range recommendations {
+		snapshots = append(snapshots, models.RecommendationSnapshot{
+			Date:           day,
+			Rank:           i + 1,
+			Ticker:         recommendation.Stock.Ticker,
+			Score:          recommendation.Score,
+			Strategy:       string(SortByScore),
+			ScoringVersion: ScoringVersion,
+			Recommendation: recommendation,
+		})
+	}
+
+	if err := s.repo.ReplaceForDate(ctx, day, snapshots); err != nil {
+		return nil, fmt.Errorf("error saving snapshot: %w", err)
+	}
+
+	return snapshots, nil
+}
+
+// GetSnapshot obtiene la foto de una fecha
+func (s *snapshotService) GetSnapshot(ctx context.Context, date time.Time) ([]models.RecommendationSnapshot, error) {
+	return s.repo.GetByDate(ctx, truncateDay(date))
+}
+
+// GetSnapshotDates obtiene las fechas con foto guardada en formato YYYY-MM-DD
+func (s *snapshotService) GetSnapshotDates(ctx context.Context) ([]string, error) {
+	dates, err := s.repo.GetDates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	formatted := make([]string, 0, len(dates))
+	for _, date := range dates {
+		formatted = append(formatted, date.Format(time.DateOnly))
+	}
+
+	return formatted, nil
+}
+
+// DiffSnapshots compara las fotos de dos fechas y devuelve los tickers que entraron o salieron de la lista
+func (s *snapshotService) DiffSnapshots(ctx context.Context, from, to time.Time) (*models.SnapshotDiff, error) {
+	before, err := s.repo.GetByDate(ctx, truncateDay(from))
+	if err != nil {
+		return nil, err
+	}
+	after, err := s.repo.GetByDate(ctx, truncateDay(to))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(before) == 0 {
+		return nil, fmt.Errorf("%w: no snapshot for %s", ErrNotFound, from.Format(time.DateOnly))
+	}
+	if len(after) == 0 {
+		return nil, fmt.Errorf("%w: no snapshot for %s", ErrNotFound, to.Format(time.DateOnly))
+	}
+
+	return &models.SnapshotDiff{
+		From:    from.Format(time.DateOnly),
+		To:      to.Format(time.DateOnly),
+		Entered: missingFrom(after, before),
+		Left:    missingFrom(before, after),
+	}, nil
+}
+
+// missingFrom devuelve las entradas de una foto cuyo ticker no aparece en la otra
+func missingFrom(snapshots, other []models.RecommendationSnapshot) []models.RecommendationSnapshot {
+	present := make(map[string]bool, len(other))
+	for _, snapshot := range other {
+		present[snapshot.Ticker] = true
+	}
+
+	missing := []models.RecommendationSnapshot{}
+	for _, snapshot := range snapshots {
+		if !present[snapshot.Ticker] {
+			missing = append(missing, snapshot)
+		}
+	}
+
+	return missing
+}
+
+// truncateDay obtiene el inicio del día en UTC
+func truncateDay(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"regexp"
 	"sort"
@@ -9,58 +10,207 @@ import (
 	"sync"
 	"time"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 
+	"github.com/liferip/stock-analyzer/backend/config"
+	"github.com/liferip/stock-analyzer/backend/internal/cache"
+	"github.com/liferip/stock-analyzer/backend/internal/events"
+	"github.com/liferip/stock-analyzer/backend/internal/metrics"
 	"github.com/liferip/stock-analyzer/backend/internal/models"
 	"github.com/liferip/stock-analyzer/backend/internal/repository"
 	"github.com/liferip/stock-analyzer/backend/pkg/httpclient"
+	"github.com/liferip/stock-analyzer/backend/pkg/logger"
 )
 
 // Module proporciona las dependencias del servicio
-var Module = fx.Provide(NewStockService)
+var Module = fx.Options(
+	fx.Provide(
+		NewStockService,
+		NewTaxonomyService,
+		NewSnapshotService,
+		NewAlertService,
+		NewWebhookService,
+		NewDigestService,
+		NewStockStream,
+		NewAPIKeyService,
+	),
+	fx.Invoke(
+		RegisterSnapshotJob,
+		RegisterAlertEngine,
+		RegisterWebhookDispatcher,
+		RegisterDigestJob,
+		RegisterStockStream,
+		RegisterRecommendationWatcher,
+		RegisterTaxonomyRefresh,
+	),
+)
+
+// tracer crea los spans de los servicios
+var tracer = otel.Tracer("github.com/liferip/stock-analyzer/backend/internal/service")
+
+// recommendationLimit es la cantidad de recomendaciones devueltas al usuario
+const recommendationLimit = 5
+
+// Factores que componen la puntuación de una recomendación
+const (
+	FactorRating       = "rating_change"
+	FactorAction       = "action"
+	FactorTargetChange = "target_change"
+)
+
+// Pesos de cada factor en la puntuación
+const (
+	ratingWeight       = 1.0
+	actionWeight       = 1.0
+	targetChangeWeight = 0.1 // Normaliza el impacto del cambio porcentual del target
+)
+
+// ErrInvalidInput indica que los datos recibidos no son válidos
+var ErrInvalidInput = errors.New("invalid input")
+
+// RecommendationSort define el criterio para ordenar las recomendaciones
+type RecommendationSort string
+
+const (
+	// SortByScore ordena por puntuación (de mayor a menor)
+	SortByScore RecommendationSort = "score"
+	// SortByUpside ordena por potencial desde el precio actual (de mayor a menor)
+	SortByUpside RecommendationSort = "upside"
+)
+
+// ParseRecommendationSort valida un criterio de ordenamiento, usando la puntuación por defecto
+func ParseRecommendationSort(value string) (RecommendationSort, error) {
+	switch RecommendationSort(value) {
+	case "", SortByScore:
+		return SortByScore, nil
+	case SortByUpside:
+		return SortByUpside, nil
+	default:
+		return "", fmt.Errorf("%w: unknown sort %q", ErrInvalidInput, value)
+	}
+}
 
 // StockService interfaz que define las operaciones del servicio
 type StockService interface {
 	GetAllStocks(ctx context.Context) ([]models.Stock, error)
 	GetStockByTicker(ctx context.Context, ticker string) (*models.Stock, error)
 	SyncStocksFromAPI(ctx context.Context) (int, error)
-	GetRecommendations(ctx context.Context) ([]models.StockRecommendation, error)
-	GetRecommendationsByTime(ctx context.Context, time string) ([]models.StockRecommendation, error)
+	GetRecommendations(ctx context.Context, sortBy RecommendationSort) ([]models.StockRecommendation, error)
+	GetTopRecommendations(ctx context.Context, sortBy RecommendationSort, limit int) ([]models.StockRecommendation, error)
+	GetRecommendationsByTime(ctx context.Context, time string, sortBy RecommendationSort) ([]models.StockRecommendation, error)
+	UpsertPrices(ctx context.Context, items []models.StockPriceItem) (int, error)
+	RunBacktest(ctx context.Context, params models.BacktestParams) (*models.BacktestResult, error)
+	FindStocks(ctx context.Context, filter models.StockFilter) ([]models.Stock, int64, error)
+	GetRatingHistory(ctx context.Context, filter models.RatingEventFilter) ([]models.RatingEvent, int64, error)
+	GetBrokerages(ctx context.Context) ([]models.BrokerageSummary, error)
+	ScoreStock(ctx context.Context, stock *models.Stock) (models.StockRecommendation, error)
 }
 
 // stockService implementación de StockService
 type stockService struct {
 	repo        repository.StockRepository
+	priceRepo   repository.PriceRepository
+	eventRepo   repository.EventRepository
+	syncRuns    repository.SyncRunRepository
+	taxonomy    TaxonomyService
 	stockClient *httpclient.StockClient
+	bus         *events.Bus
+	cache       *cache.Cache
+	metrics     *metrics.Metrics
+	benchmark   string
 	logger      *zap.Logger
 }
 
 // NewStockService crea una nueva instancia de StockService
 func NewStockService(
 	repo repository.StockRepository,
+	priceRepo repository.PriceRepository,
+	eventRepo repository.EventRepository,
+	syncRuns repository.SyncRunRepository,
+	taxonomy TaxonomyService,
 	stockClient *httpclient.StockClient,
+	bus *events.Bus,
+	cache *cache.Cache,
+	metrics *metrics.Metrics,
+	cfg *config.Config,
 	logger *zap.Logger,
 ) StockService {
 	return &stockService{
 		repo:        repo,
+		priceRepo:   priceRepo,
+		eventRepo:   eventRepo,
+		syncRuns:    syncRuns,
+		taxonomy:    taxonomy,
 		stockClient: stockClient,
+		bus:         bus,
+		cache:       cache,
+		metrics:     metrics,
+		benchmark:   cfg.BacktestBenchmark,
 		logger:      logger.Named("stock_service"),
 	}
 }
 
 // GetAllStocks obtiene todos los stocks
 func (s *stockService) GetAllStocks(ctx context.Context) ([]models.Stock, error) {
-	return s.repo.GetAll(ctx)
+	ctx, span := tracer.Start(ctx, "stockService.GetAllStocks")
+	defer span.End()
+
+	return cache.Load(ctx, s.cache, "stocks", func() ([]models.Stock, error) {
+		return s.repo.GetAll(ctx)
+	})
 }
 
 // GetStockByTicker obtiene un stock por su ticker
 func (s *stockService) GetStockByTicker(ctx context.Context, ticker string) (*models.Stock, error) {
+	ctx, span := tracer.Start(ctx, "stockService.GetStockByTicker")
+	defer span.End()
+
 	return s.repo.GetByTicker(ctx, ticker)
 }
 
 // SyncStocksFromAPI sincroniza los stocks desde la API externa
 func (s *stockService) SyncStocksFromAPI(ctx context.Context) (int, error) {
+	ctx, span := tracer.Start(ctx, "stockService.SyncStocksFromAPI")
+	defer span.End()
+
+	start := time.Now()
+	count, err := s.syncStocksFromAPI(ctx)
+	s.metrics.ObserveSync(time.Since(start), count, err)
+	s.recordSyncRun(ctx, start, count, err)
+
+	span.SetAttributes(attribute.Int("sync.count", count))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return count, err
+}
+
+// recordSyncRun guarda el resultado de una sincronización, que usa el chequeo de disponibilidad
+// para saber si los datos están al día. Se guarda aunque la solicitud se haya cancelado.
+func (s *stockService) recordSyncRun(ctx context.Context, start time.Time, count int, err error) {
+	run := &models.SyncRun{
+		StartedAt:  start,
+		FinishedAt: time.Now(),
+		Count:      count,
+		Succeeded:  err == nil,
+	}
+	if err != nil {
+		run.Error = err.Error()
+	}
+
+	if err := s.syncRuns.Create(context.WithoutCancel(ctx), run); err != nil {
+		logger.Ctx(ctx, s.logger).Warn("Error recording sync run", zap.Error(err))
+	}
+}
+
+// syncStocksFromAPI recorre las páginas de la API externa y guarda sus stocks
+func (s *stockService) syncStocksFromAPI(ctx context.Context) (int, error) {
 	timeStart := time.Now()
 	var nextPage string
 	var count int
@@ -71,9 +221,10 @@ func (s *stockService) SyncStocksFromAPI(ctx context.Context) (int, error) {
 
 	for {
 		// Obtener datos de la API
-		response, err := s.stockClient.GetStocks(nextPage)
+		response, err := s.stockClient.GetStocks(ctx, nextPage)
 		if err != nil {
-			s.logger.Error("Error getting stocks from the API", zap.Error(err))
+			logger.Ctx(ctx, s.logger).Error("Error getting stocks from the API", zap.Error(err))
+			s.invalidateAfterSync(ctx, count)
 			return count, fmt.Errorf("error getting stocks from the API: %w", err)
 		}
 
@@ -95,7 +246,7 @@ func (s *stockService) SyncStocksFromAPI(ctx context.Context) (int, error) {
 							// Enviar el error al canal
 						default:
 							// Si el canal está lleno, logear el error y continuar
-							s.logger.Error("Error processing stock item", zap.Error(err))
+							logger.Ctx(ctx, s.logger).Error("Error processing stock item", zap.Error(err))
 						}
 						return
 					}
@@ -115,7 +266,8 @@ func (s *stockService) SyncStocksFromAPI(ctx context.Context) (int, error) {
 		// Verificar si hay errores
 		select {
 		case err := <-errCh:
-			s.logger.Error("Error in worker", zap.Error(err))
+			logger.Ctx(ctx, s.logger).Error("Error in worker", zap.Error(err))
+			s.invalidateAfterSync(ctx, count)
 			return count, err
 		default:
 			// No hay errores, continuamos
@@ -128,16 +280,39 @@ func (s *stockService) SyncStocksFromAPI(ctx context.Context) (int, error) {
 		}
 	}
 
-	s.logger.Info("Synchronization completed", zap.Int("count", count), zap.Duration("duration", time.Since(timeStart)))
+	logger.Ctx(ctx, s.logger).Info("Synchronization completed", zap.Int("count", count), zap.Duration("duration", time.Since(timeStart)))
+
+	// Invalidar la caché antes de notificar, para que los suscriptores vean los datos nuevos
+	s.invalidateAfterSync(ctx, count)
+
+	// Notificar a los suscriptores que la sincronización terminó
+	s.bus.Publish(ctx, events.SyncCompleted, events.SyncCompletedPayload{
+		Count:     count,
+		StartedAt: timeStart,
+		Duration:  time.Since(timeStart),
+	})
+
 	return count, nil
 }
 
+// invalidateAfterSync descarta los listados y recomendaciones guardados en caché si la
+// sincronización guardó algún stock, aunque haya terminado con error
+func (s *stockService) invalidateAfterSync(ctx context.Context, count int) {
+	if count > 0 {
+		s.cache.Invalidate(ctx)
+	}
+}
+
 // processStockItem procesa un solo item de stock
 func (s *stockService) processStockItem(ctx context.Context, item models.StockItem, mu *sync.Mutex, count *int) error {
+	ctx, span := tracer.Start(ctx, "stockService.processStockItem",
+		trace.WithAttributes(attribute.String("stock.ticker", item.Ticker)))
+	defer span.End()
+
 	// Parsear la fecha
 	timeValue, err := time.Parse(time.RFC3339, item.Time)
 	if err != nil {
-		s.logger.Warn("Error parsing date, using current time",
+		logger.Ctx(ctx, s.logger).Warn("Error parsing date, using current time",
 			zap.String("time", item.Time),
 			zap.Error(err))
 		timeValue = time.Now()
@@ -156,10 +331,44 @@ func (s *stockService) processStockItem(ctx context.Context, item models.StockIt
 		Time:       timeValue,
 	}
 
+	// Registrar las etiquetas que no tienen puntuación en la taxonomía
+	for kind, labels := range map[string][]string{
+		models.TaxonomyKindRating: {item.RatingFrom, item.RatingTo},
+		models.TaxonomyKindAction: {item.Action},
+	} {
+		for _, label := range labels {
+			if err := s.taxonomy.Observe(ctx, kind, label); err != nil {
+				logger.Ctx(ctx, s.logger).Warn("Error recording unmapped label",
+					zap.String("kind", kind),
+					zap.String("label", label),
+					zap.Error(err))
+			}
+		}
+	}
+
+	// Registrar el evento en el historial
+	event := &models.RatingEvent{
+		Ticker:     item.Ticker,
+		Company:    item.Company,
+		Brokerage:  item.Brokerage,
+		Action:     item.Action,
+		RatingFrom: item.RatingFrom,
+		RatingTo:   item.RatingTo,
+		TargetFrom: item.TargetFrom,
+		TargetTo:   item.TargetTo,
+		Time:       timeValue,
+	}
+	if err := s.eventRepo.Create(ctx, event); err != nil {
+		logger.Ctx(ctx, s.logger).Error("Error recording rating event",
+			zap.String("ticker", item.Ticker),
+			zap.Error(err))
+		return fmt.Errorf("error recording rating event: %w", err)
+	}
+
 	// Verificar si ya existe
 	existing, err := s.repo.GetByTickerSimple(ctx, item.Ticker)
 	if err != nil {
-		s.logger.Error("Error checking existing stock",
+		logger.Ctx(ctx, s.logger).Error("Error checking existing stock",
 			zap.String("ticker", item.Ticker),
 			zap.Error(err))
 		return fmt.Errorf("error checking existing stock: %w", err)
@@ -168,11 +377,12 @@ func (s *stockService) processStockItem(ctx context.Context, item models.StockIt
 	if existing == nil {
 		// Crear nuevo stock
 		if err := s.repo.Create(ctx, stock); err != nil {
-			s.logger.Error("Error creating stock",
+			logger.Ctx(ctx, s.logger).Error("Error creating stock",
 				zap.String("ticker", item.Ticker),
 				zap.Error(err))
 			return fmt.Errorf("error creating stock: %w", err)
 		}
+		s.bus.Publish(ctx, events.StockSaved, events.StockSavedPayload{Stock: *stock, Created: true})
 	} else {
 		// Truncar la fecha a segundos para evitar problemas de precisión
 		timeValue = timeValue.Truncate(time.Second)
@@ -182,11 +392,12 @@ func (s *stockService) processStockItem(ctx context.Context, item models.StockIt
 		if timeValue.After(existing.Time) {
 			stock.ID = existing.ID
 			if err := s.repo.Update(ctx, stock); err != nil {
-				s.logger.Error("Error updating stock:",
+				logger.Ctx(ctx, s.logger).Error("Error updating stock:",
 					zap.String("ticker", item.Ticker),
 					zap.Error(err))
 				return fmt.Errorf("error updating stock: %w", err)
 			}
+			s.bus.Publish(ctx, events.StockSaved, events.StockSavedPayload{Stock: *stock, Created: false})
 		}
 	}
 
@@ -198,96 +409,275 @@ func (s *stockService) processStockItem(ctx context.Context, item models.StockIt
 }
 
 // GetRecommendations obtiene recomendaciones de stocks para invertir
-func (s *stockService) GetRecommendations(ctx context.Context) ([]models.StockRecommendation, error) {
+func (s *stockService) GetRecommendations(ctx context.Context, sortBy RecommendationSort) ([]models.StockRecommendation, error) {
+	return s.GetTopRecommendations(ctx, sortBy, recommendationLimit)
+}
+
+// GetTopRecommendations obtiene las mejores recomendaciones hasta un límite
+func (s *stockService) GetTopRecommendations(ctx context.Context, sortBy RecommendationSort, limit int) ([]models.StockRecommendation, error) {
+	ctx, span := tracer.Start(ctx, "stockService.GetTopRecommendations")
+	defer span.End()
+
+	key := fmt.Sprintf("recommendations:%s:%d", sortBy, limit)
+	return cache.Load(ctx, s.cache, key, func() ([]models.StockRecommendation, error) {
+		return s.computeTopRecommendations(ctx, sortBy, limit)
+	})
+}
+
+// computeTopRecommendations calcula las mejores recomendaciones sobre todos los stocks
+func (s *stockService) computeTopRecommendations(ctx context.Context, sortBy RecommendationSort, limit int) ([]models.StockRecommendation, error) {
+	start := time.Now()
+	defer func() { s.metrics.ObserveRecommendations(metrics.RecommendationsTop, time.Since(start)) }()
+
 	// Obtener todos los stocks
 	stocks, err := s.repo.GetAll(ctx)
 	if err != nil {
-		s.logger.Error("Error getting stocks for recommendations", zap.Error(err))
+		logger.Ctx(ctx, s.logger).Error("Error getting stocks for recommendations", zap.Error(err))
+		return nil, err
+	}
+
+	// Obtener el último precio de cierre de cada ticker
+	closes, err := s.latestCloses(ctx)
+	if err != nil {
+		logger.Ctx(ctx, s.logger).Error("Error getting prices for recommendations", zap.Error(err))
 		return nil, err
 	}
 
-	return s.processRecommendations(stocks), nil
+	return s.processRecommendations(stocks, closes, sortBy, limit), nil
 }
 
 // GetRecommendationsByTime obtiene recomendaciones de stocks por fecha
-func (s *stockService) GetRecommendationsByTime(ctx context.Context, time string) ([]models.StockRecommendation, error) {
+func (s *stockService) GetRecommendationsByTime(ctx context.Context, time string, sortBy RecommendationSort) ([]models.StockRecommendation, error) {
+	ctx, span := tracer.Start(ctx, "stockService.GetRecommendationsByTime")
+	defer span.End()
+
+	key := fmt.Sprintf("recommendations:%s:%s", sortBy, time)
+	return cache.Load(ctx, s.cache, key, func() ([]models.StockRecommendation, error) {
+		return s.computeRecommendationsByTime(ctx, time, sortBy)
+	})
+}
+
+// computeRecommendationsByTime calcula las recomendaciones sobre los stocks de una fecha
+func (s *stockService) computeRecommendationsByTime(ctx context.Context, date string, sortBy RecommendationSort) ([]models.StockRecommendation, error) {
+	start := time.Now()
+	defer func() { s.metrics.ObserveRecommendations(metrics.RecommendationsByTime, time.Since(start)) }()
+
 	// Obtener stocks por fecha
-	stocks, err := s.repo.GetAllByTime(ctx, time)
+	stocks, err := s.repo.GetAllByTime(ctx, date)
+	if err != nil {
+		logger.Ctx(ctx, s.logger).Error("Error getting stocks for recommendations", zap.Error(err))
+		return nil, err
+	}
+
+	// Obtener el último precio de cierre de cada ticker
+	closes, err := s.latestCloses(ctx)
 	if err != nil {
-		s.logger.Error("Error getting stocks for recommendations", zap.Error(err))
+		logger.Ctx(ctx, s.logger).Error("Error getting prices for recommendations", zap.Error(err))
 		return nil, err
 	}
 
-	return s.processRecommendations(stocks), nil
+	return s.processRecommendations(stocks, closes, sortBy, recommendationLimit), nil
 }
 
-// processRecommendations procesa una lista de stocks y devuelve recomendaciones ordenadas
-func (s *stockService) processRecommendations(stocks []models.Stock) []models.StockRecommendation {
+// FindStocks obtiene una página de stocks que cumplen los filtros
+func (s *stockService) FindStocks(ctx context.Context, filter models.StockFilter) ([]models.Stock, int64, error) {
+	ctx, span := tracer.Start(ctx, "stockService.FindStocks")
+	defer span.End()
+
+	// stockPage agrupa los resultados para guardarlos juntos en la caché
+	type stockPage struct {
+		Items []models.Stock `json:"items"`
+		Total int64          `json:"total"`
+	}
+
+	key := fmt.Sprintf("stocks:%q:%q:%d:%d", filter.Ticker, filter.Brokerage, filter.Limit, filter.Offset)
+	page, err := cache.Load(ctx, s.cache, key, func() (stockPage, error) {
+		stocks, total, err := s.repo.Find(ctx, filter)
+		return stockPage{Items: stocks, Total: total}, err
+	})
+	return page.Items, page.Total, err
+}
+
+// GetRatingHistory obtiene una página del historial de calificaciones que cumple los filtros
+func (s *stockService) GetRatingHistory(ctx context.Context, filter models.RatingEventFilter) ([]models.RatingEvent, int64, error) {
+	ctx, span := tracer.Start(ctx, "stockService.GetRatingHistory")
+	defer span.End()
+
+	return s.eventRepo.Find(ctx, filter)
+}
+
+// GetBrokerages obtiene los brokers con su actividad en el historial de calificaciones
+func (s *stockService) GetBrokerages(ctx context.Context) ([]models.BrokerageSummary, error) {
+	ctx, span := tracer.Start(ctx, "stockService.GetBrokerages")
+	defer span.End()
+
+	return s.eventRepo.GetBrokerages(ctx)
+}
+
+// ScoreStock calcula la recomendación de un stock, con el potencial desde su último precio si se conoce
+func (s *stockService) ScoreStock(ctx context.Context, stock *models.Stock) (models.StockRecommendation, error) {
+	ctx, span := tracer.Start(ctx, "stockService.ScoreStock")
+	defer span.End()
+
+	recommendation := s.calculateRecommendationScore(stock)
+
+	price, err := s.priceRepo.GetLatestByTicker(ctx, stock.Ticker)
+	if err != nil {
+		return recommendation, err
+	}
+	if price != nil {
+		s.applyUpside(&recommendation, price.Close)
+	}
+
+	return recommendation, nil
+}
+
+// latestCloses obtiene el último precio de cierre conocido de cada ticker
+func (s *stockService) latestCloses(ctx context.Context) (map[string]float64, error) {
+	prices, err := s.priceRepo.GetLatest(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	closes := make(map[string]float64, len(prices))
+	for ticker, price := range prices {
+		closes[ticker] = price.Close
+	}
+
+	return closes, nil
+}
+
+// UpsertPrices guarda precios de cierre por ticker y fecha
+func (s *stockService) UpsertPrices(ctx context.Context, items []models.StockPriceItem) (int, error) {
+	ctx, span := tracer.Start(ctx, "stockService.UpsertPrices")
+	defer span.End()
+
+	prices := make([]models.StockPrice, 0, len(items))
+
+	for _, item := range items {
+		if item.Ticker == "" {
+			return 0, fmt.Errorf("%w: ticker is required", ErrInvalidInput)
+		}
+		if item.Close <= 0 {
+			return 0, fmt.Errorf("%w: close must be positive for %s", ErrInvalidInput, item.Ticker)
+		}
+
+		date, err := time.Parse(time.DateOnly, item.Date)
+		if err != nil {
+			return 0, fmt.Errorf("%w: invalid date %q for %s", ErrInvalidInput, item.Date, item.Ticker)
+		}
+
+		prices = append(prices, models.StockPrice{
+			Ticker: item.Ticker,
+			Date:   date,
+			Close:  item.Close,
+		})
+	}
+
+	if err := s.priceRepo.Upsert(ctx, prices); err != nil {
+		logger.Ctx(ctx, s.logger).Error("Error saving prices", zap.Error(err))
+		return 0, fmt.Errorf("error saving prices: %w", err)
+	}
+
+	// Los precios cambian el potencial de las recomendaciones
+	s.cache.Invalidate(ctx)
+
+	return len(prices), nil
+}
+
+// processRecommendations procesa una lista de stocks y devuelve las mejores recomendaciones ordenadas
+func (s *stockService) processRecommendations(
+	stocks []models.Stock,
+	closes map[string]float64,
+	sortBy RecommendationSort,
+	limit int,
+) []models.StockRecommendation {
 	recommendations := make([]models.StockRecommendation, 0, len(stocks))
 
 	for _, stock := range stocks {
 		// Calcular puntuación y razón
-		score, reason, potentialUp := s.calculateRecommendationScore(&stock)
+		recommendation := s.calculateRecommendationScore(&stock)
 
-		recommendations = append(recommendations, models.StockRecommendation{
-			Stock:       stock,
-			Score:       score,
-			Reason:      reason,
-			PotentialUp: potentialUp,
-		})
+		// Calcular el potencial desde el precio actual si se conoce
+		if price, ok := closes[stock.Ticker]; ok {
+			s.applyUpside(&recommendation, price)
+		}
+
+		recommendations = append(recommendations, recommendation)
 	}
 
-	// Ordenar recomendaciones por puntuación (de mayor a menor)
-	sort.Slice(recommendations, func(i, j int) bool {
-		return recommendations[i].Score > recommendations[j].Score
-	})
+	if sortBy == SortByUpside {
+		// Ordenar por potencial (de mayor a menor), dejando al final los que no tienen precio
+		sort.SliceStable(recommendations, func(i, j int) bool {
+			a, b := recommendations[i].PotentialUp, recommendations[j].PotentialUp
+			if a == nil || b == nil {
+				return a != nil
+			}
+			return *a > *b
+		})
+	} else {
+		// Ordenar recomendaciones por puntuación (de mayor a menor)
+		sort.Slice(recommendations, func(i, j int) bool {
+			return recommendations[i].Score > recommendations[j].Score
+		})
+	}
 
-	// Limitar a 5 recomendaciones
-	if len(recommendations) > 5 {
-		recommendations = recommendations[:5]
+	// Limitar la cantidad de recomendaciones
+	if len(recommendations) > limit {
+		recommendations = recommendations[:limit]
 	}
 
 	return recommendations
 }
 
-// calculateRecommendationScore calcula una puntuación para una recomendación
-func (s *stockService) calculateRecommendationScore(stock *models.Stock) (float64, []string, float64) {
-	var score float64
+// calculateRecommendationScore calcula la puntuación de una recomendación, junto con
+// las razones legibles y la contribución de cada factor
+func (s *stockService) calculateRecommendationScore(stock *models.Stock) models.StockRecommendation {
+	recommendation := models.StockRecommendation{Stock: *stock}
 	var reasons []string
-	var potentialUp float64
 
 	// Puntuación base por mejora de calificación
+	rating := models.ScoreFactor{
+		Factor: FactorRating,
+		Input:  fmt.Sprintf("%s -> %s", stock.RatingFrom, stock.RatingTo),
+		Weight: ratingWeight,
+	}
 	if stock.RatingFrom != stock.RatingTo {
-		ratingScore := s.getRatingScore(stock.RatingTo) - s.getRatingScore(stock.RatingFrom)
-		score += ratingScore
-		if ratingScore > 0 {
+		rating.Value = s.getRatingScore(stock.RatingTo) - s.getRatingScore(stock.RatingFrom)
+		rating.Contribution = rating.Value * rating.Weight
+		if rating.Value > 0 {
 			reasons = append(reasons, fmt.Sprintf("Rating improvement from %s to %s", stock.RatingFrom, stock.RatingTo))
 		}
 	}
 
 	// Puntuación por acción
-	actionScore := s.getActionScore(stock.Action)
-	score += actionScore
-	if actionScore > 0 {
+	action := models.ScoreFactor{
+		Factor: FactorAction,
+		Input:  stock.Action,
+		Value:  s.getActionScore(stock.Action),
+		Weight: actionWeight,
+	}
+	action.Contribution = action.Value * action.Weight
+	if action.Value > 0 {
 		reasons = append(reasons, fmt.Sprintf("Action taken %s %s", stock.Action, stock.Brokerage))
 	}
 
 	// Puntuación por potencial de crecimiento basado en target
-	if stock.TargetFrom != "" && stock.TargetTo != "" {
-		fromValue, fromErr := s.parsePrice(stock.TargetFrom)
-		toValue, toErr := s.parsePrice(stock.TargetTo)
-
-		if fromErr == nil && toErr == nil && fromValue > 0 {
-			growthPercent := (toValue - fromValue) / fromValue * 100
-			potentialUp = growthPercent
-
-			// Puntuar si hay un aumento en el precio objetivo
-			if growthPercent > 0 {
-				score += growthPercent / 10 // Normalizar el impacto
-				reasons = append(reasons, fmt.Sprintf("Increase in target price by %.2f%%", growthPercent))
-			} else {
-				reasons = append(reasons, fmt.Sprintf("Target price decreased by %.2f%%", growthPercent))
-			}
+	target := models.ScoreFactor{
+		Factor: FactorTargetChange,
+		Input:  fmt.Sprintf("%s -> %s", stock.TargetFrom, stock.TargetTo),
+		Weight: targetChangeWeight,
+	}
+	if growthPercent, ok := targetChangePercent(stock); ok {
+		recommendation.TargetChange = growthPercent
+		target.Value = growthPercent
+
+		// Puntuar si hay un aumento en el precio objetivo
+		if growthPercent > 0 {
+			target.Contribution = growthPercent * target.Weight
+			reasons = append(reasons, fmt.Sprintf("Increase in target price by %.2f%%", growthPercent))
+		} else {
+			reasons = append(reasons, fmt.Sprintf("Target price decreased by %.2f%%", growthPercent))
 		}
 	}
 
@@ -303,47 +693,44 @@ func (s *stockService) calculateRecommendationScore(stock *models.Stock) (float6
 		reasons = append(reasons, "Recommendation based on general analysis")
 	}
 
-	return score, reasons, potentialUp
+	recommendation.Breakdown = []models.ScoreFactor{rating, action, target}
+	for _, factor := range recommendation.Breakdown {
+		recommendation.Score += factor.Contribution
+	}
+	recommendation.Reason = reasons
+
+	return recommendation
 }
 
-// getRatingScore asigna una puntuación numérica a una calificación
-func (s *stockService) getRatingScore(rating string) float64 {
-	switch rating {
-	case "Strong-Buy", "Buy", "Speculative Buy", "Positive", "Market Outperform", "Sector Outperform":
-		return 5
-	case "Outperform", "Outperformer", "Overweight":
-		return 4
-	case "Neutral", "Hold", "Equal Weight", "In-Line", "Inline", "Sector Perform", "Market Perform", "Sector Weight", "Peer Perform":
-		return 3
-	case "Underweight", "Underperform", "Sector Underperform", "Reduce", "Negative", "Cautious":
-		return 2
-	case "Sell":
-		return 1
-	default:
-		return 0
+// applyUpside calcula el potencial desde el precio actual hasta el precio objetivo
+func (s *stockService) applyUpside(recommendation *models.StockRecommendation, currentPrice float64) {
+	if currentPrice <= 0 {
+		return
+	}
+
+	price := currentPrice
+	recommendation.CurrentPrice = &price
+
+	target, err := parsePrice(recommendation.Stock.TargetTo)
+	if err != nil || target <= 0 {
+		return
 	}
+
+	upside := (target - currentPrice) / currentPrice * 100
+	recommendation.PotentialUp = &upside
+	recommendation.AboveTarget = currentPrice > target
+}
+
+// getRatingScore asigna una puntuación numérica a una calificación según la taxonomía
+func (s *stockService) getRatingScore(rating string) float64 {
+	score, _ := s.taxonomy.Score(models.TaxonomyKindRating, rating)
+	return score
 }
 
-// getActionScore asigna una puntuación numérica a una acción
+// getActionScore asigna una puntuación numérica a una acción según la taxonomía
 func (s *stockService) getActionScore(action string) float64 {
-	switch action {
-	case "upgraded by":
-		return 8
-	case "target raised by":
-		return 7
-	case "target set by":
-		return 6
-	case "initiated by":
-		return 5
-	case "reiterated by":
-		return 4
-	case "downgraded by":
-		return 3
-	case "target lowered by":
-		return 1
-	default:
-		return 0
-	}
+	score, _ := s.taxonomy.Score(models.TaxonomyKindAction, action)
+	return score
 }
 
 // TODO: Implementar una puntuación real basada en un API como Alphavantage
@@ -359,10 +746,27 @@ func (s *stockService) getActionScore(action string) float64 {
 // 	}
 // }
 
+// priceSymbols contiene los símbolos que se eliminan al convertir un precio
+var priceSymbols = regexp.MustCompile(`[$,\s]`)
+
 // parsePrice convierte un string de precio a float64
-func (s *stockService) parsePrice(price string) (float64, error) {
+func parsePrice(price string) (float64, error) {
 	// Eliminar el símbolo de dólar, comas y espacios con expresión regular
-	re := regexp.MustCompile(`[$,\s]`)
-	price = re.ReplaceAllString(price, "")
+	price = priceSymbols.ReplaceAllString(price, "")
 	return strconv.ParseFloat(price, 64)
 }
+
+// targetChangePercent calcula el cambio porcentual entre el precio objetivo anterior y el nuevo
+func targetChangePercent(stock *models.Stock) (float64, bool) {
+	if stock.TargetFrom == "" || stock.TargetTo == "" {
+		return 0, false
+	}
+
+	fromValue, fromErr := parsePrice(stock.TargetFrom)
+	toValue, toErr := parsePrice(stock.TargetTo)
+	if fromErr != nil || toErr != nil || fromValue <= 0 {
+		return 0, false
+	}
+
+	return (toValue - fromValue) / fromValue * 100, true
+}
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/liferip/stock-analyzer/backend/internal/events"
+	"github.com/liferip/stock-analyzer/backend/internal/models"
+)
+
+const (
+	// streamHistorySize es la cantidad de eventos recientes guardados para retomar el stream
+	streamHistorySize = 1000
+	// streamSubscriberBuffer es la cantidad de eventos que un suscriptor puede tener sin leer
+	// antes de ser desconectado
+	streamSubscriberBuffer = 256
+)
+
+// StockStream distribuye en vivo los stocks creados o actualizados por la sincronización
+type StockStream interface {
+	Subscribe(filter models.StockStreamFilter, lastEventID uint64) ([]models.StockStreamEvent, *StockSubscription)
+	Unsubscribe(sub *StockSubscription)
+	Publish(created bool, stock models.Stock) models.StockStreamEvent
+	Close()
+}
+
+// StockSubscription es la suscripción de un cliente al stream. El canal Events se cierra
+// cuando el cliente no lee a tiempo o cuando el stream se cierra; en el primer caso el cliente
+// puede reconectarse y retomar desde el último ID recibido.
+type StockSubscription struct {
+	Events <-chan models.StockStreamEvent
+
+	events chan models.StockStreamEvent
+	filter models.StockStreamFilter
+}
+
+// stockStream implementación de StockStream en memoria
+type stockStream struct {
+	logger *zap.Logger
+
+	mu          sync.Mutex
+	lastID      uint64
+	history     []models.StockStreamEvent
+	subscribers map[*StockSubscription]struct{}
+	closed      bool
+}
+
+// NewStockStream crea una nueva instancia de StockStream
+func NewStockStream(logger *zap.Logger) StockStream {
+	return &stockStream{
+		logger:      logger.Named("stock_stream"),
+		subscribers: make(map[*StockSubscription]struct{}),
+	}
+}
+
+// RegisterStockStream publica en el stream cada stock guardado por la sincronización
+func RegisterStockStream(bus *events.Bus, stream StockStream) {
+	bus.Subscribe(func(ctx context.Context, event events.Event) {
+		if event.Type != events.StockSaved {
+			return
+		}
+
+		payload := event.Payload.(events.StockSavedPayload)
+		stream.Publish(payload.Created, payload.Stock)
+	})
+}
+
+// Subscribe registra un suscriptor y devuelve los eventos guardados posteriores a lastEventID
+// que cumplen el filtro. Con lastEventID 0 no se devuelve historial. Si lastEventID es mayor
+// que el último ID publicado (por ejemplo tras un reinicio) se devuelve todo el historial.
+func (s *stockStream) Subscribe(filter models.StockStreamFilter, lastEventID uint64) ([]models.StockStreamEvent, *StockSubscription) {
+	ch := make(chan models.StockStreamEvent, streamSubscriberBuffer)
+	sub := &StockSubscription{
+		Events: ch,
+		events: ch,
+		filter: filter,
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		close(ch)
+		return nil, sub
+	}
+
+	var replay []models.StockStreamEvent
+	if lastEventID > 0 {
+		if lastEventID > s.lastID {
+			lastEventID = 0
+		}
+		for _, event := range s.history {
+			if event.ID > lastEventID && matchStreamFilter(filter, &event.Stock) {
+				replay = append(replay, event)
+			}
+		}
+	}
+
+	s.subscribers[sub] = struct{}{}
+	return replay, sub
+}
+
+// Unsubscribe elimina un suscriptor y cierra su canal
+func (s *stockStream) Unsubscribe(sub *StockSubscription) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.subscribers[sub]; ok {
+		delete(s.subscribers, sub)
+		close(sub.events)
+	}
+}
+
+// Publish numera el evento, lo guarda en el historial y lo envía a los suscriptores sin
+// bloquear; los suscriptores que no tienen espacio en su buffer se desconectan
+func (s *stockStream) Publish(created bool, stock models.Stock) models.StockStreamEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastID++
+	event := models.StockStreamEvent{
+		ID:      s.lastID,
+		Time:    time.Now(),
+		Created: created,
+		Stock:   stock,
+	}
+
+	s.history = append(s.history, event)
+	if len(s.history) > streamHistorySize {
+		s.history = s.history[len(s.history)-streamHistorySize:]
+	}
+
+	for sub := range s.subscribers {
+		if !matchStreamFilter(sub.filter, &stock) {
+			continue
+		}
+
+		select {
+		case sub.events <- event:
+		default:
+			s.logger.Warn("Disconnecting slow stream subscriber", zap.Uint64("event_id", event.ID))
+			delete(s.subscribers, sub)
+			close(sub.events)
+		}
+	}
+
+	return event
+}
+
+// Close desconecta a todos los suscriptores; se usa al apagar el servidor
+func (s *stockStream) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+	for sub := range s.subscribers {
+		delete(s.subscribers, sub)
+		close(sub.events)
+	}
+}
+
+// matchStreamFilter indica si un stock cumple el filtro de un suscriptor
+func matchStreamFilter(filter models.StockStreamFilter, stock *models.Stock) bool {
+	if len(filter.Tickers) > 0 && !containsFold(filter.Tickers, stock.Ticker) {
+		return false
+	}
+	if len(filter.Brokerages) > 0 && !containsFold(filter.Brokerages, stock.Brokerage) {
+		return false
+	}
+	return true
+}
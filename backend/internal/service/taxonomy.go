@@ -0,0 +1,466 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"github.com/liferip/stock-analyzer/backend/config"
+	"github.com/liferip/stock-analyzer/backend/internal/cache"
+	"github.com/liferip/stock-analyzer/backend/internal/models"
+	"github.com/liferip/stock-analyzer/backend/internal/repository"
+)
+
+// taxonomyRefreshTimeout es el tiempo máximo de cada recarga periódica de la taxonomía
+const taxonomyRefreshTimeout = 30 * time.Second
+
+// ErrNotFound indica que el recurso solicitado no existe
+var ErrNotFound = errors.New("not found")
+
+// ErrConflict indica que el recurso ya existe
+var ErrConflict = errors.New("already exists")
+
+// defaultTaxonomy contiene la taxonomía inicial, equivalente a las puntuaciones
+// que estaban fijas en el código. Se usa solo si la base de datos está vacía.
+var defaultTaxonomy = []models.TaxonomyLabel{
+	{Kind: models.TaxonomyKindRating, Name: "Strong-Buy", Score: 5},
+	{Kind: models.TaxonomyKindRating, Name: "Buy", Score: 5},
+	{Kind: models.TaxonomyKindRating, Name: "Speculative Buy", Score: 5},
+	{Kind: models.TaxonomyKindRating, Name: "Positive", Score: 5},
+	{Kind: models.TaxonomyKindRating, Name: "Market Outperform", Score: 5},
+	{Kind: models.TaxonomyKindRating, Name: "Sector Outperform", Score: 5},
+	{Kind: models.TaxonomyKindRating, Name: "Outperform", Score: 4, Aliases: []models.TaxonomyAlias{
+		{Kind: models.TaxonomyKindRating, Alias: "Outperformer"},
+	}},
+	{Kind: models.TaxonomyKindRating, Name: "Overweight", Score: 4},
+	{Kind: models.TaxonomyKindRating, Name: "Neutral", Score: 3},
+	{Kind: models.TaxonomyKindRating, Name: "Hold", Score: 3},
+	{Kind: models.TaxonomyKindRating, Name: "Equal Weight", Score: 3},
+	{Kind: models.TaxonomyKindRating, Name: "In-Line", Score: 3, Aliases: []models.TaxonomyAlias{
+		{Kind: models.TaxonomyKindRating, Alias: "Inline"},
+	}},
+	{Kind: models.TaxonomyKindRating, Name: "Sector Perform", Score: 3},
+	{Kind: models.TaxonomyKindRating, Name: "Market Perform", Score: 3},
+	{Kind: models.TaxonomyKindRating, Name: "Sector Weight", Score: 3},
+	{Kind: models.TaxonomyKindRating, Name: "Peer Perform", Score: 3},
+	{Kind: models.TaxonomyKindRating, Name: "Underweight", Score: 2},
+	{Kind: models.TaxonomyKindRating, Name: "Underperform", Score: 2},
+	{Kind: models.TaxonomyKindRating, Name: "Sector Underperform", Score: 2},
+	{Kind: models.TaxonomyKindRating, Name: "Reduce", Score: 2},
+	{Kind: models.TaxonomyKindRating, Name: "Negative", Score: 2},
+	{Kind: models.TaxonomyKindRating, Name: "Cautious", Score: 2},
+	{Kind: models.TaxonomyKindRating, Name: "Sell", Score: 1},
+	{Kind: models.TaxonomyKindAction, Name: "upgraded by", Score: 8},
+	{Kind: models.TaxonomyKindAction, Name: "target raised by", Score: 7},
+	{Kind: models.TaxonomyKindAction, Name: "target set by", Score: 6},
+	{Kind: models.TaxonomyKindAction, Name: "initiated by", Score: 5},
+	{Kind: models.TaxonomyKindAction, Name: "reiterated by", Score: 4},
+	{Kind: models.TaxonomyKindAction, Name: "downgraded by", Score: 3},
+	{Kind: models.TaxonomyKindAction, Name: "target lowered by", Score: 1},
+}
+
+// ParseTaxonomyKind valida el tipo de etiqueta
+func ParseTaxonomyKind(kind string) (string, error) {
+	switch kind {
+	case models.TaxonomyKindRating, models.TaxonomyKindAction:
+		return kind, nil
+	default:
+		return "", fmt.Errorf("%w: unknown taxonomy kind %q", ErrInvalidInput, kind)
+	}
+}
+
+// TaxonomyService interfaz que define las operaciones sobre la taxonomía de calificaciones y acciones
+type TaxonomyService interface {
+	Score(kind, label string) (float64, bool)
+	Observe(ctx context.Context, kind, label string) error
+	ListLabels(ctx context.Context, kind string) ([]models.TaxonomyLabel, error)
+	CreateLabel(ctx context.Context, kind string, input models.TaxonomyLabelInput) (*models.TaxonomyLabel, error)
+	UpdateLabel(ctx context.Context, kind, id string, input models.TaxonomyLabelInput) (*models.TaxonomyLabel, error)
+	DeleteLabel(ctx context.Context, kind, id string) error
+	CreateAlias(ctx context.Context, kind string, input models.TaxonomyAliasInput) (*models.TaxonomyAlias, error)
+	DeleteAlias(ctx context.Context, kind, id string) error
+	ListUnmapped(ctx context.Context, kind string) ([]models.UnmappedLabel, error)
+	Refresh(ctx context.Context) error
+}
+
+// taxonomyService implementación de TaxonomyService con las puntuaciones en memoria
+type taxonomyService struct {
+	repo   repository.TaxonomyRepository
+	cache  *cache.Cache
+	logger *zap.Logger
+
+	// reloadMu evita que una recarga con datos anteriores reemplace a una más reciente
+	reloadMu sync.Mutex
+
+	mu sync.RWMutex
+	// scores contiene la puntuación por tipo y etiqueta (canónica o alias)
+	scores map[string]map[string]float64
+	// canonical contiene el nombre canónico por tipo y etiqueta (canónica o alias)
+	canonical map[string]map[string]string
+}
+
+// NewTaxonomyService crea una nueva instancia de TaxonomyService, cargando la
+// taxonomía inicial si la base de datos está vacía
+func NewTaxonomyService(repo repository.TaxonomyRepository, cache *cache.Cache, logger *zap.Logger) (TaxonomyService, error) {
+	s := &taxonomyService{
+		repo:   repo,
+		cache:  cache,
+		logger: logger.Named("taxonomy_service"),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	count, err := repo.CountLabels(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error counting taxonomy labels: %w", err)
+	}
+
+	if count == 0 {
+		labels := make([]models.TaxonomyLabel, len(defaultTaxonomy))
+		for i, label := range defaultTaxonomy {
+			labels[i] = label
+			labels[i].Aliases = append([]models.TaxonomyAlias(nil), label.Aliases...)
+		}
+		switch err := repo.CreateLabels(ctx, labels); {
+		case errors.Is(err, repository.ErrDuplicate):
+			// Otra instancia creó la taxonomía al mismo tiempo
+			s.logger.Info("Default taxonomy already created")
+		case err != nil:
+			return nil, fmt.Errorf("error seeding taxonomy: %w", err)
+		default:
+			s.logger.Info("Default taxonomy created", zap.Int("labels", len(labels)))
+		}
+	}
+
+	if err := s.reload(ctx); err != nil {
+		return nil, fmt.Errorf("error loading taxonomy: %w", err)
+	}
+
+	return s, nil
+}
+
+// RegisterTaxonomyRefresh vuelve a leer la taxonomía cada TAXONOMY_REFRESH_SECONDS, para que
+// cada instancia vea las etiquetas y alias modificados desde las demás
+func RegisterTaxonomyRefresh(lc fx.Lifecycle, taxonomy TaxonomyService, cfg *config.Config, logger *zap.Logger) {
+	if cfg.TaxonomyRefresh <= 0 {
+		return
+	}
+	logger = logger.Named("taxonomy_refresh")
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			go func() {
+				defer close(done)
+
+				ticker := time.NewTicker(cfg.TaxonomyRefresh)
+				defer ticker.Stop()
+
+				for {
+					select {
+					case <-runCtx.Done():
+						return
+					case <-ticker.C:
+					}
+
+					ctx, cancel := context.WithTimeout(runCtx, taxonomyRefreshTimeout)
+					if err := taxonomy.Refresh(ctx); err != nil && runCtx.Err() == nil {
+						logger.Error("Error refreshing taxonomy", zap.Error(err))
+					}
+					cancel()
+				}
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		},
+	})
+}
+
+// Refresh vuelve a cargar la taxonomía desde la base de datos; solo invalida la caché si cambió
+func (s *taxonomyService) Refresh(ctx context.Context) error {
+	return s.reload(ctx)
+}
+
+// reload vuelve a cargar la taxonomía en memoria desde la base de datos e invalida la caché
+// de recomendaciones si cambió alguna puntuación o alias
+func (s *taxonomyService) reload(ctx context.Context) error {
+	s.reloadMu.Lock()
+	defer s.reloadMu.Unlock()
+
+	labels, err := s.repo.GetLabels(ctx, "")
+	if err != nil {
+		return err
+	}
+
+	scores := make(map[string]map[string]float64)
+	canonical := make(map[string]map[string]string)
+	for _, label := range labels {
+		if scores[label.Kind] == nil {
+			scores[label.Kind] = make(map[string]float64)
+			canonical[label.Kind] = make(map[string]string)
+		}
+		scores[label.Kind][label.Name] = label.Score
+		canonical[label.Kind][label.Name] = label.Name
+		for _, alias := range label.Aliases {
+			scores[label.Kind][alias.Alias] = label.Score
+			canonical[label.Kind][alias.Alias] = label.Name
+		}
+	}
+
+	s.mu.Lock()
+	changed := !reflect.DeepEqual(scores, s.scores) || !reflect.DeepEqual(canonical, s.canonical)
+	s.scores = scores
+	s.canonical = canonical
+	s.mu.Unlock()
+
+	// Las puntuaciones cambian el resultado de las recomendaciones guardadas en caché
+	if changed {
+		s.cache.Invalidate(ctx)
+	}
+
+	return nil
+}
+
+// Score obtiene la puntuación de una etiqueta, indicando si está en la taxonomía
+func (s *taxonomyService) Score(kind, label string) (float64, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	score, ok := s.scores[kind][label]
+	return score, ok
+}
+
+// isMapped indica si una etiqueta existe como canónica o como alias
+func (s *taxonomyService) isMapped(kind, label string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, ok := s.canonical[kind][label]
+	return ok
+}
+
+// Observe registra una etiqueta recibida durante la sincronización si no está en la taxonomía
+func (s *taxonomyService) Observe(ctx context.Context, kind, label string) error {
+	if label == "" || s.isMapped(kind, label) {
+		return nil
+	}
+
+	if err := s.repo.RecordUnmapped(ctx, kind, label, time.Now()); err != nil {
+		return fmt.Errorf("error recording unmapped label: %w", err)
+	}
+
+	return nil
+}
+
+// ListLabels obtiene las etiquetas canónicas de un tipo con sus alias
+func (s *taxonomyService) ListLabels(ctx context.Context, kind string) ([]models.TaxonomyLabel, error) {
+	return s.repo.GetLabels(ctx, kind)
+}
+
+// CreateLabel crea una etiqueta canónica
+func (s *taxonomyService) CreateLabel(ctx context.Context, kind string, input models.TaxonomyLabelInput) (*models.TaxonomyLabel, error) {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidInput)
+	}
+	if input.Score == nil {
+		return nil, fmt.Errorf("%w: score is required", ErrInvalidInput)
+	}
+	if s.isMapped(kind, name) {
+		return nil, fmt.Errorf("%w: %s label %q is already mapped", ErrConflict, kind, name)
+	}
+
+	label := models.TaxonomyLabel{
+		Kind:    kind,
+		Name:    name,
+		Score:   *input.Score,
+		Aliases: []models.TaxonomyAlias{},
+	}
+	if err := s.repo.CreateLabels(ctx, []models.TaxonomyLabel{label}); err != nil {
+		if errors.Is(err, repository.ErrDuplicate) {
+			return nil, fmt.Errorf("%w: %s label %q is already mapped", ErrConflict, kind, name)
+		}
+		return nil, fmt.Errorf("error creating label: %w", err)
+	}
+
+	return s.afterChange(ctx, kind, name)
+}
+
+// UpdateLabel actualiza el nombre o la puntuación de una etiqueta canónica
+func (s *taxonomyService) UpdateLabel(ctx context.Context, kind, id string, input models.TaxonomyLabelInput) (*models.TaxonomyLabel, error) {
+	label, err := s.getLabel(ctx, kind, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if name := strings.TrimSpace(input.Name); name != "" && name != label.Name {
+		if s.isMapped(kind, name) {
+			return nil, fmt.Errorf("%w: %s label %q is already mapped", ErrConflict, kind, name)
+		}
+		label.Name = name
+	}
+	if input.Score != nil {
+		label.Score = *input.Score
+	}
+
+	if err := s.repo.UpdateLabel(ctx, label); err != nil {
+		if errors.Is(err, repository.ErrDuplicate) {
+			return nil, fmt.Errorf("%w: %s label %q is already mapped", ErrConflict, kind, label.Name)
+		}
+		return nil, fmt.Errorf("error updating label: %w", err)
+	}
+
+	return s.afterChange(ctx, kind, label.Name)
+}
+
+// DeleteLabel elimina una etiqueta canónica y sus alias
+func (s *taxonomyService) DeleteLabel(ctx context.Context, kind, id string) error {
+	if _, err := s.getLabel(ctx, kind, id); err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteLabel(ctx, id); err != nil {
+		return fmt.Errorf("error deleting label: %w", err)
+	}
+
+	return s.reload(ctx)
+}
+
+// CreateAlias asocia una etiqueta recibida de la API externa con una etiqueta canónica
+func (s *taxonomyService) CreateAlias(ctx context.Context, kind string, input models.TaxonomyAliasInput) (*models.TaxonomyAlias, error) {
+	aliasName := strings.TrimSpace(input.Alias)
+	if aliasName == "" || input.Label == "" {
+		return nil, fmt.Errorf("%w: alias and label are required", ErrInvalidInput)
+	}
+	if s.isMapped(kind, aliasName) {
+		return nil, fmt.Errorf("%w: %s label %q is already mapped", ErrConflict, kind, aliasName)
+	}
+
+	labels, err := s.repo.GetLabels(ctx, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	var labelID string
+	for _, label := range labels {
+		if label.Name == input.Label {
+			labelID = label.ID
+			break
+		}
+	}
+	if labelID == "" {
+		return nil, fmt.Errorf("%w: %s label %q does not exist", ErrInvalidInput, kind, input.Label)
+	}
+
+	alias := &models.TaxonomyAlias{
+		LabelID: labelID,
+		Kind:    kind,
+		Alias:   aliasName,
+	}
+	if err := s.repo.CreateAlias(ctx, alias); err != nil {
+		if errors.Is(err, repository.ErrDuplicate) {
+			return nil, fmt.Errorf("%w: %s label %q is already mapped", ErrConflict, kind, aliasName)
+		}
+		return nil, fmt.Errorf("error creating alias: %w", err)
+	}
+
+	if err := s.reload(ctx); err != nil {
+		return nil, err
+	}
+
+	return alias, nil
+}
+
+// DeleteAlias elimina un alias
+func (s *taxonomyService) DeleteAlias(ctx context.Context, kind, id string) error {
+	if _, err := uuid.Parse(id); err != nil {
+		return fmt.Errorf("%w: %s alias %s", ErrNotFound, kind, id)
+	}
+
+	alias, err := s.repo.GetAliasByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if alias == nil || alias.Kind != kind {
+		return fmt.Errorf("%w: %s alias %s", ErrNotFound, kind, id)
+	}
+
+	if err := s.repo.DeleteAlias(ctx, id); err != nil {
+		return fmt.Errorf("error deleting alias: %w", err)
+	}
+
+	return s.reload(ctx)
+}
+
+// ListUnmapped obtiene las etiquetas vistas durante la sincronización que siguen sin puntuación
+func (s *taxonomyService) ListUnmapped(ctx context.Context, kind string) ([]models.UnmappedLabel, error) {
+	labels, err := s.repo.GetUnmapped(ctx, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	// Excluir las etiquetas que se asignaron después de ser registradas
+	unmapped := make([]models.UnmappedLabel, 0, len(labels))
+	for _, label := range labels {
+		if !s.isMapped(kind, label.Label) {
+			unmapped = append(unmapped, label)
+		}
+	}
+
+	return unmapped, nil
+}
+
+// getLabel obtiene una etiqueta canónica de un tipo, o ErrNotFound si no existe
+func (s *taxonomyService) getLabel(ctx context.Context, kind, id string) (*models.TaxonomyLabel, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, fmt.Errorf("%w: %s label %s", ErrNotFound, kind, id)
+	}
+
+	label, err := s.repo.GetLabelByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if label == nil || label.Kind != kind {
+		return nil, fmt.Errorf("%w: %s label %s", ErrNotFound, kind, id)
+	}
+
+	return label, nil
+}
+
+// afterChange recarga la taxonomía y devuelve la etiqueta modificada
+func (s *taxonomyService) afterChange(ctx context.Context, kind, name string) (*models.TaxonomyLabel, error) {
+	if err := s.reload(ctx); err != nil {
+		return nil, err
+	}
+
+	labels, err := s.repo.GetLabels(ctx, kind)
+	if err != nil {
+		return nil, err
+	}
+	for i := range labels {
+		if labels[i].Name == name {
+			return &labels[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s label %q", ErrNotFound, kind, name)
+}
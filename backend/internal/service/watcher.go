@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/liferip/stock-analyzer/backend/internal/events"
+	"github.com/liferip/stock-analyzer/backend/internal/models"
+)
+
+// watcherTimeout es el tiempo máximo para comparar las recomendaciones después de una sincronización
+const watcherTimeout = time.Minute
+
+// RegisterRecommendationWatcher compara la lista de recomendaciones después de cada sincronización
+// con la anterior y publica RecommendationsChanged cuando entra o sale algún ticker. La
+// comparación se hace en segundo plano, fuera de la solicitud que sincronizó.
+func RegisterRecommendationWatcher(bus *events.Bus, stockService StockService, logger *zap.Logger) {
+	logger = logger.Named("recommendation_watcher")
+
+	var mu sync.Mutex
+	var previous []string
+
+	// Tomar la lista actual como referencia para la primera sincronización
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if recommendations, err := stockService.GetRecommendations(ctx, SortByScore); err != nil {
+		logger.Warn("Error loading initial recommendations", zap.Error(err))
+	} else {
+		previous = recommendationTickers(recommendations)
+	}
+
+	bus.SubscribeAsync(watcherTimeout, func(ctx context.Context, event events.Event) {
+		if event.Type != events.SyncCompleted {
+			return
+		}
+
+		recommendations, err := stockService.GetRecommendations(ctx, SortByScore)
+		if err != nil {
+			logger.Error("Error getting recommendations", zap.Error(err))
+			return
+		}
+
+		current := recommendationTickers(recommendations)
+
+		mu.Lock()
+		entered := tickersMissingFrom(current, previous)
+		left := tickersMissingFrom(previous, current)
+		previous = current
+		mu.Unlock()
+
+		if len(entered) == 0 && len(left) == 0 {
+			return
+		}
+
+		bus.Publish(ctx, events.RecommendationsChanged, events.RecommendationsChangedPayload{
+			Recommendations: recommendations,
+			Entered:         entered,
+			Left:            left,
+		})
+	})
+}
+
+// recommendationTickers obtiene los tickers de una lista de recomendaciones en orden
+func recommendationTickers(recommendations []models.StockRecommendation) []string {
+	tickers := make([]string, 0, len(recommendations))
+	for _, recommendation := range recommendations {
+		tickers = append(tickers, recommendation.Stock.Ticker)
+	}
+	return tickers
+}
+
+// tickersMissingFrom devuelve los tickers de una lista que no aparecen en la otra
+func tickersMissingFrom(tickers, other []string) []string {
+	present := make(map[string]bool, len(other))
+	for _, ticker := range other {
+		present[ticker] = true
+	}
+
+	missing := []string{}
+	for _, ticker := range tickers {
+		if !present[ticker] {
+			missing = append(missing, ticker)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,519 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"github.com/liferip/stock-analyzer/backend/config"
+	"github.com/liferip/stock-analyzer/backend/internal/events"
+	"github.com/liferip/stock-analyzer/backend/internal/models"
+	"github.com/liferip/stock-analyzer/backend/internal/repository"
+	"github.com/liferip/stock-analyzer/backend/pkg/logger"
+)
+
+const (
+	// webhookPollInterval es cada cuánto el worker revisa las entregas pendientes
+	webhookPollInterval = 5 * time.Second
+	// webhookBatchSize es la cantidad máxima de entregas procesadas por revisión
+	webhookBatchSize = 50
+	// webhookTimeout es el tiempo máximo de espera de la respuesta de un webhook
+	webhookTimeout = 10 * time.Second
+	// webhookEnqueueTimeout es el tiempo máximo para encolar las entregas de un evento
+	webhookEnqueueTimeout = 30 * time.Second
+	// webhookMaxBackoff limita la espera entre reintentos
+	webhookMaxBackoff = time.Hour
+	// defaultDeliveryLimit es la cantidad de entregas devueltas por defecto
+	defaultDeliveryLimit = 100
+	// maxDeliveryLimit es la cantidad máxima de entregas devueltas en una consulta
+	maxDeliveryLimit = 1000
+
+	// WebhookPing es el evento de prueba enviado a pedido del usuario
+	WebhookPing = "webhook.ping"
+)
+
+// Cabeceras enviadas en cada entrega de webhook
+const (
+	WebhookHeaderDelivery  = "X-Webhook-Delivery"
+	WebhookHeaderEvent     = "X-Webhook-Event"
+	WebhookHeaderTimestamp = "X-Webhook-Timestamp"
+	WebhookHeaderSignature = "X-Webhook-Signature"
+)
+
+// WebhookService interfaz que define las operaciones sobre webhooks y la entrega de eventos
+type WebhookService interface {
+	ListWebhooks(ctx context.Context) ([]models.Webhook, error)
+	GetWebhook(ctx context.Context, id string) (*models.Webhook, error)
+	CreateWebhook(ctx context.Context, input models.WebhookInput) (*models.Webhook, string, error)
+	UpdateWebhook(ctx context.Context, id string, input models.WebhookInput) (*models.Webhook, error)
+	DeleteWebhook(ctx context.Context, id string) error
+	PingWebhook(ctx context.Context, id string) (*models.WebhookDelivery, error)
+	ListDeliveries(ctx context.Context, filter models.WebhookDeliveryFilter) ([]models.WebhookDelivery, error)
+	Enqueue(ctx context.Context, event events.Event) error
+	Run(ctx context.Context)
+}
+
+// webhookService implementación de WebhookService con un worker en segundo plano
+type webhookService struct {
+	repo        repository.WebhookRepository
+	client      *http.Client
+	maxAttempts int
+	backoff     time.Duration
+	logger      *zap.Logger
+
+	wake chan struct{}
+
+	mu       sync.RWMutex
+	webhooks []models.Webhook
+}
+
+// NewWebhookService crea una nueva instancia de WebhookService
+func NewWebhookService(repo repository.WebhookRepository, cfg *config.Config, logger *zap.Logger) (WebhookService, error) {
+	s := &webhookService{
+		repo: repo,
+		client: &http.Client{
+			Timeout: webhookTimeout,
+		},
+		maxAttempts: cfg.WebhookMaxAttempts,
+		backoff:     cfg.WebhookBackoff,
+		logger:      logger.Named("webhook_service"),
+		wake:        make(chan struct{}, 1),
+	}
+	if s.maxAttempts <= 0 {
+		s.maxAttempts = 1
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := s.reload(ctx); err != nil {
+		return nil, fmt.Errorf("error loading webhooks: %w", err)
+	}
+
+	return s, nil
+}
+
+// RegisterWebhookDispatcher encola en segundo plano los eventos del bus para los webhooks
+// suscritos y ejecuta el worker de entregas mientras la aplicación está activa
+func RegisterWebhookDispatcher(lc fx.Lifecycle, bus *events.Bus, webhookService WebhookService, logger *zap.Logger) {
+	logger = logger.Named("webhook_dispatcher")
+
+	bus.SubscribeAsync(webhookEnqueueTimeout, func(ctx context.Context, event events.Event) {
+		if err := webhookService.Enqueue(ctx, event); err != nil {
+			logger.Error("Error enqueuing webhook deliveries",
+				zap.String("event", event.Type),
+				zap.Error(err))
+		}
+	})
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			go func() {
+				defer close(done)
+				webhookService.Run(runCtx)
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		},
+	})
+}
+
+// reload vuelve a cargar los webhooks activos en memoria
+func (s *webhookService) reload(ctx context.Context) error {
+	webhooks, err := s.repo.GetWebhooks(ctx)
+	if err != nil {
+		return err
+	}
+
+	enabled := make([]models.Webhook, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		if webhook.Enabled {
+			enabled = append(enabled, webhook)
+		}
+	}
+
+	s.mu.Lock()
+	s.webhooks = enabled
+	s.mu.Unlock()
+
+	return nil
+}
+
+// ListWebhooks obtiene todos los webhooks registrados
+func (s *webhookService) ListWebhooks(ctx context.Context) ([]models.Webhook, error) {
+	return s.repo.GetWebhooks(ctx)
+}
+
+// GetWebhook obtiene un webhook, o ErrNotFound si no existe
+func (s *webhookService) GetWebhook(ctx context.Context, id string) (*models.Webhook, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, fmt.Errorf("%w: webhook %s", ErrNotFound, id)
+	}
+
+	webhook, err := s.repo.GetWebhookByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if webhook == nil {
+		return nil, fmt.Errorf("%w: webhook %s", ErrNotFound, id)
+	}
+
+	return webhook, nil
+}
+
+// CreateWebhook registra un webhook y devuelve su secreto; si no se indica uno se genera
+func (s *webhookService) CreateWebhook(ctx context.Context, input models.WebhookInput) (*models.Webhook, string, error) {
+	webhook := &models.Webhook{Enabled: true}
+	if err := applyWebhookInput(webhook, input); err != nil {
+		return nil, "", err
+	}
+
+	if webhook.Secret == "" {
+		secret, err := generateSecret()
+		if err != nil {
+			return nil, "", fmt.Errorf("error generating webhook secret: %w", err)
+		}
+		webhook.Secret = secret
+	}
+
+	if err := s.repo.CreateWebhook(ctx, webhook); err != nil {
+		return nil, "", fmt.Errorf("error creating webhook: %w", err)
+	}
+
+	return webhook, webhook.Secret, s.reload(ctx)
+}
+
+// UpdateWebhook reemplaza la URL y los eventos de un webhook; el secreto solo cambia si se indica
+func (s *webhookService) UpdateWebhook(ctx context.Context, id string, input models.WebhookInput) (*models.Webhook, error) {
+	webhook, err := s.GetWebhook(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyWebhookInput(webhook, input); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateWebhook(ctx, webhook); err != nil {
+		return nil, fmt.Errorf("error updating webhook: %w", err)
+	}
+
+	return webhook, s.reload(ctx)
+}
+
+// DeleteWebhook elimina un webhook; el registro de entregas ya realizadas se conserva
+func (s *webhookService) DeleteWebhook(ctx context.Context, id string) error {
+	if _, err := s.GetWebhook(ctx, id); err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteWebhook(ctx, id); err != nil {
+		return fmt.Errorf("error deleting webhook: %w", err)
+	}
+
+	return s.reload(ctx)
+}
+
+// PingWebhook encola un evento de prueba para un webhook
+func (s *webhookService) PingWebhook(ctx context.Context, id string) (*models.WebhookDelivery, error) {
+	webhook, err := s.GetWebhook(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := json.Marshal(events.Event{
+		Type:    WebhookPing,
+		Time:    time.Now(),
+		Payload: map[string]string{"webhook_id": webhook.ID},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error encoding webhook payload: %w", err)
+	}
+
+	deliveries := []models.WebhookDelivery{newDelivery(webhook.ID, WebhookPing, payload)}
+	if err := s.repo.CreateDeliveries(ctx, deliveries); err != nil {
+		return nil, fmt.Errorf("error enqueuing webhook delivery: %w", err)
+	}
+	s.notify()
+
+	return &deliveries[0], nil
+}
+
+// ListDeliveries obtiene el registro de entregas, de la más reciente a la más antigua
+func (s *webhookService) ListDeliveries(ctx context.Context, filter models.WebhookDeliveryFilter) ([]models.WebhookDelivery, error) {
+	switch filter.Status {
+	case "", models.WebhookDeliveryPending, models.WebhookDeliverySucceeded, models.WebhookDeliveryFailed:
+	default:
+		return nil, fmt.Errorf("%w: invalid status %q", ErrInvalidInput, filter.Status)
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultDeliveryLimit
+	}
+	if filter.Limit > maxDeliveryLimit {
+		filter.Limit = maxDeliveryLimit
+	}
+
+	return s.repo.GetDeliveries(ctx, filter)
+}
+
+// Enqueue crea una entrega pendiente del evento para cada webhook activo suscrito a él
+func (s *webhookService) Enqueue(ctx context.Context, event events.Event) error {
+	s.mu.RLock()
+	webhooks := s.webhooks
+	s.mu.RUnlock()
+
+	var subscribed []models.Webhook
+	for _, webhook := range webhooks {
+		if len(webhook.Events) == 0 || containsFold(webhook.Events, event.Type) {
+			subscribed = append(subscribed, webhook)
+		}
+	}
+	if len(subscribed) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("error encoding webhook payload: %w", err)
+	}
+
+	deliveries := make([]models.WebhookDelivery, 0, len(subscribed))
+	for _, webhook := range subscribed {
+		deliveries = append(deliveries, newDelivery(webhook.ID, event.Type, payload))
+	}
+
+	if err := s.repo.CreateDeliveries(ctx, deliveries); err != nil {
+		return err
+	}
+	s.notify()
+
+	return nil
+}
+
+// Run procesa las entregas pendientes hasta que se cancele el contexto
+func (s *webhookService) Run(ctx context.Context) {
+	logger.Ctx(ctx, s.logger).Info("Webhook worker started")
+	defer logger.Ctx(ctx, s.logger).Info("Webhook worker stopped")
+
+	ticker := time.NewTicker(webhookPollInterval)
+	defer ticker.Stop()
+
+	for {
+		s.deliverDue(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-s.wake:
+		}
+	}
+}
+
+// notify despierta al worker sin bloquear si ya tiene un aviso pendiente
+func (s *webhookService) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// deliverDue envía las entregas pendientes cuyo próximo intento ya venció
+func (s *webhookService) deliverDue(ctx context.Context) {
+	deliveries, err := s.repo.GetDueDeliveries(ctx, time.Now(), webhookBatchSize)
+	if err != nil {
+		if ctx.Err() == nil {
+			logger.Ctx(ctx, s.logger).Error("Error getting due webhook deliveries", zap.Error(err))
+		}
+		return
+	}
+
+	webhooks := make(map[string]*models.Webhook)
+	for i := range deliveries {
+		if ctx.Err() != nil {
+			return
+		}
+
+		delivery := &deliveries[i]
+		webhook, ok := webhooks[delivery.WebhookID]
+		if !ok {
+			if webhook, err = s.repo.GetWebhookByID(ctx, delivery.WebhookID); err != nil {
+				continue
+			}
+			webhooks[delivery.WebhookID] = webhook
+		}
+
+		s.attempt(ctx, webhook, delivery)
+	}
+}
+
+// attempt realiza un intento de entrega y programa el siguiente si falla
+func (s *webhookService) attempt(ctx context.Context, webhook *models.Webhook, delivery *models.WebhookDelivery) {
+	now := time.Now()
+	delivery.Attempts++
+
+	var statusCode int
+	var err error
+	switch {
+	case webhook == nil:
+		err = fmt.Errorf("webhook no longer exists")
+		delivery.Attempts = s.maxAttempts
+	case !webhook.Enabled:
+		err = fmt.Errorf("webhook is disabled")
+		delivery.Attempts = s.maxAttempts
+	default:
+		statusCode, err = s.send(ctx, webhook, delivery, now)
+	}
+
+	delivery.LastStatusCode = statusCode
+	switch {
+	case err == nil:
+		delivery.Status = models.WebhookDeliverySucceeded
+		delivery.LastError = ""
+		delivery.DeliveredAt = &now
+	case delivery.Attempts >= s.maxAttempts:
+		delivery.Status = models.WebhookDeliveryFailed
+		delivery.LastError = err.Error()
+		logger.Ctx(ctx, s.logger).Warn("Webhook delivery failed",
+			zap.String("delivery_id", delivery.ID),
+			zap.String("webhook_id", delivery.WebhookID),
+			zap.Int("attempts", delivery.Attempts),
+			zap.Error(err))
+	default:
+		delivery.LastError = err.Error()
+		delivery.NextAttemptAt = now.Add(s.backoffFor(delivery.Attempts))
+	}
+
+	// Guardar el resultado aunque el worker se esté deteniendo
+	saveCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+	if err := s.repo.UpdateDelivery(saveCtx, delivery); err != nil {
+		logger.Ctx(ctx, s.logger).Error("Error saving webhook delivery", zap.String("delivery_id", delivery.ID), zap.Error(err))
+	}
+}
+
+// send envía el payload firmado al webhook y devuelve el código de estado recibido
+func (s *webhookService) send(ctx context.Context, webhook *models.Webhook, delivery *models.WebhookDelivery, now time.Time) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewBufferString(delivery.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("error creating request: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "stock-analyzer-webhooks")
+	req.Header.Set(WebhookHeaderDelivery, delivery.ID)
+	req.Header.Set(WebhookHeaderEvent, delivery.EventType)
+	req.Header.Set(WebhookHeaderTimestamp, timestamp)
+	req.Header.Set(WebhookHeaderSignature, SignWebhookPayload(webhook.Secret, timestamp, []byte(delivery.Payload)))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("error sending request: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("webhook responded with status code %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// backoffFor calcula la espera exponencial antes del siguiente intento
+func (s *webhookService) backoffFor(attempts int) time.Duration {
+	wait := s.backoff
+	for i := 1; i < attempts && wait < webhookMaxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > webhookMaxBackoff {
+		wait = webhookMaxBackoff
+	}
+	return wait
+}
+
+// SignWebhookPayload calcula la firma HMAC-SHA256 de "<timestamp>.<payload>" con el secreto del webhook,
+// en el formato enviado en la cabecera X-Webhook-Signature
+func SignWebhookPayload(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// newDelivery crea una entrega pendiente lista para el primer intento
+func newDelivery(webhookID, eventType string, payload []byte) models.WebhookDelivery {
+	return models.WebhookDelivery{
+		ID:            uuid.New().String(),
+		WebhookID:     webhookID,
+		EventType:     eventType,
+		Payload:       string(payload),
+		Status:        models.WebhookDeliveryPending,
+		NextAttemptAt: time.Now(),
+	}
+}
+
+// applyWebhookInput valida y copia los datos de entrada en un webhook
+func applyWebhookInput(webhook *models.Webhook, input models.WebhookInput) error {
+	target, err := url.Parse(strings.TrimSpace(input.URL))
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return fmt.Errorf("%w: url must be an absolute http or https URL", ErrInvalidInput)
+	}
+
+	for _, eventType := range input.Events {
+		if !containsFold(events.Types, eventType) {
+			return fmt.Errorf("%w: unknown event %q, expected one of %s",
+				ErrInvalidInput, eventType, strings.Join(events.Types, ", "))
+		}
+	}
+
+	webhook.URL = target.String()
+	webhook.Events = input.Events
+	if input.Secret != "" {
+		webhook.Secret = input.Secret
+	}
+	if input.Enabled != nil {
+		webhook.Enabled = *input.Enabled
+	}
+
+	return nil
+}
+
+// generateSecret genera un secreto aleatorio para firmar los webhooks
+func generateSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,127 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+
+	"github.com/liferip/stock-analyzer/backend/config"
+)
+
+// tracer crea los spans de las consultas a la base de datos
+var tracer = otel.Tracer("github.com/liferip/stock-analyzer/backend/internal/tracing")
+
+// parentContextKey guarda en la sentencia el contexto anterior al span de la operación
+const parentContextKey = "tracing:parent_context"
+
+// RegisterGORM agrega un span por cada consulta de GORM, hijo del span del contexto con el que
+// el repositorio ejecuta la consulta
+func RegisterGORM(cfg *config.Config, db *gorm.DB) error {
+	if !Enabled(cfg) {
+		return nil
+	}
+	return db.Use(gormPlugin{})
+}
+
+// gormPlugin registra los callbacks de trazado en cada tipo de operación de GORM
+type gormPlugin struct{}
+
+// Name devuelve el nombre del plugin
+func (gormPlugin) Name() string {
+	return "tracing"
+}
+
+// Initialize registra los callbacks antes y después de cada operación
+func (gormPlugin) Initialize(db *gorm.DB) error {
+	callbacks := db.Callback()
+
+	for _, register := range []struct {
+		operation string
+		before    func(string, func(*gorm.DB)) error
+		after     func(string, func(*gorm.DB)) error
+	}{
+		{"INSERT", callbacks.Create().Before("gorm:create").Register, callbacks.Create().After("gorm:create").Register},
+		{"SELECT", callbacks.Query().Before("gorm:query").Register, callbacks.Query().After("gorm:query").Register},
+		{"UPDATE", callbacks.Update().Before("gorm:update").Register, callbacks.Update().After("gorm:update").Register},
+		{"DELETE", callbacks.Delete().Before("gorm:delete").Register, callbacks.Delete().After("gorm:delete").Register},
+		{"SELECT", callbacks.Row().Before("gorm:row").Register, callbacks.Row().After("gorm:row").Register},
+		{"RAW", callbacks.Raw().Before("gorm:raw").Register, callbacks.Raw().After("gorm:raw").Register},
+	} {
+		operation := register.operation
+		if err := register.before("tracing:before_"+strings.ToLower(operation), func(tx *gorm.DB) {
+			startSpan(tx, operation)
+		}); err != nil {
+			return err
+		}
+		if err := register.after("tracing:after_"+strings.ToLower(operation), func(tx *gorm.DB) {
+			endSpan(tx, operation)
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// startSpan inicia el span de una operación y lo guarda en el contexto de la sentencia
+func startSpan(tx *gorm.DB, operation string) {
+	if tx.Statement == nil || tx.Statement.Context == nil {
+		return
+	}
+
+	tx.InstanceSet(parentContextKey, tx.Statement.Context)
+	ctx, _ := tracer.Start(tx.Statement.Context, operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", dbSystem(tx)),
+			attribute.String("db.operation.name", operation),
+		))
+	tx.Statement.Context = ctx
+}
+
+// dbSystem obtiene el nombre de la base de datos según el driver de GORM
+func dbSystem(tx *gorm.DB) string {
+	if tx.Dialector != nil && tx.Dialector.Name() == "sqlite" {
+		return "sqlite"
+	}
+	return "cockroachdb"
+}
+
+// endSpan completa el span con la consulta, la tabla, las filas afectadas y el error, si hubo.
+// La sentencia vuelve al contexto anterior, porque los repositorios reutilizan la misma
+// consulta para contar y para buscar.
+func endSpan(tx *gorm.DB, operation string) {
+	if tx.Statement == nil || tx.Statement.Context == nil {
+		return
+	}
+
+	span := trace.SpanFromContext(tx.Statement.Context)
+	if parent, ok := tx.InstanceGet(parentContextKey); ok {
+		tx.Statement.Context = parent.(context.Context)
+	}
+	if !span.IsRecording() {
+		return
+	}
+	defer span.End()
+
+	// La consulta se registra sin los valores de los parámetros
+	span.SetAttributes(
+		attribute.String("db.query.text", tx.Statement.SQL.String()),
+		attribute.Int64("db.rows_affected", tx.Statement.RowsAffected),
+	)
+	if table := tx.Statement.Table; table != "" {
+		span.SetAttributes(attribute.String("db.collection.name", table))
+		span.SetName(operation + " " + table)
+	}
+
+	if tx.Error != nil && !errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+		span.RecordError(tx.Error)
+		span.SetStatus(codes.Error, tx.Error.Error())
+	}
+}
@@ -0,0 +1,111 @@
+// Package tracing configura el trazado distribuido con OpenTelemetry y la propagación del
+// contexto de traza de W3C.
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"github.com/liferip/stock-analyzer/backend/config"
+)
+
+// Module configura el trazado al iniciar la aplicación
+var Module = fx.Invoke(Register, RegisterGORM)
+
+// Exportadores de trazas disponibles
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+)
+
+// serviceName es el nombre del servicio en las trazas si no se define OTEL_SERVICE_NAME
+const serviceName = "stock-analyzer"
+
+// Register configura el propagador de W3C (traceparent y baggage) y, si TRACING_EXPORTER no es
+// none, el proveedor global de trazas con su exportador. Las trazas pendientes se envían al
+// detener la aplicación.
+func Register(lc fx.Lifecycle, cfg *config.Config, logger *zap.Logger) error {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	if !Enabled(cfg) {
+		return nil
+	}
+
+	logger = logger.Named("tracing")
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		logger.Warn("OpenTelemetry error", zap.Error(err))
+	}))
+
+	exporter, err := newExporter(cfg)
+	if err != nil {
+		return err
+	}
+
+	// Los atributos de OTEL_RESOURCE_ATTRIBUTES y OTEL_SERVICE_NAME reemplazan a los por defecto
+	res, err := resource.New(context.Background(),
+		resource.WithAttributes(attribute.String("service.name", serviceName)),
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+	)
+	if err != nil {
+		return fmt.Errorf("error creating tracing resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.TracingSampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	lc.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			return provider.Shutdown(ctx)
+		},
+	})
+
+	logger.Info("Tracing enabled",
+		zap.String("exporter", cfg.TracingExporter),
+		zap.Float64("sample_ratio", cfg.TracingSampleRatio))
+	return nil
+}
+
+// Enabled indica si hay un exportador de trazas configurado
+func Enabled(cfg *config.Config) bool {
+	return cfg.TracingExporter != "" && cfg.TracingExporter != ExporterNone
+}
+
+// newExporter crea el exportador configurado en TRACING_EXPORTER. El exportador OTLP usa HTTP y
+// se configura con las variables estándar, como OTEL_EXPORTER_OTLP_ENDPOINT.
+func newExporter(cfg *config.Config) (sdktrace.SpanExporter, error) {
+	switch cfg.TracingExporter {
+	case ExporterStdout:
+		exporter, err := stdouttrace.New(stdouttrace.WithPrettyPrint())
+		if err != nil {
+			return nil, fmt.Errorf("error creating stdout trace exporter: %w", err)
+		}
+		return exporter, nil
+	case ExporterOTLP:
+		exporter, err := otlptracehttp.New(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("error creating OTLP trace exporter: %w", err)
+		}
+		return exporter, nil
+	default:
+		return nil, fmt.Errorf("unknown TRACING_EXPORTER %q, expected 'none', 'stdout' or 'otlp'", cfg.TracingExporter)
+	}
+}
@@ -0,0 +1,120 @@
+package httpclient
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen indica que no se llamó a la API externa porque falló demasiadas veces seguidas
+var ErrCircuitOpen = errors.New("circuit breaker open: external API unavailable")
+
+// CircuitState es el estado del circuito de la API externa
+type CircuitState string
+
+const (
+	// CircuitClosed deja pasar las llamadas
+	CircuitClosed CircuitState = "closed"
+	// CircuitOpen rechaza las llamadas hasta que pase el tiempo de espera
+	CircuitOpen CircuitState = "open"
+	// CircuitHalfOpen deja pasar una llamada de prueba; si funciona, el circuito se cierra
+	CircuitHalfOpen CircuitState = "half_open"
+)
+
+// CircuitStatus describe el circuito en un momento dado
+type CircuitStatus struct {
+	State               CircuitState `json:"state"`
+	ConsecutiveFailures int          `json:"consecutive_failures"`
+	// OpenedAt es cuándo se abrió el circuito; nil si está cerrado
+	OpenedAt *time.Time `json:"opened_at,omitempty"`
+}
+
+// breaker abre el circuito después de threshold fallas seguidas y, pasado cooldown, permite
+// una llamada de prueba. Un threshold de cero no abre nunca el circuito.
+type breaker struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// newBreaker crea un circuito cerrado
+func newBreaker(threshold int, cooldown time.Duration) *breaker {
+	return &breaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+	}
+}
+
+// allow indica si se puede llamar a la API externa
+func (b *breaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state() {
+	case CircuitOpen:
+		return ErrCircuitOpen
+	case CircuitHalfOpen:
+		if b.probing {
+			return ErrCircuitOpen
+		}
+		b.probing = true
+	}
+	return nil
+}
+
+// record registra el resultado de una llamada
+func (b *breaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	if err == nil {
+		b.failures = 0
+		b.openedAt = time.Time{}
+		return
+	}
+
+	b.failures++
+	if b.threshold > 0 && b.failures >= b.threshold {
+		// Una prueba fallida vuelve a abrir el circuito por otro periodo de espera
+		b.openedAt = b.now()
+	}
+}
+
+// release libera la llamada de prueba sin registrar su resultado
+func (b *breaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
+
+// status obtiene el estado actual del circuito
+func (b *breaker) status() CircuitStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	status := CircuitStatus{State: b.state(), ConsecutiveFailures: b.failures}
+	if !b.openedAt.IsZero() {
+		openedAt := b.openedAt
+		status.OpenedAt = &openedAt
+	}
+	return status
+}
+
+// state calcula el estado del circuito; se llama con mu tomado
+func (b *breaker) state() CircuitState {
+	if b.openedAt.IsZero() {
+		return CircuitClosed
+	}
+	if b.now().Sub(b.openedAt) < b.cooldown {
+		return CircuitOpen
+	}
+	return CircuitHalfOpen
+}
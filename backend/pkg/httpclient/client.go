@@ -1,49 +1,98 @@
 package httpclient
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"time"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 
 	"github.com/liferip/stock-analyzer/backend/config"
+	"github.com/liferip/stock-analyzer/backend/internal/metrics"
 	"github.com/liferip/stock-analyzer/backend/internal/models"
 )
 
 // Module proporciona las dependencias del cliente HTTP
 var Module = fx.Provide(NewStockClient)
 
+// tracer crea los spans de las llamadas a la API externa
+var tracer = otel.Tracer("github.com/liferip/stock-analyzer/backend/pkg/httpclient")
+
 // StockClient es un cliente para la API de stocks
 type StockClient struct {
 	BaseURL    string
 	APIKey     string
 	HTTPClient *http.Client
+	breaker    *breaker
+	metrics    *metrics.Metrics
 	logger     *zap.Logger
 }
 
 // NewStockClient crea un nuevo cliente para la API de stocks
-func NewStockClient(cfg *config.Config, logger *zap.Logger) *StockClient {
+func NewStockClient(cfg *config.Config, metrics *metrics.Metrics, logger *zap.Logger) *StockClient {
 	return &StockClient{
-		BaseURL: cfg.APIEndpoint,
+		BaseURL: cfg.APIEndpoint.String(),
 		APIKey:  cfg.APIKey,
 		HTTPClient: &http.Client{
 			Timeout: 10 * time.Second,
 		},
-		logger: logger.Named("stock_client"),
+		breaker: newBreaker(cfg.UpstreamBreakerThreshold, cfg.UpstreamBreakerCooldown),
+		metrics: metrics,
+		logger:  logger.Named("stock_client"),
 	}
 }
 
 // GetStocks obtiene la lista de stocks desde la API externa
-func (c *StockClient) GetStocks(nextPage string) (*models.StockResponse, error) {
+func (c *StockClient) GetStocks(ctx context.Context, nextPage string) (*models.StockResponse, error) {
+	ctx, span := tracer.Start(ctx, "StockClient.GetStocks",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("stock_api.next_page", nextPage)))
+	defer span.End()
+
+	if err := c.breaker.allow(); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	response, err := c.getStocks(ctx, nextPage)
+	// Una solicitud cancelada no indica que la API externa esté fallando
+	if ctx.Err() == nil {
+		c.breaker.record(err)
+	} else {
+		c.breaker.release()
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("stock_api.items", len(response.Items)))
+	return response, nil
+}
+
+// CircuitStatus obtiene el estado del circuito de la API externa
+func (c *StockClient) CircuitStatus() CircuitStatus {
+	return c.breaker.status()
+}
+
+// getStocks obtiene una página de stocks, reintentando si la API externa responde con un error
+func (c *StockClient) getStocks(ctx context.Context, nextPage string) (*models.StockResponse, error) {
 	url := c.BaseURL
 	if nextPage != "" {
 		url = fmt.Sprintf("%s?next_page=%s", url, nextPage)
 	}
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("error creating request to external API: %w", err)
 	}
@@ -51,18 +100,24 @@ func (c *StockClient) GetStocks(nextPage string) (*models.StockResponse, error)
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.APIKey))
 	req.Header.Set("Content-Type", "application/json")
 
+	// Propagar la traza a la API externa con el header traceparent
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
 	maxAttempts := 5
 	var lastErr error
 
 	for i := 1; i <= maxAttempts; i++ {
+		start := time.Now()
 		resp, err := c.HTTPClient.Do(req)
 		if err != nil {
+			c.metrics.ObserveUpstreamRequest(0, time.Since(start))
 			lastErr = fmt.Errorf("error making request to external API (attempt %d/%d): %w",
 				i, maxAttempts, err)
 
 			return nil, lastErr
 		}
 		defer resp.Body.Close()
+		c.metrics.ObserveUpstreamRequest(resp.StatusCode, time.Since(start))
 
 		if resp.StatusCode != http.StatusOK {
 			lastErr = fmt.Errorf("error external API responded with a status code%d (attempt %d/%d)",
@@ -74,6 +129,10 @@ func (c *StockClient) GetStocks(nextPage string) (*models.StockResponse, error)
 					zap.Int("attempt", i),
 					zap.Int("max_attempts", maxAttempts),
 					zap.Error(lastErr))
+				c.metrics.IncUpstreamRetries()
+				trace.SpanFromContext(ctx).AddEvent("retry", trace.WithAttributes(
+					attribute.Int("attempt", i),
+					attribute.Int("http.response.status_code", resp.StatusCode)))
 				time.Sleep(5 * time.Second)
 				continue
 			}
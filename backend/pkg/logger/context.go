@@ -0,0 +1,25 @@
+package logger
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// contextKey es la clave del logger de la solicitud en el contexto
+type contextKey struct{}
+
+// WithContext devuelve una copia del contexto con el logger de la solicitud
+func WithContext(ctx context.Context, logger *zap.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, logger)
+}
+
+// Ctx devuelve el logger de un componente con los campos de la solicitud del contexto, como
+// request_id. Si el contexto no tiene logger de solicitud, devuelve el del componente.
+func Ctx(ctx context.Context, component *zap.Logger) *zap.Logger {
+	request, ok := ctx.Value(contextKey{}).(*zap.Logger)
+	if !ok || request == nil {
+		return component
+	}
+	return request.Named(component.Name())
+}
@@ -0,0 +1,206 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"github.com/liferip/stock-analyzer/backend/config"
+)
+
+// Module proporciona las dependencias del cliente SMTP
+var Module = fx.Provide(NewMailer)
+
+const (
+	// dialTimeout es el tiempo máximo para conectarse al servidor SMTP
+	dialTimeout = 10 * time.Second
+	// sendTimeout es el tiempo máximo de un envío completo si el contexto no tiene otro menor
+	sendTimeout = time.Minute
+)
+
+// Message representa un correo con versión HTML y de texto plano
+type Message struct {
+	To      []string
+	Subject string
+	HTML    string
+	Text    string
+}
+
+// Mailer envía correos a través de un servidor SMTP
+type Mailer struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+	logger   *zap.Logger
+}
+
+// NewMailer crea un nuevo cliente SMTP a partir de la configuración
+func NewMailer(cfg *config.Config, logger *zap.Logger) *Mailer {
+	return &Mailer{
+		Host:     cfg.SMTPHost,
+		Port:     strconv.Itoa(cfg.SMTPPort),
+		Username: cfg.SMTPUser,
+		Password: cfg.SMTPPass,
+		From:     cfg.SMTPFrom,
+		logger:   logger.Named("mailer"),
+	}
+}
+
+// Enabled indica si hay un servidor SMTP configurado
+func (m *Mailer) Enabled() bool {
+	return m.Host != ""
+}
+
+// Send envía un correo multipart/alternative con las versiones de texto plano y HTML. La
+// conexión se corta al vencer el contexto o sendTimeout, para no esperar a un servidor colgado.
+func (m *Mailer) Send(ctx context.Context, msg Message) error {
+	if !m.Enabled() {
+		return fmt.Errorf("SMTP is not configured")
+	}
+	if len(msg.To) == 0 {
+		return fmt.Errorf("no recipients")
+	}
+
+	body, err := m.build(msg)
+	if err != nil {
+		return fmt.Errorf("error building email: %w", err)
+	}
+
+	addr := net.JoinHostPort(m.Host, m.Port)
+	if err := m.send(ctx, addr, msg.To, body); err != nil {
+		return fmt.Errorf("error sending email via %s: %w", addr, err)
+	}
+
+	m.logger.Info("Email sent",
+		zap.String("subject", msg.Subject),
+		zap.Int("recipients", len(msg.To)))
+	return nil
+}
+
+// send entrega el mensaje como smtp.SendMail, con STARTTLS si el servidor lo admite, pero con
+// tiempos máximos de conexión y de lectura y escritura
+func (m *Mailer) send(ctx context.Context, addr string, to []string, body []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+
+	dialer := net.Dialer{Timeout: dialTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	deadline, _ := ctx.Deadline()
+	if err := conn.SetDeadline(deadline); err != nil {
+		return err
+	}
+	// Cortar la conexión si se cancela el contexto antes del plazo
+	stop := context.AfterFunc(ctx, func() { conn.SetDeadline(time.Now()) })
+	defer stop()
+
+	client, err := smtp.NewClient(conn, m.Host)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: m.Host}); err != nil {
+			return err
+		}
+	}
+
+	// Sin usuario se envía sin autenticación, por ejemplo a un servidor SMTP local de pruebas
+	if m.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", m.Username, m.Password, m.Host)); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(m.From); err != nil {
+		return err
+	}
+	for _, recipient := range to {
+		if err := client.Rcpt(recipient); err != nil {
+			return err
+		}
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := writer.Write(body); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+// build arma el mensaje MIME con las cabeceras y ambas versiones del contenido
+func (m *Mailer) build(msg Message) ([]byte, error) {
+	boundary, err := randomBoundary()
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", m.From)
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(msg.To, ", "))
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&buf, "Content-Type: multipart/alternative; boundary=%q\r\n\r\n", boundary)
+
+	parts := []struct {
+		contentType string
+		content     string
+	}{
+		{"text/plain", msg.Text},
+		{"text/html", msg.HTML},
+	}
+	for _, part := range parts {
+		fmt.Fprintf(&buf, "--%s\r\n", boundary)
+		fmt.Fprintf(&buf, "Content-Type: %s; charset=utf-8\r\n", part.contentType)
+		buf.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+
+		writer := quotedprintable.NewWriter(&buf)
+		if _, err := writer.Write([]byte(part.content)); err != nil {
+			return nil, err
+		}
+		if err := writer.Close(); err != nil {
+			return nil, err
+		}
+		buf.WriteString("\r\n")
+	}
+	fmt.Fprintf(&buf, "--%s--\r\n", boundary)
+
+	return buf.Bytes(), nil
+}
+
+// randomBoundary genera un separador aleatorio para las partes del mensaje
+func randomBoundary() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
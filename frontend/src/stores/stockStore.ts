@@ -4,16 +4,26 @@ import type { Stock, StockRecommendation } from "@/types";
 
 export const useStockStore = defineStore("stock", () => {
   const API_URL = import.meta.env.VITE_API_URL || "http://localhost:8081";
+  // API key sent with every request; it is embedded in the bundle, so use a dedicated key
+  const API_KEY = import.meta.env.VITE_API_KEY || "";
   const stocks = ref<Stock[]>([]);
   const recommendations = ref<StockRecommendation[]>([]);
   const isLoading = ref(false);
   const error = ref<string | null>(null);
 
+  const apiFetch = (path: string, init: RequestInit = {}) => {
+    const headers = new Headers(init.headers);
+    if (API_KEY) {
+      headers.set("X-API-Key", API_KEY);
+    }
+    return fetch(`${API_URL}${path}`, { ...init, headers });
+  };
+
   const fetchStocks = async () => {
     isLoading.value = true;
     error.value = null;
     try {
-      const response = await fetch(`${API_URL}/api/stock`);
+      const response = await apiFetch("/api/stock");
       const data = await response.json();
       if (!response.ok) {
         throw new Error(`HTTP error! Status: ${response.status}`);
@@ -32,7 +42,7 @@ export const useStockStore = defineStore("stock", () => {
     isLoading.value = true;
     error.value = null;
     try {
-      const response = await fetch(`${API_URL}/api/stock/ticker/${ticker}`);
+      const response = await apiFetch(`/api/stock/ticker/${ticker}`);
       const data = await response.json();
       return data.item;
     } catch (err) {
@@ -48,11 +58,11 @@ export const useStockStore = defineStore("stock", () => {
     isLoading.value = true;
     error.value = null;
     try {
-      const url = date
-        ? `${API_URL}/api/stock/recommendations?time=${date}`
-        : `${API_URL}/api/stock/recommendations`;
+      const path = date
+        ? `/api/stock/recommendations?time=${date}`
+        : "/api/stock/recommendations";
 
-      const response = await fetch(url);
+      const response = await apiFetch(path);
       const data = await response.json();
       if (!response.ok) {
         throw new Error(`HTTP error! Status: ${response.status}`);
@@ -72,9 +82,17 @@ export const useStockStore = defineStore("stock", () => {
     isLoading.value = true;
     error.value = null;
     try {
-      await fetch(`${API_URL}/api/stock/sync`, {
+      const response = await apiFetch("/api/stock/sync", {
         method: "POST",
       });
+      if (response.status === 401 || response.status === 403) {
+        error.value =
+          "Failed to sync stocks: VITE_API_KEY must be a key with the write:sync scope";
+        return false;
+      }
+      if (!response.ok) {
+        throw new Error(`HTTP error! Status: ${response.status}`);
+      }
       await fetchStocks();
       return true;
     } catch (err) {
@@ -13,9 +13,21 @@ export interface Stock {
   updated_at: string;
 }
 
+export interface ScoreFactor {
+  factor: "rating_change" | "action" | "target_change";
+  input: string;
+  value: number;
+  weight: number;
+  contribution: number;
+}
+
 export interface StockRecommendation {
   stock: Stock;
   score: number;
   reasons: string[];
-  potential_up: number;
+  breakdown: ScoreFactor[];
+  current_price?: number;
+  potential_up?: number;
+  above_target: boolean;
+  target_change?: number;
 }